@@ -0,0 +1,72 @@
+package beanimplement
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/bean"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/env"
+)
+
+const earthRadiusMeters = 6371000.0
+
+type speedProfile struct {
+	// average speed in km/h
+	speedKmh float64
+	// multiplier applied to the straight-line distance to approximate the real path length
+	detourFactor float64
+}
+
+type HaversineRoutingProvider struct {
+	profiles map[string]speedProfile
+}
+
+func NewHaversineRoutingProvider() bean.RoutingProvider {
+	return &HaversineRoutingProvider{
+		profiles: map[string]speedProfile{
+			model.TravelMode.Walk:    {speedKmh: speedFromEnv("ROUTING_WALK_SPEED_KMH", 4.8), detourFactor: 1.25},
+			model.TravelMode.Drive:   {speedKmh: speedFromEnv("ROUTING_DRIVE_SPEED_KMH", 25), detourFactor: 1.4},
+			model.TravelMode.Transit: {speedKmh: speedFromEnv("ROUTING_TRANSIT_SPEED_KMH", 18), detourFactor: 1.4},
+		},
+	}
+}
+
+func speedFromEnv(key string, defaultSpeed float64) float64 {
+	value, err := env.GetEnv(key)
+	if err != nil || value == "" {
+		return defaultSpeed
+	}
+	speed, err := strconv.ParseFloat(value, 64)
+	if err != nil || speed <= 0 {
+		log.Errorf("Invalid %s value %q, falling back to %v km/h", key, value, defaultSpeed)
+		return defaultSpeed
+	}
+	return speed
+}
+
+func (p *HaversineRoutingProvider) Estimate(ctx context.Context, from bean.LatLng, to bean.LatLng, mode string) (float64, time.Duration, error) {
+	profile, ok := p.profiles[mode]
+	if !ok {
+		return 0, 0, fmt.Errorf("unsupported travel mode: %s", mode)
+	}
+
+	distance := haversineMeters(from, to)
+	hours := distance * profile.detourFactor / 1000 / profile.speedKmh
+	return distance, time.Duration(hours * float64(time.Hour)).Round(time.Second), nil
+}
+
+func haversineMeters(from bean.LatLng, to bean.LatLng) float64 {
+	lat1 := from.Lat * math.Pi / 180
+	lat2 := to.Lat * math.Pi / 180
+	deltaLat := (to.Lat - from.Lat) * math.Pi / 180
+	deltaLong := (to.Long - from.Long) * math.Pi / 180
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLong/2)*math.Sin(deltaLong/2)
+	return 2 * earthRadiusMeters * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
@@ -0,0 +1,74 @@
+package beanimplement
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/bean"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+func TestSpeedFromEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		set   bool
+		value string
+		want  float64
+	}{
+		{"unset", false, "", 4.8},
+		{"empty", true, "", 4.8},
+		{"configured", true, "6", 6},
+		{"not a number", true, "fast", 4.8},
+		{"not positive", true, "0", 4.8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set {
+				t.Setenv("ROUTING_TEST_SPEED_KMH", tt.value)
+			}
+			if got := speedFromEnv("ROUTING_TEST_SPEED_KMH", 4.8); got != tt.want {
+				t.Errorf("speedFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHaversineRoutingProviderEstimate(t *testing.T) {
+	provider := &HaversineRoutingProvider{
+		profiles: map[string]speedProfile{
+			model.TravelMode.Walk:  {speedKmh: 5, detourFactor: 1},
+			model.TravelMode.Drive: {speedKmh: 40, detourFactor: 1.5},
+		},
+	}
+	// one degree of latitude is about 111.2km
+	from := bean.LatLng{Lat: 10, Long: 106}
+	to := bean.LatLng{Lat: 11, Long: 106}
+
+	tests := []struct {
+		name         string
+		mode         string
+		wantDistance float64
+		wantDuration time.Duration
+		wantErr      bool
+	}{
+		{"walk", model.TravelMode.Walk, 111195, 22*time.Hour + 14*time.Minute + 21*time.Second, false},
+		{"drive takes the detour", model.TravelMode.Drive, 111195, 4*time.Hour + 10*time.Minute + 11*time.Second, false},
+		{"unsupported mode", model.TravelMode.Transit, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance, duration, err := provider.Estimate(context.Background(), from, to, tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Estimate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.Abs(distance-tt.wantDistance) > 1 {
+				t.Errorf("Estimate() distance = %v, want %v", distance, tt.wantDistance)
+			}
+			if (duration - tt.wantDuration).Abs() > time.Second {
+				t.Errorf("Estimate() duration = %v, want %v", duration, tt.wantDuration)
+			}
+		})
+	}
+}
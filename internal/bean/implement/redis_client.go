@@ -3,6 +3,7 @@ package beanimplement
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/bean"
@@ -51,3 +52,52 @@ func (r *RedisService) Get(ctx context.Context, key string) (string, error) {
 func (r *RedisService) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
+
+func (r *RedisService) HSet(ctx context.Context, key string, field string, value interface{}, ttl time.Duration) error {
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, field, value)
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisService) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return r.client.HGetAll(ctx, key).Result()
+}
+
+func (r *RedisService) HDel(ctx context.Context, key string, field string) error {
+	return r.client.HDel(ctx, key, field).Err()
+}
+
+func (r *RedisService) Publish(ctx context.Context, channel string, message interface{}) error {
+	return r.client.Publish(ctx, channel, message).Err()
+}
+
+func (r *RedisService) Subscribe(ctx context.Context, channel string) <-chan string {
+	pubsub := r.client.Subscribe(ctx, channel)
+	messages := make(chan string)
+
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		// the channel of go-redis resubscribes by itself after a dropped connection
+		ch := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- message.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return messages
+}
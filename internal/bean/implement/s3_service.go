@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -152,3 +154,36 @@ func (s *S3Service) GenerateSignedDownloadURL(ctx context.Context, s3Key string,
 
 	return request.URL, nil
 }
+
+func (s *S3Service) Enabled() bool {
+	return s.enabled
+}
+
+func (s *S3Service) KeyFromURL(objectURL string) (string, bool) {
+	if !s.enabled {
+		return "", false
+	}
+	parsed, err := url.Parse(objectURL)
+	if err != nil || parsed.Host == "" {
+		return "", false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if !strings.HasSuffix(host, ".amazonaws.com") {
+		return "", false
+	}
+	path := strings.TrimPrefix(parsed.Path, "/")
+
+	var key string
+	if strings.HasPrefix(host, s.bucketName+".s3.") || strings.HasPrefix(host, s.bucketName+".s3-") {
+		// virtual-hosted style: https://<bucket>.s3.<region>.amazonaws.com/<key>
+		key = path
+	} else if strings.HasPrefix(host, "s3.") || strings.HasPrefix(host, "s3-") {
+		// path style: https://s3.<region>.amazonaws.com/<bucket>/<key>
+		var found bool
+		key, found = strings.CutPrefix(path, s.bucketName+"/")
+		if !found {
+			return "", false
+		}
+	}
+	return key, key != ""
+}
@@ -0,0 +1,38 @@
+package beanimplement
+
+import "testing"
+
+func TestS3ServiceKeyFromURL(t *testing.T) {
+	service := &S3Service{bucketName: "travel-app", prefix: "order-images/", enabled: true}
+
+	tests := []struct {
+		name    string
+		url     string
+		wantKey string
+		wantOK  bool
+	}{
+		{"virtual-hosted", "https://travel-app.s3.ap-southeast-1.amazonaws.com/order-images/beach1700000000.jpg", "order-images/beach1700000000.jpg", true},
+		{"virtual-hosted without region", "https://travel-app.s3.amazonaws.com/order-images/beach.jpg", "order-images/beach.jpg", true},
+		{"path style", "https://s3.ap-southeast-1.amazonaws.com/travel-app/order-images/beach.jpg", "order-images/beach.jpg", true},
+		{"escaped key", "https://travel-app.s3.amazonaws.com/order-images/my%20beach.jpg", "order-images/my beach.jpg", true},
+		{"another bucket", "https://other-bucket.s3.amazonaws.com/order-images/beach.jpg", "", false},
+		{"another bucket in path style", "https://s3.amazonaws.com/other-bucket/beach.jpg", "", false},
+		{"bucket root", "https://travel-app.s3.amazonaws.com/", "", false},
+		{"not s3", "https://images.example.com/travel-app/beach.jpg", "", false},
+		{"look-alike host", "https://travel-app.s3.amazonaws.com.example.com/beach.jpg", "", false},
+		{"bare key", "order-images/beach.jpg", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ok := service.KeyFromURL(tt.url)
+			if key != tt.wantKey || ok != tt.wantOK {
+				t.Errorf("KeyFromURL(%q) = (%q, %v), want (%q, %v)", tt.url, key, ok, tt.wantKey, tt.wantOK)
+			}
+		})
+	}
+
+	disabled := &S3Service{bucketName: "travel-app", enabled: false}
+	if key, ok := disabled.KeyFromURL("https://travel-app.s3.amazonaws.com/order-images/beach.jpg"); ok {
+		t.Errorf("disabled KeyFromURL = (%q, true), want false", key)
+	}
+}
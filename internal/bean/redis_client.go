@@ -1,9 +1,19 @@
 package bean
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type RedisClient interface {
 	Set(ctx context.Context, key string, value interface{}) error
 	Get(ctx context.Context, key string) (string, error)
 	Delete(ctx context.Context, key string) error
+	// HSet writes one field of a hash and pushes the expiry of the whole hash to ttl from now
+	HSet(ctx context.Context, key string, field string, value interface{}, ttl time.Duration) error
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+	HDel(ctx context.Context, key string, field string) error
+	Publish(ctx context.Context, channel string, message interface{}) error
+	// Subscribe delivers the payloads published on channel until ctx is done, reconnecting as needed
+	Subscribe(ctx context.Context, channel string) <-chan string
 }
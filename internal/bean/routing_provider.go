@@ -0,0 +1,17 @@
+package bean
+
+import (
+	"context"
+	"time"
+)
+
+type LatLng struct {
+	Lat  float64
+	Long float64
+}
+
+// RoutingProvider estimates how far apart two stops are and how long it takes to travel between them.
+// The default implementation works offline from straight-line distance; a real router can be plugged in later.
+type RoutingProvider interface {
+	Estimate(ctx context.Context, from LatLng, to LatLng, mode string) (distanceMeters float64, duration time.Duration, err error)
+}
@@ -11,4 +11,8 @@ type S3Service interface {
 	DeleteImage(ctx context.Context, imageURL string) error
 	GenerateSignedUploadURL(ctx context.Context, fileName string, contentType string) (string, string, error)
 	GenerateSignedDownloadURL(ctx context.Context, s3Key string, expiresIn time.Duration) (string, error)
+	// Enabled reports whether the service is configured, every other call fails when it is not
+	Enabled() bool
+	// KeyFromURL returns the object key of a URL pointing into the bucket, and false for any other URL
+	KeyFromURL(objectURL string) (string, bool)
 }
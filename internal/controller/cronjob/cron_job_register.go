@@ -9,39 +9,80 @@ import (
 )
 
 type CronJobRegister struct {
-	tripService service.TripService
-	cron        *cron.Cron
+	tripService          service.TripService
+	tripChecklistService service.TripChecklistService
+	tripPollService      service.TripPollService
+	syncService          service.SyncService
+	cron                 *cron.Cron
 }
 
-func NewCronJobRegister(tripService service.TripService) *CronJobRegister {
+func NewCronJobRegister(
+	tripService service.TripService,
+	tripChecklistService service.TripChecklistService,
+	tripPollService service.TripPollService,
+	syncService service.SyncService,
+) *CronJobRegister {
 	return &CronJobRegister{
-		tripService: tripService,
-		cron:        cron.New(),
+		tripService:          tripService,
+		tripChecklistService: tripChecklistService,
+		tripPollService:      tripPollService,
+		syncService:          syncService,
+		cron:                 cron.New(),
 	}
 }
 
+// jobs run hourly because every trip is evaluated at midnight of its own time zone
 func (c *CronJobRegister) RegisterJobs() {
-	c.cron.AddFunc("0 0 * * *", func() {
+	c.cron.AddFunc("0 * * * *", func() {
 		ctx := &gin.Context{}
 		err := c.tripService.UpdateStatusTripStart(ctx)
 		if err != nil {
 			log.Error("CronJobRegister.RegisterJobs - UpdateStatusTripStart Error: " + err.Error())
 		}
 	})
-	c.cron.AddFunc("0 0 * * *", func() {
+	c.cron.AddFunc("0 * * * *", func() {
 		ctx := &gin.Context{}
 		err := c.tripService.UpdateStatusTripEnd(ctx)
 		if err != nil {
 			log.Error("CronJobRegister.RegisterJobs - UpdateStatusTripEnd Error: " + err.Error())
 		}
 	})
-	c.cron.AddFunc("0 0 * * *", func() {
+	c.cron.AddFunc("0 * * * *", func() {
 		ctx := &gin.Context{}
 		err := c.tripService.SendTripStartReminders(ctx)
 		if err != nil {
 			log.Error("CronJobRegister.RegisterJobs - SendTripStartReminders Error: " + err.Error())
 		}
 	})
+	c.cron.AddFunc("0 * * * *", func() {
+		ctx := &gin.Context{}
+		err := c.tripChecklistService.SendChecklistReminders(ctx)
+		if err != nil {
+			log.Error("CronJobRegister.RegisterJobs - SendChecklistReminders Error: " + err.Error())
+		}
+	})
+	c.cron.AddFunc("0 3 * * *", func() {
+		ctx := &gin.Context{}
+		err := c.tripService.PurgeDeletedTrips(ctx)
+		if err != nil {
+			log.Error("CronJobRegister.RegisterJobs - PurgeDeletedTrips Error: " + err.Error())
+		}
+	})
+	c.cron.AddFunc("30 3 * * *", func() {
+		ctx := &gin.Context{}
+		err := c.syncService.PurgeTombstones(ctx)
+		if err != nil {
+			log.Error("CronJobRegister.RegisterJobs - PurgeTombstones Error: " + err.Error())
+		}
+	})
+	// poll deadlines are set by users to the minute, so they are checked more often
+	c.cron.AddFunc("*/5 * * * *", func() {
+		ctx := &gin.Context{}
+		err := c.tripPollService.CloseExpiredPolls(ctx)
+		if err != nil {
+			log.Error("CronJobRegister.RegisterJobs - CloseExpiredPolls Error: " + err.Error())
+		}
+	})
 }
 
 func (c *CronJobRegister) Start() {
@@ -24,6 +24,17 @@ type Server struct {
 	invitationTripHandler   *v1.InvitationTripHandler
 	tripMemberHandler       *v1.TripMemberHandler
 	tripImageHandler        *v1.TripImageHandler
+	tripExpenseHandler      *v1.TripExpenseHandler
+	exchangeRateHandler     *v1.ExchangeRateHandler
+	tripBookingHandler      *v1.TripBookingHandler
+	tripDocumentHandler     *v1.TripDocumentHandler
+	tripChecklistHandler    *v1.TripChecklistHandler
+	tripCommentHandler      *v1.TripCommentHandler
+	tripPollHandler         *v1.TripPollHandler
+	tripRealtimeHandler     *v1.TripRealtimeHandler
+	tripActivityHandler     *v1.TripActivityHandler
+	syncHandler             *v1.SyncHandler
+	tripBundleHandler       *v1.TripBundleHandler
 }
 
 func NewServer(authAuthHandler *v1.AuthHandler,
@@ -37,6 +48,17 @@ func NewServer(authAuthHandler *v1.AuthHandler,
 	invitationTripHandler *v1.InvitationTripHandler,
 	tripMemberHandler *v1.TripMemberHandler,
 	tripImageHandler *v1.TripImageHandler,
+	tripExpenseHandler *v1.TripExpenseHandler,
+	exchangeRateHandler *v1.ExchangeRateHandler,
+	tripBookingHandler *v1.TripBookingHandler,
+	tripDocumentHandler *v1.TripDocumentHandler,
+	tripChecklistHandler *v1.TripChecklistHandler,
+	tripCommentHandler *v1.TripCommentHandler,
+	tripPollHandler *v1.TripPollHandler,
+	tripRealtimeHandler *v1.TripRealtimeHandler,
+	tripActivityHandler *v1.TripActivityHandler,
+	syncHandler *v1.SyncHandler,
+	tripBundleHandler *v1.TripBundleHandler,
 ) *Server {
 	return &Server{
 		authAuthHandler:         authAuthHandler,
@@ -50,6 +72,17 @@ func NewServer(authAuthHandler *v1.AuthHandler,
 		invitationTripHandler:   invitationTripHandler,
 		tripMemberHandler:       tripMemberHandler,
 		tripImageHandler:        tripImageHandler,
+		tripExpenseHandler:      tripExpenseHandler,
+		exchangeRateHandler:     exchangeRateHandler,
+		tripBookingHandler:      tripBookingHandler,
+		tripDocumentHandler:     tripDocumentHandler,
+		tripChecklistHandler:    tripChecklistHandler,
+		tripCommentHandler:      tripCommentHandler,
+		tripPollHandler:         tripPollHandler,
+		tripRealtimeHandler:     tripRealtimeHandler,
+		tripActivityHandler:     tripActivityHandler,
+		syncHandler:             syncHandler,
+		tripBundleHandler:       tripBundleHandler,
 	}
 }
 
@@ -75,6 +108,17 @@ func (s *Server) Run() {
 		s.invitationTripHandler,
 		s.tripMemberHandler,
 		s.tripImageHandler,
+		s.tripExpenseHandler,
+		s.exchangeRateHandler,
+		s.tripBookingHandler,
+		s.tripDocumentHandler,
+		s.tripChecklistHandler,
+		s.tripCommentHandler,
+		s.tripPollHandler,
+		s.tripRealtimeHandler,
+		s.tripActivityHandler,
+		s.syncHandler,
+		s.tripBundleHandler,
 	)
 	err := httpServerInstance.ListenAndServe()
 	if err != nil {
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -76,3 +77,27 @@ func (a *AuthMiddleware) VerifyAccessToken(c *gin.Context) {
 	statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.ACCESS_TOKEN_INVALID, "accessToken")
 	c.AbortWithStatusJSON(statusCode, errResponse)
 }
+
+// VerifyWebSocketAccessToken accepts the access token from the accessToken query parameter as well,
+// since browsers cannot set headers on a WebSocket handshake
+func (a *AuthMiddleware) VerifyWebSocketAccessToken(c *gin.Context) {
+	if c.GetHeader("Authorization") == "" {
+		if accessToken := c.Query("accessToken"); accessToken != "" {
+			c.Request.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+	}
+	a.VerifyAccessToken(c)
+}
+
+// VerifyAdminKey guards operator endpoints with the shared ADMIN_API_KEY, which must be set for them to work
+func (a *AuthMiddleware) VerifyAdminKey(c *gin.Context) {
+	adminKey, err := env.GetEnv("ADMIN_API_KEY")
+	providedKey := c.GetHeader("X-Admin-Key")
+	if err != nil || adminKey == "" || subtle.ConstantTimeCompare([]byte(adminKey), []byte(providedKey)) != 1 {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.FORBIDDEN, "X-Admin-Key")
+		c.AbortWithStatusJSON(statusCode, errResponse)
+		return
+	}
+
+	c.Next()
+}
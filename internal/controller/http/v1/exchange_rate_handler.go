@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	httpcommon "github.com/swefinal-travel-planner/travel-app-be/internal/domain/http_common"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/validation"
+)
+
+// rate files are a few hundred lines at most
+const maxExchangeRateFileSize = 1 << 20
+
+type ExchangeRateHandler struct {
+	exchangeRateService service.ExchangeRateService
+}
+
+func NewExchangeRateHandler(exchangeRateService service.ExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		exchangeRateService: exchangeRateService,
+	}
+}
+
+// @Summary Get exchange rates
+// @Description Get the locally managed exchange rates, expressed as units of each currency per USD
+// @Tags ExchangeRates
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[[]model.ExchangeRateResponse]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /exchange-rates [get]
+func (h *ExchangeRateHandler) GetExchangeRates(c *gin.Context) {
+	rates, errCode := h.exchangeRateService.GetExchangeRates(c)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(&rates))
+}
+
+// @Summary Import exchange rates
+// @Description Load exchange rates from a JSON body, or from an uploaded .json or .csv file (currency,rate rows). Existing currencies are overwritten
+// @Tags ExchangeRates
+// @Accept json,mpfd
+// @Produce json
+// @Param request body model.ExchangeRateImportRequest false "Rates quoted against base (USD when empty)"
+// @Param file formData file false "JSON or CSV rate file"
+// @Param base formData string false "Currency the file rates are quoted against"
+// @Param X-Admin-Key header string true "Admin API key"
+// @Success 200 {object} httpcommon.HttpResponse[model.ExchangeRateImportResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /exchange-rates [put]
+func (h *ExchangeRateHandler) ImportExchangeRates(c *gin.Context) {
+	var response *model.ExchangeRateImportResponse
+	var errCode string
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil || fileHeader.Size > maxExchangeRateFileSize {
+			statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "file")
+			c.JSON(statusCode, errResponse)
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "file")
+			c.JSON(statusCode, errResponse)
+			return
+		}
+		defer file.Close()
+		content, err := io.ReadAll(io.LimitReader(file, maxExchangeRateFileSize))
+		if err != nil {
+			statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "file")
+			c.JSON(statusCode, errResponse)
+			return
+		}
+		response, errCode = h.exchangeRateService.ImportExchangeRatesFile(c, fileHeader.Filename, c.PostForm("base"), content)
+	} else {
+		var importRequest model.ExchangeRateImportRequest
+		if err := validation.BindJsonAndValidate(c, &importRequest); err != nil {
+			return
+		}
+		response, errCode = h.exchangeRateService.ImportExchangeRates(c, importRequest)
+	}
+
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(response))
+}
@@ -19,6 +19,17 @@ func MapRoutes(router *gin.Engine,
 	invitationTripHandler *InvitationTripHandler,
 	tripMemberHandler *TripMemberHandler,
 	tripImageHandler *TripImageHandler,
+	tripExpenseHandler *TripExpenseHandler,
+	exchangeRateHandler *ExchangeRateHandler,
+	tripBookingHandler *TripBookingHandler,
+	tripDocumentHandler *TripDocumentHandler,
+	tripChecklistHandler *TripChecklistHandler,
+	tripCommentHandler *TripCommentHandler,
+	tripPollHandler *TripPollHandler,
+	tripRealtimeHandler *TripRealtimeHandler,
+	tripActivityHandler *TripActivityHandler,
+	syncHandler *SyncHandler,
+	tripBundleHandler *TripBundleHandler,
 ) {
 	v1 := router.Group("/api/v1")
 	{
@@ -76,19 +87,73 @@ func MapRoutes(router *gin.Engine,
 		{
 			trip.POST("", authMiddleware.VerifyAccessToken, tripHandler.CreateTripManually)
 			trip.GET("", authMiddleware.VerifyAccessToken, tripHandler.GetAllTrips)
+			trip.GET("/trash", authMiddleware.VerifyAccessToken, tripHandler.GetTrash)
 			trip.GET("/:tripId", authMiddleware.VerifyAccessToken, tripHandler.GetTrip)
 			trip.PATCH("/:tripId", authMiddleware.VerifyAccessToken, tripHandler.UpdateTrip)
 			trip.POST("/:tripId/trip-items", authMiddleware.VerifyAccessToken, tripHandler.CreateTripItems)
 			trip.GET("/:tripId/trip-items", authMiddleware.VerifyAccessToken, tripHandler.GetTripItems)
+			trip.GET("/:tripId/travel-summary", authMiddleware.VerifyAccessToken, tripHandler.GetTripTravelSummary)
+			trip.GET("/:tripId/validation", authMiddleware.VerifyAccessToken, tripHandler.ValidateTripSchedule)
+			trip.POST("/:tripId/days/:day/optimize", authMiddleware.VerifyAccessToken, tripHandler.OptimizeTripDay)
+			trip.GET("/:tripId/route", authMiddleware.VerifyAccessToken, tripHandler.ExportTripRoute)
+			trip.GET("/:tripId/itinerary.pdf", authMiddleware.VerifyAccessToken, tripHandler.ExportItineraryPDF)
 			trip.POST("/ai", authMiddleware.VerifyAccessToken, tripHandler.CreateTripByAI)
 			trip.GET("/:tripId/members", authMiddleware.VerifyAccessToken, tripMemberHandler.GetTripMembers)
 			trip.DELETE("/:tripId/members/:memberId", authMiddleware.VerifyAccessToken, tripMemberHandler.DeleteTripMember)
+			trip.PATCH("/:tripId/members/:memberId/role", authMiddleware.VerifyAccessToken, tripMemberHandler.ChangeTripMemberRole)
+			trip.POST("/:tripId/leave", authMiddleware.VerifyAccessToken, tripMemberHandler.LeaveTrip)
+			trip.POST("/:tripId/transfer-ownership", authMiddleware.VerifyAccessToken, tripMemberHandler.TransferOwnership)
 			trip.DELETE("/:tripId", authMiddleware.VerifyAccessToken, tripHandler.DeleteTrip)
+			trip.POST("/:tripId/restore", authMiddleware.VerifyAccessToken, tripHandler.RestoreTrip)
+			trip.POST("/:tripId/cancel", authMiddleware.VerifyAccessToken, tripHandler.CancelTrip)
+			trip.POST("/:tripId/archive", authMiddleware.VerifyAccessToken, tripHandler.ArchiveTrip)
+			trip.POST("/:tripId/unarchive", authMiddleware.VerifyAccessToken, tripHandler.UnarchiveTrip)
 			trip.POST("/:tripId/images", authMiddleware.VerifyAccessToken, tripImageHandler.CreateTripImage)
 			trip.GET("/:tripId/images", authMiddleware.VerifyAccessToken, tripImageHandler.GetTripImages)
 			trip.DELETE("/:tripId/images/:imageId", authMiddleware.VerifyAccessToken, tripImageHandler.DeleteTripImage)
 			trip.GET("/:tripId/trip-items/:tripItemId/images", authMiddleware.VerifyAccessToken, tripImageHandler.GetAllByTripIDAndTripItemID)
 			trip.GET("/:tripId/pending-invitations", authMiddleware.VerifyAccessToken, invitationTripHandler.GetPendingInvitationsByTripID)
+			trip.POST("/:tripId/expenses", authMiddleware.VerifyAccessToken, tripExpenseHandler.CreateExpense)
+			trip.GET("/:tripId/expenses", authMiddleware.VerifyAccessToken, tripExpenseHandler.GetExpenses)
+			trip.GET("/:tripId/expenses/export", authMiddleware.VerifyAccessToken, tripExpenseHandler.ExportExpenses)
+			trip.DELETE("/:tripId/expenses/:expenseId", authMiddleware.VerifyAccessToken, tripExpenseHandler.DeleteExpense)
+			trip.GET("/:tripId/balances", authMiddleware.VerifyAccessToken, tripExpenseHandler.GetBalances)
+			trip.POST("/:tripId/settlements", authMiddleware.VerifyAccessToken, tripExpenseHandler.CreateSettlement)
+			trip.GET("/:tripId/budget", authMiddleware.VerifyAccessToken, tripExpenseHandler.GetBudgetSummary)
+			trip.POST("/:tripId/bookings", authMiddleware.VerifyAccessToken, tripBookingHandler.CreateBooking)
+			trip.GET("/:tripId/bookings", authMiddleware.VerifyAccessToken, tripBookingHandler.GetBookings)
+			trip.PUT("/:tripId/bookings/:bookingId", authMiddleware.VerifyAccessToken, tripBookingHandler.UpdateBooking)
+			trip.DELETE("/:tripId/bookings/:bookingId", authMiddleware.VerifyAccessToken, tripBookingHandler.DeleteBooking)
+			trip.GET("/:tripId/timeline", authMiddleware.VerifyAccessToken, tripBookingHandler.GetTripTimeline)
+			trip.POST("/:tripId/documents", authMiddleware.VerifyAccessToken, tripDocumentHandler.CreateDocument)
+			trip.GET("/:tripId/documents", authMiddleware.VerifyAccessToken, tripDocumentHandler.GetDocuments)
+			trip.GET("/:tripId/documents/:documentId/download", authMiddleware.VerifyAccessToken, tripDocumentHandler.GetDocumentDownloadURL)
+			trip.DELETE("/:tripId/documents/:documentId", authMiddleware.VerifyAccessToken, tripDocumentHandler.DeleteDocument)
+			trip.POST("/:tripId/checklists", authMiddleware.VerifyAccessToken, tripChecklistHandler.CreateChecklist)
+			trip.GET("/:tripId/checklists", authMiddleware.VerifyAccessToken, tripChecklistHandler.GetChecklists)
+			trip.DELETE("/:tripId/checklists/:checklistId", authMiddleware.VerifyAccessToken, tripChecklistHandler.DeleteChecklist)
+			trip.POST("/:tripId/checklists/:checklistId/items", authMiddleware.VerifyAccessToken, tripChecklistHandler.CreateChecklistItem)
+			trip.PUT("/:tripId/checklists/:checklistId/items/:itemId", authMiddleware.VerifyAccessToken, tripChecklistHandler.UpdateChecklistItem)
+			trip.PATCH("/:tripId/checklists/:checklistId/items/:itemId/check", authMiddleware.VerifyAccessToken, tripChecklistHandler.CheckChecklistItem)
+			trip.DELETE("/:tripId/checklists/:checklistId/items/:itemId", authMiddleware.VerifyAccessToken, tripChecklistHandler.DeleteChecklistItem)
+			trip.POST("/:tripId/comments", authMiddleware.VerifyAccessToken, tripCommentHandler.CreateComment)
+			trip.GET("/:tripId/comments", authMiddleware.VerifyAccessToken, tripCommentHandler.GetComments)
+			trip.PUT("/:tripId/comments/:commentId", authMiddleware.VerifyAccessToken, tripCommentHandler.UpdateComment)
+			trip.DELETE("/:tripId/comments/:commentId", authMiddleware.VerifyAccessToken, tripCommentHandler.DeleteComment)
+			trip.POST("/:tripId/polls", authMiddleware.VerifyAccessToken, tripPollHandler.CreatePoll)
+			trip.GET("/:tripId/polls", authMiddleware.VerifyAccessToken, tripPollHandler.GetPolls)
+			trip.GET("/:tripId/polls/:pollId", authMiddleware.VerifyAccessToken, tripPollHandler.GetPoll)
+			trip.PUT("/:tripId/polls/:pollId/votes", authMiddleware.VerifyAccessToken, tripPollHandler.Vote)
+			trip.POST("/:tripId/polls/:pollId/close", authMiddleware.VerifyAccessToken, tripPollHandler.ClosePoll)
+			trip.DELETE("/:tripId/polls/:pollId", authMiddleware.VerifyAccessToken, tripPollHandler.DeletePoll)
+			trip.GET("/:tripId/live", authMiddleware.VerifyWebSocketAccessToken, tripRealtimeHandler.Connect)
+			trip.GET("/:tripId/activity", authMiddleware.VerifyAccessToken, tripActivityHandler.GetActivities)
+			trip.GET("/:tripId/bundle", authMiddleware.VerifyAccessToken, tripBundleHandler.GetTripBundle)
+		}
+		exchangeRate := v1.Group("/exchange-rates")
+		{
+			exchangeRate.GET("", authMiddleware.VerifyAccessToken, exchangeRateHandler.GetExchangeRates)
+			exchangeRate.PUT("", authMiddleware.VerifyAdminKey, exchangeRateHandler.ImportExchangeRates)
 		}
 		tripInvitation := v1.Group("/invitation-trips")
 		{
@@ -97,6 +162,11 @@ func MapRoutes(router *gin.Engine,
 			tripInvitation.PUT("/deny/:invitationId", authMiddleware.VerifyAccessToken, invitationTripHandler.DenyInvitation)
 			tripInvitation.DELETE("/withdraw/:invitationId", authMiddleware.VerifyAccessToken, invitationTripHandler.WithdrawInvitation)
 		}
+		sync := v1.Group("/sync")
+		{
+			sync.GET("", authMiddleware.VerifyAccessToken, syncHandler.GetChanges)
+			sync.POST("", authMiddleware.VerifyAccessToken, syncHandler.ApplyMutations)
+		}
 	}
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
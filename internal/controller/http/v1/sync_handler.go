@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
+	httpcommon "github.com/swefinal-travel-planner/travel-app-be/internal/domain/http_common"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/validation"
+)
+
+type SyncHandler struct {
+	syncService service.SyncService
+}
+
+func NewSyncHandler(syncService service.SyncService) *SyncHandler {
+	return &SyncHandler{
+		syncService: syncService,
+	}
+}
+
+// @Summary Get changes since the last sync
+// @Description Get the trips, items, members, images, invitations and notifications that changed since the cursor, plus tombstones for what was deleted. Without a cursor, or with one older than 90 days, everything is returned with fullResync set
+// @Tags Sync
+// @Accept json
+// @Produce json
+// @Param since query string false "cursor of the previous sync"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.SyncResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /sync [get]
+func (h *SyncHandler) GetChanges(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	changes, errCode := h.syncService.GetChanges(c, userID, c.Query("since"))
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "since")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(changes))
+}
+
+// @Summary Apply offline changes
+// @Description Replay a batch of changes queued while offline, in order. Each one is reported as applied, failed, or in conflict when the server copy changed after baseUpdatedAt
+// @Tags Sync
+// @Accept json
+// @Produce json
+// @Param request body model.SyncMutationRequest true "Queued changes"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.SyncMutationResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /sync [post]
+func (h *SyncHandler) ApplyMutations(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	var mutationRequest model.SyncMutationRequest
+	if err := validation.BindJsonAndValidate(c, &mutationRequest); err != nil {
+		return
+	}
+
+	results := h.syncService.ApplyMutations(c, userID, mutationRequest.Mutations)
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(results))
+}
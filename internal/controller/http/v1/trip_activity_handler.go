@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
+	httpcommon "github.com/swefinal-travel-planner/travel-app-be/internal/domain/http_common"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+const (
+	defaultActivityPageSize = 20
+	maxActivityPageSize     = 100
+)
+
+type TripActivityHandler struct {
+	tripActivityService service.TripActivityService
+}
+
+func NewTripActivityHandler(tripActivityService service.TripActivityService) *TripActivityHandler {
+	return &TripActivityHandler{
+		tripActivityService: tripActivityService,
+	}
+}
+
+// @Summary Get trip activity
+// @Description Get the audit trail of a trip, newest first: who changed what, with the values before and after
+// @Tags TripActivities
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param cursor query int false "nextCursor of the previous page"
+// @Param limit query int false "Page size, 20 by default and at most 100"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripActivityPageResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/activity [get]
+func (h *TripActivityHandler) GetActivities(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var cursor *int64
+	if value := c.Query("cursor"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 {
+			statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "cursor")
+			c.JSON(statusCode, errResponse)
+			return
+		}
+		cursor = &parsed
+	}
+
+	limit := defaultActivityPageSize
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxActivityPageSize {
+			statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "limit")
+			c.JSON(statusCode, errResponse)
+			return
+		}
+		limit = parsed
+	}
+
+	activities, errCode := h.tripActivityService.GetActivities(c, userID, tripID, cursor, limit)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(activities))
+}
@@ -0,0 +1,218 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
+	httpcommon "github.com/swefinal-travel-planner/travel-app-be/internal/domain/http_common"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/validation"
+)
+
+type TripBookingHandler struct {
+	tripBookingService service.TripBookingService
+}
+
+func NewTripBookingHandler(tripBookingService service.TripBookingService) *TripBookingHandler {
+	return &TripBookingHandler{
+		tripBookingService: tripBookingService,
+	}
+}
+
+// @Summary Create trip booking
+// @Description Attach a hotel, flight, train or car rental booking to a trip
+// @Tags TripBookings
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param request body model.TripBookingRequest true "Trip booking payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripBookingResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/bookings [post]
+func (h *TripBookingHandler) CreateBooking(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var bookingRequest model.TripBookingRequest
+	if err := validation.BindJsonAndValidate(c, &bookingRequest); err != nil {
+		return
+	}
+
+	booking, errCode := h.tripBookingService.CreateBooking(c, userID, tripID, bookingRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(booking))
+}
+
+// @Summary Get trip bookings
+// @Description Get all bookings of a trip ordered by start time
+// @Tags TripBookings
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[[]model.TripBookingResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/bookings [get]
+func (h *TripBookingHandler) GetBookings(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	bookings, errCode := h.tripBookingService.GetBookings(c, userID, tripID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(&bookings))
+}
+
+// @Summary Update trip booking
+// @Description Replace the details of a booking (creator or admin only)
+// @Tags TripBookings
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param bookingId path int true "Booking ID"
+// @Param request body model.TripBookingRequest true "Trip booking payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripBookingResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/bookings/{bookingId} [put]
+func (h *TripBookingHandler) UpdateBooking(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	bookingID, err := strconv.ParseInt(c.Param("bookingId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "bookingId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var bookingRequest model.TripBookingRequest
+	if err := validation.BindJsonAndValidate(c, &bookingRequest); err != nil {
+		return
+	}
+
+	booking, errCode := h.tripBookingService.UpdateBooking(c, userID, tripID, bookingID, bookingRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(booking))
+}
+
+// @Summary Delete trip booking
+// @Description Delete a booking (creator or admin only)
+// @Tags TripBookings
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param bookingId path int true "Booking ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/bookings/{bookingId} [delete]
+func (h *TripBookingHandler) DeleteBooking(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	bookingID, err := strconv.ParseInt(c.Param("bookingId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "bookingId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	errCode := h.tripBookingService.DeleteBooking(c, userID, tripID, bookingID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+// @Summary Get trip timeline
+// @Description Get trip items and bookings merged into one chronological timeline per trip day
+// @Tags TripBookings
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param language query string false "Language of place info (vi or en)"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripTimelineResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/timeline [get]
+func (h *TripBookingHandler) GetTripTimeline(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	timeline, errCode := h.tripBookingService.GetTripTimeline(c, userID, tripID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(timeline))
+}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+type TripBundleHandler struct {
+	tripBundleService service.TripBundleService
+}
+
+func NewTripBundleHandler(tripBundleService service.TripBundleService) *TripBundleHandler {
+	return &TripBundleHandler{
+		tripBundleService: tripBundleService,
+	}
+}
+
+// @Summary Download offline trip bundle
+// @Description Download a zip with trip.json, items.json (with place info), members.json, documents.json, photos.json, the documents, downscaled photos and a manifest.json listing the SHA-256 of every file. Files whose hash is passed in known are left out of the archive but stay in the manifest
+// @Tags Trips
+// @Param tripId path int true "Trip ID"
+// @Param language query string false "Language for place info (vi or en)" Enums(vi,en) default(vi)
+// @Param known query []string false "SHA-256 hashes of files the client already has" collectionFormat(csv)
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce application/zip
+// @Router /trips/{tripId}/bundle [get]
+// @Success 200 {file} file
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripBundleHandler) GetTripBundle(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	tripIdInt, err := strconv.ParseInt(ctx.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	// the place info of the items is fetched with the raw query value, so unknown languages are rejected rather than defaulted
+	lang := ctx.DefaultQuery("language", "vi")
+	if lang != "vi" && lang != "en" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "language")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	knownHashes := queryList(ctx, "known")
+	for _, hash := range knownHashes {
+		if len(hash) != 64 || strings.Trim(strings.ToLower(hash), "0123456789abcdef") != "" {
+			statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "known")
+			ctx.JSON(statusCode, errResponse)
+			return
+		}
+	}
+
+	content, errCode := handler.tripBundleService.GetTripBundle(ctx, userId, tripIdInt, lang, knownHashes)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"trip-%d-bundle.zip\"", tripIdInt))
+	ctx.Data(200, "application/zip", content)
+}
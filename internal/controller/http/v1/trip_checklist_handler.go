@@ -0,0 +1,310 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
+	httpcommon "github.com/swefinal-travel-planner/travel-app-be/internal/domain/http_common"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/validation"
+)
+
+type TripChecklistHandler struct {
+	tripChecklistService service.TripChecklistService
+}
+
+func NewTripChecklistHandler(tripChecklistService service.TripChecklistService) *TripChecklistHandler {
+	return &TripChecklistHandler{
+		tripChecklistService: tripChecklistService,
+	}
+}
+
+// @Summary Create trip checklist
+// @Description Create a packing list or to-do checklist, optionally seeded with a default packing list
+// @Tags TripChecklists
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param language query string false "Language of seeded items (vi or en)"
+// @Param request body model.TripChecklistRequest true "Trip checklist payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripChecklistResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/checklists [post]
+func (h *TripChecklistHandler) CreateChecklist(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var checklistRequest model.TripChecklistRequest
+	if err := validation.BindJsonAndValidate(c, &checklistRequest); err != nil {
+		return
+	}
+
+	checklist, errCode := h.tripChecklistService.CreateChecklist(c, userID, tripID, checklistRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(checklist))
+}
+
+// @Summary Get trip checklists
+// @Description Get all checklists of a trip with their items
+// @Tags TripChecklists
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[[]model.TripChecklistResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/checklists [get]
+func (h *TripChecklistHandler) GetChecklists(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	checklists, errCode := h.tripChecklistService.GetChecklists(c, userID, tripID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(&checklists))
+}
+
+// @Summary Delete trip checklist
+// @Description Delete a checklist and its items (creator or admin only)
+// @Tags TripChecklists
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param checklistId path int true "Checklist ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/checklists/{checklistId} [delete]
+func (h *TripChecklistHandler) DeleteChecklist(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, checklistID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+
+	errCode := h.tripChecklistService.DeleteChecklist(c, userID, tripID, checklistID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+// @Summary Create checklist item
+// @Description Add an item to a checklist, optionally assigned to a member with a due date
+// @Tags TripChecklists
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param checklistId path int true "Checklist ID"
+// @Param request body model.TripChecklistItemRequest true "Checklist item payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripChecklistItemResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/checklists/{checklistId}/items [post]
+func (h *TripChecklistHandler) CreateChecklistItem(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, checklistID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+
+	var itemRequest model.TripChecklistItemRequest
+	if err := validation.BindJsonAndValidate(c, &itemRequest); err != nil {
+		return
+	}
+
+	item, errCode := h.tripChecklistService.CreateChecklistItem(c, userID, tripID, checklistID, itemRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(item))
+}
+
+// @Summary Update checklist item
+// @Description Change the title, assignee or due date of a checklist item
+// @Tags TripChecklists
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param checklistId path int true "Checklist ID"
+// @Param itemId path int true "Checklist item ID"
+// @Param request body model.TripChecklistItemRequest true "Checklist item payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripChecklistItemResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/checklists/{checklistId}/items/{itemId} [put]
+func (h *TripChecklistHandler) UpdateChecklistItem(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, checklistID, itemID, ok := parseChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	var itemRequest model.TripChecklistItemRequest
+	if err := validation.BindJsonAndValidate(c, &itemRequest); err != nil {
+		return
+	}
+
+	item, errCode := h.tripChecklistService.UpdateChecklistItem(c, userID, tripID, checklistID, itemID, itemRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(item))
+}
+
+// @Summary Check checklist item
+// @Description Check or uncheck a checklist item, recording who checked it
+// @Tags TripChecklists
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param checklistId path int true "Checklist ID"
+// @Param itemId path int true "Checklist item ID"
+// @Param request body model.TripChecklistItemCheckRequest true "Check payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripChecklistItemResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/checklists/{checklistId}/items/{itemId}/check [patch]
+func (h *TripChecklistHandler) CheckChecklistItem(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, checklistID, itemID, ok := parseChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	var checkRequest model.TripChecklistItemCheckRequest
+	if err := validation.BindJsonAndValidate(c, &checkRequest); err != nil {
+		return
+	}
+
+	item, errCode := h.tripChecklistService.CheckChecklistItem(c, userID, tripID, checklistID, itemID, checkRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(item))
+}
+
+// @Summary Delete checklist item
+// @Description Delete a checklist item (creator or admin only)
+// @Tags TripChecklists
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param checklistId path int true "Checklist ID"
+// @Param itemId path int true "Checklist item ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/checklists/{checklistId}/items/{itemId} [delete]
+func (h *TripChecklistHandler) DeleteChecklistItem(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, checklistID, itemID, ok := parseChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	errCode := h.tripChecklistService.DeleteChecklistItem(c, userID, tripID, checklistID, itemID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+func parseChecklistParams(c *gin.Context) (int64, int64, bool) {
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return 0, 0, false
+	}
+
+	checklistID, err := strconv.ParseInt(c.Param("checklistId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "checklistId")
+		c.JSON(statusCode, errResponse)
+		return 0, 0, false
+	}
+	return tripID, checklistID, true
+}
+
+func parseChecklistItemParams(c *gin.Context) (int64, int64, int64, bool) {
+	tripID, checklistID, ok := parseChecklistParams(c)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	itemID, err := strconv.ParseInt(c.Param("itemId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "itemId")
+		c.JSON(statusCode, errResponse)
+		return 0, 0, 0, false
+	}
+	return tripID, checklistID, itemID, true
+}
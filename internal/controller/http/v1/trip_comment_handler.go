@@ -0,0 +1,196 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
+	httpcommon "github.com/swefinal-travel-planner/travel-app-be/internal/domain/http_common"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/validation"
+)
+
+type TripCommentHandler struct {
+	tripCommentService service.TripCommentService
+}
+
+func NewTripCommentHandler(tripCommentService service.TripCommentService) *TripCommentHandler {
+	return &TripCommentHandler{
+		tripCommentService: tripCommentService,
+	}
+}
+
+// @Summary Create trip comment
+// @Description Comment on a trip or one of its items, or reply to a comment; @Name mentions notify those members
+// @Tags TripComments
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param request body model.TripCommentRequest true "Trip comment payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripCommentResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/comments [post]
+func (h *TripCommentHandler) CreateComment(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var commentRequest model.TripCommentRequest
+	if err := validation.BindJsonAndValidate(c, &commentRequest); err != nil {
+		return
+	}
+
+	comment, errCode := h.tripCommentService.CreateComment(c, userID, tripID, commentRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(comment))
+}
+
+// @Summary Get trip comments
+// @Description Get the comment threads of a trip, or of one trip item when tripItemId is given
+// @Tags TripComments
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param tripItemId query int false "Trip item ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[[]model.TripCommentResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/comments [get]
+func (h *TripCommentHandler) GetComments(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var tripItemID *int64
+	if value := c.Query("tripItemId"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripItemId")
+			c.JSON(statusCode, errResponse)
+			return
+		}
+		tripItemID = &parsed
+	}
+
+	comments, errCode := h.tripCommentService.GetComments(c, userID, tripID, tripItemID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(&comments))
+}
+
+// @Summary Update trip comment
+// @Description Edit a comment (author only); newly mentioned members are notified
+// @Tags TripComments
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param commentId path int true "Comment ID"
+// @Param request body model.TripCommentUpdateRequest true "Trip comment payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripCommentResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/comments/{commentId} [put]
+func (h *TripCommentHandler) UpdateComment(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	commentID, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "commentId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var commentRequest model.TripCommentUpdateRequest
+	if err := validation.BindJsonAndValidate(c, &commentRequest); err != nil {
+		return
+	}
+
+	comment, errCode := h.tripCommentService.UpdateComment(c, userID, tripID, commentID, commentRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(comment))
+}
+
+// @Summary Delete trip comment
+// @Description Delete a comment (author or admin only)
+// @Tags TripComments
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param commentId path int true "Comment ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/comments/{commentId} [delete]
+func (h *TripCommentHandler) DeleteComment(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	commentID, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "commentId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	errCode := h.tripCommentService.DeleteComment(c, userID, tripID, commentID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
@@ -0,0 +1,178 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
+	httpcommon "github.com/swefinal-travel-planner/travel-app-be/internal/domain/http_common"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/validation"
+)
+
+type TripDocumentHandler struct {
+	tripDocumentService service.TripDocumentService
+}
+
+func NewTripDocumentHandler(tripDocumentService service.TripDocumentService) *TripDocumentHandler {
+	return &TripDocumentHandler{
+		tripDocumentService: tripDocumentService,
+	}
+}
+
+// @Summary Create trip document
+// @Description Register a ticket, reservation or scan and get a presigned URL to PUT the file to with the same content type
+// @Tags TripDocuments
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param request body model.TripDocumentRequest true "Trip document payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripDocumentUploadResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/documents [post]
+func (h *TripDocumentHandler) CreateDocument(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var documentRequest model.TripDocumentRequest
+	if err := validation.BindJsonAndValidate(c, &documentRequest); err != nil {
+		return
+	}
+
+	document, errCode := h.tripDocumentService.CreateDocument(c, userID, tripID, documentRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(document))
+}
+
+// @Summary Get trip documents
+// @Description Get the documents shared with the trip plus the caller's private ones
+// @Tags TripDocuments
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[[]model.TripDocumentResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/documents [get]
+func (h *TripDocumentHandler) GetDocuments(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	documents, errCode := h.tripDocumentService.GetDocuments(c, userID, tripID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(&documents))
+}
+
+// @Summary Get trip document download URL
+// @Description Get a short-lived signed URL to download a document
+// @Tags TripDocuments
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param documentId path int true "Document ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripDocumentDownloadResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/documents/{documentId}/download [get]
+func (h *TripDocumentHandler) GetDocumentDownloadURL(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	documentID, err := strconv.ParseInt(c.Param("documentId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "documentId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	download, errCode := h.tripDocumentService.GetDocumentDownloadURL(c, userID, tripID, documentID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(download))
+}
+
+// @Summary Delete trip document
+// @Description Delete a document (uploader, or admin for documents shared with the trip)
+// @Tags TripDocuments
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param documentId path int true "Document ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/documents/{documentId} [delete]
+func (h *TripDocumentHandler) DeleteDocument(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	documentID, err := strconv.ParseInt(c.Param("documentId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "documentId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	errCode := h.tripDocumentService.DeleteDocument(c, userID, tripID, documentID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
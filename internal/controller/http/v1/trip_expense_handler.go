@@ -0,0 +1,305 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
+	httpcommon "github.com/swefinal-travel-planner/travel-app-be/internal/domain/http_common"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	expensereportutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/expense_report_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/validation"
+)
+
+type TripExpenseHandler struct {
+	tripExpenseService service.TripExpenseService
+}
+
+func NewTripExpenseHandler(tripExpenseService service.TripExpenseService) *TripExpenseHandler {
+	return &TripExpenseHandler{
+		tripExpenseService: tripExpenseService,
+	}
+}
+
+// @Summary Create trip expense
+// @Description Record an expense paid by a member and split among selected members (equal, shares or exact amounts)
+// @Tags TripExpenses
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param request body model.TripExpenseRequest true "Trip expense payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripExpenseResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/expenses [post]
+func (h *TripExpenseHandler) CreateExpense(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var expenseRequest model.TripExpenseRequest
+	if err := validation.BindJsonAndValidate(c, &expenseRequest); err != nil {
+		return
+	}
+
+	expense, errCode := h.tripExpenseService.CreateExpense(c, userID, tripID, expenseRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(expense))
+}
+
+// @Summary Get trip expenses
+// @Description Get all expenses of a trip with their splits
+// @Tags TripExpenses
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[[]model.TripExpenseResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/expenses [get]
+func (h *TripExpenseHandler) GetExpenses(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	expenses, errCode := h.tripExpenseService.GetExpenses(c, userID, tripID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(&expenses))
+}
+
+// @Summary Delete trip expense
+// @Description Delete an expense (creator, payer or admin only)
+// @Tags TripExpenses
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param expenseId path int true "Expense ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/expenses/{expenseId} [delete]
+func (h *TripExpenseHandler) DeleteExpense(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	expenseID, err := strconv.ParseInt(c.Param("expenseId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "expenseId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	errCode := h.tripExpenseService.DeleteExpense(c, userID, tripID, expenseID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+// @Summary Get trip balances
+// @Description Get what each member paid and owes per currency, with the minimal set of transfers to settle up
+// @Tags TripExpenses
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripBalancesResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/balances [get]
+func (h *TripExpenseHandler) GetBalances(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	balances, errCode := h.tripExpenseService.GetBalances(c, userID, tripID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(balances))
+}
+
+// @Summary Record settlement
+// @Description Record a payment from one member to another, which is deducted from their balances
+// @Tags TripExpenses
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param request body model.TripSettlementRequest true "Settlement payload"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/settlements [post]
+func (h *TripExpenseHandler) CreateSettlement(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var settlementRequest model.TripSettlementRequest
+	if err := validation.BindJsonAndValidate(c, &settlementRequest); err != nil {
+		return
+	}
+
+	errCode := h.tripExpenseService.CreateSettlement(c, userID, tripID, settlementRequest)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+// @Summary Get trip budget summary
+// @Description Get spending converted to the trip budget currency, against the budget overall, by category and by day
+// @Tags TripExpenses
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param Authorization header string true "Authorization: Bearer"
+// @Success 200 {object} httpcommon.HttpResponse[model.TripBudgetSummaryResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/budget [get]
+func (h *TripExpenseHandler) GetBudgetSummary(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	summary, errCode := h.tripExpenseService.GetBudgetSummary(c, userID, tripID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, httpcommon.NewSuccessResponse(summary))
+}
+
+// @Summary Export trip expenses
+// @Description Download the expense list, per-member totals and the settlement plan as a CSV file or an Excel workbook
+// @Tags TripExpenses
+// @Param tripId path int true "Trip ID"
+// @Param format query string false "File format" Enums(csv,xlsx) default(csv)
+// @Param language query string false "Language for column headers (vi or en)" Enums(vi,en) default(vi)
+// @Param Authorization header string true "Authorization: Bearer"
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Success 200 {file} file
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/expenses/export [get]
+func (h *TripExpenseHandler) ExportExpenses(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	format := c.DefaultQuery("format", string(expensereportutils.FormatCSV))
+	if !expensereportutils.IsValidFormat(format) {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "format")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	// Get language from query, only allow 'vi' and 'en', default to 'vi'
+	lang := c.DefaultQuery("language", "vi")
+	if lang != "vi" && lang != "en" {
+		lang = "vi"
+	}
+
+	report, errCode := h.tripExpenseService.GetExpenseReport(c, userID, tripID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	content, err := expensereportutils.Render(*report, expensereportutils.Format(format), lang)
+	if err != nil {
+		log.Error("TripExpenseHandler.ExportExpenses Render error: " + err.Error())
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.INTERNAL_SERVER_ERROR, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == string(expensereportutils.FormatXLSX) {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"trip-%d-expenses.%s\"", tripID, format))
+	c.Data(http.StatusOK, contentType, content)
+}
@@ -1,10 +1,16 @@
 package v1
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	pdfutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/pdf_utils"
+	routeutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/route_utils"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/validation"
 
 	"github.com/gin-gonic/gin"
@@ -62,7 +68,7 @@ func (handler *TripHandler) CreateTripManually(ctx *gin.Context) {
 }
 
 // @Summary Create/Update trip items
-// @Description Create/Update trip items
+// @Description Save the whole itinerary. Items sent with an id, or with the place of an existing item, keep their id; the others are created and the missing ones removed. A schedule with errors is rejected with 422 and its issues in data
 // @Tags Trips
 // @Accept json
 // @Param request body []model.TripItemRequest true "TripItem payload"
@@ -72,6 +78,7 @@ func (handler *TripHandler) CreateTripManually(ctx *gin.Context) {
 // @Router /trips/{tripId}/trip-items [post]
 // @Success 204 "No Content"
 // @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 422 {object} httpcommon.HttpResponse[[]model.TripValidationIssue]
 // @Failure 500 {object} httpcommon.HttpResponse[any]
 func (handler *TripHandler) CreateTripItems(ctx *gin.Context) {
 	userId := middleware.GetUserIdHelper(ctx)
@@ -95,9 +102,14 @@ func (handler *TripHandler) CreateTripItems(ctx *gin.Context) {
 		return
 	}
 
-	errCode := handler.tripItemService.CreateTripItems(ctx, userId, tripIdInt, tripItemRequests)
+	issues, errCode := handler.tripItemService.CreateTripItems(ctx, userId, tripIdInt, tripItemRequests)
 	if errCode != "" {
 		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		// a rejected schedule lists its issues, as the validation endpoint only sees the saved items
+		if len(issues) > 0 {
+			var data any = issues
+			errResponse.Data = &data
+		}
 		ctx.JSON(statusCode, errResponse)
 		return
 	}
@@ -105,25 +117,111 @@ func (handler *TripHandler) CreateTripItems(ctx *gin.Context) {
 	ctx.AbortWithStatus(204)
 }
 
+const (
+	defaultTripPageSize = 20
+	maxTripPageSize     = 100
+)
+
 // @Summary Get all trips for a user
-// @Description Get all trips that the user is a member of
+// @Description Get the trips the user is a member of, filtered and sorted. List filters accept repeated or comma-separated values.
+// @Description Every trip is returned unless limit or cursor is given; a page that has more trips after it sets the X-Next-Cursor header
 // @Tags Trips
+// @Param status query []string false "Trip statuses" collectionFormat(csv)
+// @Param city query string false "City of the trip or of any of its segments"
+// @Param from query string false "Keep trips with a day on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Keep trips with a day on or before this date (YYYY-MM-DD)"
+// @Param role query []string false "The user's roles in the trip" collectionFormat(csv)
+// @Param q query string false "Text to search for in the title"
+// @Param sort query string false "Sort field" Enums(startDate,createdAt,updatedAt) default(startDate)
+// @Param order query string false "Sort order" Enums(asc,desc) default(desc)
+// @Param cursor query string false "X-Next-Cursor of the previous page"
+// @Param limit query int false "Page size, at most 100; 20 when only a cursor is given"
 // @Param  Authorization header string true "Authorization: Bearer"
 // @Produce json
 // @Router /trips [get]
 // @Success 200 {object} httpcommon.HttpResponse[[]model.TripResponse]
+// @Header 200 {string} X-Next-Cursor "Set when more trips follow"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
 // @Failure 500 {object} httpcommon.HttpResponse[any]
 func (handler *TripHandler) GetAllTrips(ctx *gin.Context) {
 	userId := middleware.GetUserIdHelper(ctx)
 
-	trips, errCode := handler.tripService.GetAllTripsByUserID(ctx, userId)
+	request := model.TripListRequest{
+		Statuses:   queryList(ctx, "status"),
+		City:       strings.TrimSpace(ctx.Query("city")),
+		Roles:      queryList(ctx, "role"),
+		Search:     strings.TrimSpace(ctx.Query("q")),
+		Sort:       ctx.Query("sort"),
+		Descending: true,
+		Cursor:     ctx.Query("cursor"),
+	}
+	// paging is opt-in, so clients reading the plain list keep getting every trip
+	if request.Cursor != "" {
+		request.Limit = defaultTripPageSize
+	}
+
+	for _, param := range []string{"from", "to"} {
+		value := ctx.Query(param)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, param)
+			ctx.JSON(statusCode, errResponse)
+			return
+		}
+		if param == "from" {
+			request.From = &date
+		} else {
+			request.To = &date
+		}
+	}
+
+	switch ctx.DefaultQuery("order", "desc") {
+	case "asc":
+		request.Descending = false
+	case "desc":
+	default:
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "order")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	if value := ctx.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxTripPageSize {
+			statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "limit")
+			ctx.JSON(statusCode, errResponse)
+			return
+		}
+		request.Limit = parsed
+	}
+
+	page, errCode := handler.tripService.GetAllTripsByUserID(ctx, userId, request)
 	if errCode != "" {
 		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
 		ctx.JSON(statusCode, errResponse)
 		return
 	}
 
-	ctx.JSON(200, httpcommon.NewSuccessResponse(&trips))
+	if page.NextCursor != nil {
+		ctx.Header("X-Next-Cursor", *page.NextCursor)
+	}
+	ctx.JSON(200, httpcommon.NewSuccessResponse(&page.Trips))
+}
+
+// queryList collects a query parameter given repeatedly or as a comma-separated list
+func queryList(ctx *gin.Context, key string) []string {
+	var values []string
+	for _, value := range ctx.QueryArray(key) {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
 }
 
 // @Summary Get trip by ID
@@ -169,6 +267,7 @@ func (handler *TripHandler) GetTrip(ctx *gin.Context) {
 // @Param tripId path int true "Trip ID"
 // @Param  Authorization header string true "Authorization: Bearer"
 // @Param language query string false "Language for place info (vi or en)" Enums(vi,en) default(vi)
+// @Param travelMode query string false "Travel mode for the estimates between consecutive stops, omit to skip them" Enums(walk,drive,transit)
 // @Produce json
 // @Router /trips/{tripId}/trip-items [get]
 // @Success 200 {object} httpcommon.HttpResponse[[]model.TripItemResponse]
@@ -197,7 +296,15 @@ func (handler *TripHandler) GetTripItems(ctx *gin.Context) {
 		lang = "vi"
 	}
 
-	tripItems, errCode := handler.tripItemService.GetTripItemsByTripID(ctx, userId, tripIdInt)
+	// travel estimates are only attached when a travel mode is asked for
+	travelMode := ctx.Query("travelMode")
+	if travelMode != "" && !model.IsValidTravelMode(travelMode) {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "travelMode")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	tripItems, errCode := handler.tripItemService.GetTripItemsByTripID(ctx, userId, tripIdInt, travelMode)
 	if errCode != "" {
 		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
 		ctx.JSON(statusCode, errResponse)
@@ -317,7 +424,7 @@ func (handler *TripHandler) CreateTripByAI(ctx *gin.Context) {
 }
 
 // @Summary Delete trip
-// @Description Delete a trip (admin only)
+// @Description Move a trip to the trash (owner only). Admins can restore it for 30 days before it is deleted for good
 // @Tags Trips
 // @Param tripId path int true "Trip ID"
 // @Param  Authorization header string true "Authorization: Bearer"
@@ -354,3 +461,400 @@ func (handler *TripHandler) DeleteTrip(ctx *gin.Context) {
 
 	ctx.AbortWithStatus(204)
 }
+
+// @Summary Get trip trash
+// @Description Get the deleted trips the user can still restore, latest deleted first
+// @Tags Trips
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce json
+// @Router /trips/trash [get]
+// @Success 200 {object} httpcommon.HttpResponse[[]model.TripTrashResponse]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripHandler) GetTrash(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	trips, errCode := handler.tripService.GetTrash(ctx, userId)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.JSON(200, httpcommon.NewSuccessResponse(&trips))
+}
+
+// @Summary Restore trip
+// @Description Bring a deleted trip back from the trash with its items, members and images (admin only)
+// @Tags Trips
+// @Param tripId path int true "Trip ID"
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce json
+// @Router /trips/{tripId}/restore [post]
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripHandler) RestoreTrip(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	tripId, err := strconv.ParseInt(ctx.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	errCode := handler.tripService.RestoreTrip(ctx, tripId, userId)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.AbortWithStatus(204)
+}
+
+// @Summary Cancel trip
+// @Description Cancel a trip that has not ended yet and notify the other members (admin only)
+// @Tags Trips
+// @Param tripId path int true "Trip ID"
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce json
+// @Router /trips/{tripId}/cancel [post]
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 409 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripHandler) CancelTrip(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	tripId, err := strconv.ParseInt(ctx.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	errCode := handler.tripService.CancelTrip(ctx, tripId, userId)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.AbortWithStatus(204)
+}
+
+// @Summary Archive trip
+// @Description Archive a completed, cancelled or failed trip and notify the other members (admin only)
+// @Tags Trips
+// @Param tripId path int true "Trip ID"
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce json
+// @Router /trips/{tripId}/archive [post]
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 409 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripHandler) ArchiveTrip(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	tripId, err := strconv.ParseInt(ctx.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	errCode := handler.tripService.ArchiveTrip(ctx, tripId, userId)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.AbortWithStatus(204)
+}
+
+// @Summary Unarchive trip
+// @Description Move an archived trip back to the status it was archived from and notify the other members (admin only)
+// @Tags Trips
+// @Param tripId path int true "Trip ID"
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce json
+// @Router /trips/{tripId}/unarchive [post]
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 409 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripHandler) UnarchiveTrip(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	tripId, err := strconv.ParseInt(ctx.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	errCode := handler.tripService.UnarchiveTrip(ctx, tripId, userId)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.AbortWithStatus(204)
+}
+
+// @Summary Export trip route
+// @Description Export the itinerary as per-day routes in GPX, KML or GeoJSON. Items whose place info cannot be resolved are listed in the file and counted in the X-Unresolved-Items header
+// @Tags Trips
+// @Param tripId path int true "Trip ID"
+// @Param format query string true "Export format" Enums(gpx,kml,geojson)
+// @Param language query string false "Language for place info (vi or en)" Enums(vi,en) default(vi)
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce application/gpx+xml
+// @Produce application/vnd.google-earth.kml+xml
+// @Produce application/geo+json
+// @Router /trips/{tripId}/route [get]
+// @Success 200 {file} file
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripHandler) ExportTripRoute(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	tripIdInt, err := strconv.ParseInt(ctx.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	format := ctx.Query("format")
+	if format != model.TripRouteFormat.GPX && format != model.TripRouteFormat.KML && format != model.TripRouteFormat.GeoJSON {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "format")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	route, errCode := handler.tripItemService.GetTripRoute(ctx, userId, tripIdInt)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	var content []byte
+	var contentType string
+	switch format {
+	case model.TripRouteFormat.GPX:
+		content, err = routeutils.EncodeGPX(*route)
+		contentType = "application/gpx+xml"
+	case model.TripRouteFormat.KML:
+		content, err = routeutils.EncodeKML(*route)
+		contentType = "application/vnd.google-earth.kml+xml"
+	default:
+		content, err = routeutils.EncodeGeoJSON(*route)
+		contentType = "application/geo+json"
+	}
+	if err != nil {
+		log.Error("TripHandler.ExportTripRoute Encode error: " + err.Error())
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.INTERNAL_SERVER_ERROR, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"trip-%d-route.%s\"", tripIdInt, format))
+	ctx.Header("X-Unresolved-Items", strconv.Itoa(len(route.UnresolvedItems)))
+	ctx.Data(200, contentType, content)
+}
+
+// @Summary Download itinerary PDF
+// @Description Download a printable PDF with a cover page and a day-by-day table of the trip items
+// @Tags Trips
+// @Param tripId path int true "Trip ID"
+// @Param language query string false "Language for labels and place info (vi or en)" Enums(vi,en) default(vi)
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce application/pdf
+// @Router /trips/{tripId}/itinerary.pdf [get]
+// @Success 200 {file} file
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripHandler) ExportItineraryPDF(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	tripIdInt, err := strconv.ParseInt(ctx.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	// Get language from query, only allow 'vi' and 'en', default to 'vi'
+	lang := ctx.DefaultQuery("language", "vi")
+	if lang != "vi" && lang != "en" {
+		lang = "vi"
+	}
+
+	itinerary, errCode := handler.tripService.GetTripItinerary(ctx, tripIdInt, userId)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	content, err := pdfutils.RenderItinerary(*itinerary, lang)
+	if err != nil {
+		log.Error("TripHandler.ExportItineraryPDF RenderItinerary error: " + err.Error())
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.INTERNAL_SERVER_ERROR, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"trip-%d-itinerary.pdf\"", tripIdInt))
+	ctx.Data(200, "application/pdf", content)
+}
+
+// @Summary Get trip travel summary
+// @Description Get estimated distance and travel time between consecutive stops of each day, with per-day and overall totals
+// @Tags Trips
+// @Param tripId path int true "Trip ID"
+// @Param travelMode query string false "Travel mode" Enums(walk,drive,transit) default(walk)
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce json
+// @Router /trips/{tripId}/travel-summary [get]
+// @Success 200 {object} httpcommon.HttpResponse[model.TripTravelSummaryResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripHandler) GetTripTravelSummary(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	tripIdInt, err := strconv.ParseInt(ctx.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	travelMode := ctx.DefaultQuery("travelMode", model.TravelMode.Walk)
+	if !model.IsValidTravelMode(travelMode) {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "travelMode")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	summary, errCode := handler.tripItemService.GetTripTravelSummary(ctx, userId, tripIdInt, travelMode)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.JSON(200, httpcommon.NewSuccessResponse(summary))
+}
+
+// @Summary Preview day route optimization
+// @Description Suggest a new order for the items of a trip day that minimises the travel distance. Nothing is saved, apply the preview through the trip items endpoint
+// @Tags Trips
+// @Accept json
+// @Param tripId path int true "Trip ID"
+// @Param day path int true "Trip day"
+// @Param request body model.OptimizeTripDayRequest true "Optimization options"
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce json
+// @Router /trips/{tripId}/days/{day}/optimize [post]
+// @Success 200 {object} httpcommon.HttpResponse[model.OptimizeTripDayResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 422 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripHandler) OptimizeTripDay(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	tripIdInt, err := strconv.ParseInt(ctx.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	tripDay, err := strconv.ParseInt(ctx.Param("day"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "day")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	var request model.OptimizeTripDayRequest
+	if err := validation.BindJsonAndValidate(ctx, &request); err != nil {
+		return
+	}
+	if request.TravelMode == "" {
+		request.TravelMode = model.TravelMode.Walk
+	}
+	if !model.IsValidTravelMode(request.TravelMode) {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "travelMode")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	preview, errCode := handler.tripItemService.OptimizeTripDay(ctx, userId, tripIdInt, tripDay, request)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.JSON(200, httpcommon.NewSuccessResponse(preview))
+}
+
+// @Summary Validate trip schedule
+// @Description Report overlapping or out of order items, items beyond the trip length, unrealistic travel gaps and duplicate places. Errors are rejected when trip items are saved, warnings are informational
+// @Tags Trips
+// @Param tripId path int true "Trip ID"
+// @Param travelMode query string false "Travel mode used to check the gaps between stops" Enums(walk,drive,transit) default(walk)
+// @Param  Authorization header string true "Authorization: Bearer"
+// @Produce json
+// @Router /trips/{tripId}/validation [get]
+// @Success 200 {object} httpcommon.HttpResponse[model.TripValidationResponse]
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+func (handler *TripHandler) ValidateTripSchedule(ctx *gin.Context) {
+	userId := middleware.GetUserIdHelper(ctx)
+
+	tripIdInt, err := strconv.ParseInt(ctx.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	travelMode := ctx.DefaultQuery("travelMode", model.TravelMode.Walk)
+	if !model.IsValidTravelMode(travelMode) {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "travelMode")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	report, errCode := handler.tripItemService.ValidateTripSchedule(ctx, userId, tripIdInt, travelMode)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		ctx.JSON(statusCode, errResponse)
+		return
+	}
+
+	ctx.JSON(200, httpcommon.NewSuccessResponse(report))
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
 	httpcommon "github.com/swefinal-travel-planner/travel-app-be/internal/domain/http_common"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/validation"
 )
 
 type TripMemberHandler struct {
@@ -87,3 +89,114 @@ func (h *TripMemberHandler) DeleteTripMember(c *gin.Context) {
 
 	c.AbortWithStatus(http.StatusNoContent)
 }
+
+// @Summary Change a member's role
+// @Description Change the role of a trip member (admin only). Admins can only manage members ranked below them, and ownership cannot be granted this way
+// @Tags TripsMember
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param memberId path int true "Member ID"
+// @Param request body model.TripMemberRoleRequest true "New role"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/members/{memberId}/role [patch]
+func (h *TripMemberHandler) ChangeTripMemberRole(c *gin.Context) {
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	memberID, err := strconv.ParseInt(c.Param("memberId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "memberId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var roleRequest model.TripMemberRoleRequest
+	if err := validation.BindJsonAndValidate(c, &roleRequest); err != nil {
+		return
+	}
+
+	changerID := middleware.GetUserIdHelper(c)
+	errCode := h.tripMemberService.ChangeMemberRole(c.Request.Context(), tripID, memberID, changerID, roleRequest.Role)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+// @Summary Leave a trip
+// @Description Leave a trip. When the owner leaves, the longest-standing admin, or else the longest-standing member, becomes the owner. The only member of a trip cannot leave it
+// @Tags TripsMember
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 409 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/leave [post]
+func (h *TripMemberHandler) LeaveTrip(c *gin.Context) {
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	userID := middleware.GetUserIdHelper(c)
+	errCode := h.tripMemberService.LeaveTrip(c, tripID, userID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+// @Summary Transfer trip ownership
+// @Description Hand the trip over to another member (owner only). The previous owner stays on as an administrator
+// @Tags TripsMember
+// @Accept json
+// @Produce json
+// @Param tripId path int true "Trip ID"
+// @Param request body model.TripOwnershipTransferRequest true "New owner"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/transfer-ownership [post]
+func (h *TripMemberHandler) TransferOwnership(c *gin.Context) {
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	var transferRequest model.TripOwnershipTransferRequest
+	if err := validation.BindJsonAndValidate(c, &transferRequest); err != nil {
+		return
+	}
+
+	ownerID := middleware.GetUserIdHelper(c)
+	errCode := h.tripMemberService.TransferOwnership(c, tripID, ownerID, transferRequest.UserID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
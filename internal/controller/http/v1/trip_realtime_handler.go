@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/controller/http/middleware"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/env"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+const (
+	tripSocketWriteWait    = 10 * time.Second
+	tripSocketPongWait     = 60 * time.Second
+	tripSocketPingInterval = 30 * time.Second
+	tripSocketMaxMessage   = 4096
+)
+
+type TripRealtimeHandler struct {
+	tripRealtimeService service.TripRealtimeService
+	upgrader            websocket.Upgrader
+}
+
+func NewTripRealtimeHandler(tripRealtimeService service.TripRealtimeService) *TripRealtimeHandler {
+	return &TripRealtimeHandler{
+		tripRealtimeService: tripRealtimeService,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     checkSocketOrigin,
+		},
+	}
+}
+
+// native apps send no Origin, browsers must come from the origin allowed by CORS
+func checkSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	allowedOrigins, err := env.GetEnv("ALLOWED_ORIGINS")
+	if err != nil {
+		return false
+	}
+	for _, allowed := range strings.Split(allowedOrigins, ",") {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// @Summary Live trip updates
+// @Description Open a WebSocket that streams item, trip, member and presence events of a trip.
+// @Description The client sends {"tripDay": 2, "view": "itinerary"} whenever what it shows changes.
+// @Description Browsers pass the access token in the accessToken query parameter.
+// @Tags TripRealtime
+// @Param tripId path int true "Trip ID"
+// @Param accessToken query string false "Access token, when the Authorization header cannot be set"
+// @Param Authorization header string false "Authorization: Bearer"
+// @Success 101 "Switching Protocols"
+// @Failure 400 {object} httpcommon.HttpResponse[any]
+// @Failure 401 {object} httpcommon.HttpResponse[any]
+// @Failure 403 {object} httpcommon.HttpResponse[any]
+// @Failure 404 {object} httpcommon.HttpResponse[any]
+// @Failure 500 {object} httpcommon.HttpResponse[any]
+// @Router /trips/{tripId}/live [get]
+func (h *TripRealtimeHandler) Connect(c *gin.Context) {
+	userID := middleware.GetUserIdHelper(c)
+
+	tripID, err := strconv.ParseInt(c.Param("tripId"), 10, 64)
+	if err != nil {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(error_utils.ErrorCode.BAD_REQUEST, "tripId")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+
+	session, errCode := h.tripRealtimeService.Connect(c, userID, tripID)
+	if errCode != "" {
+		statusCode, errResponse := error_utils.ErrorCodeToHttpResponse(errCode, "")
+		c.JSON(statusCode, errResponse)
+		return
+	}
+	// the socket outlives the request, so nothing below may use the gin context
+	ctx := context.Background()
+	defer h.tripRealtimeService.Disconnect(ctx, session)
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the upgrader has already written the error response
+		log.Error("TripRealtimeHandler.Connect Upgrade error: " + err.Error())
+		return
+	}
+	defer conn.Close()
+
+	done := make(chan struct{})
+	go h.readPresence(ctx, conn, session, done)
+
+	presence, err := json.Marshal(session.Presence)
+	if err != nil {
+		log.Error("TripRealtimeHandler.Connect Marshal presence error: " + err.Error())
+		return
+	}
+	err = h.writeEvent(conn, model.TripEvent{
+		Type:      model.TripEventType.PresenceSnapshot,
+		TripID:    tripID,
+		Data:      presence,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(tripSocketPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case event, ok := <-session.Events:
+			if !ok {
+				// dropped by the server, the client should refetch the trip and reconnect
+				conn.SetWriteDeadline(time.Now().Add(tripSocketWriteWait))
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "resync"))
+				return
+			}
+			if err := h.writeEvent(conn, event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			h.tripRealtimeService.RefreshPresence(ctx, session)
+			conn.SetWriteDeadline(time.Now().Add(tripSocketWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func (h *TripRealtimeHandler) readPresence(ctx context.Context, conn *websocket.Conn, session *model.TripRealtimeSession, done chan struct{}) {
+	defer close(done)
+
+	conn.SetReadLimit(tripSocketMaxMessage)
+	conn.SetReadDeadline(time.Now().Add(tripSocketPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(tripSocketPongWait))
+	})
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		// a malformed message is ignored rather than ending the session
+		var presenceRequest model.TripPresenceRequest
+		if err := json.Unmarshal(message, &presenceRequest); err != nil {
+			continue
+		}
+		if (presenceRequest.TripDay != nil && *presenceRequest.TripDay < 1) || len(presenceRequest.View) > 50 {
+			continue
+		}
+		h.tripRealtimeService.UpdatePresence(ctx, session, presenceRequest)
+	}
+}
+
+func (h *TripRealtimeHandler) writeEvent(conn *websocket.Conn, event model.TripEvent) error {
+	conn.SetWriteDeadline(time.Now().Add(tripSocketWriteWait))
+	return conn.WriteJSON(event)
+}
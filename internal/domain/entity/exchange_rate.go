@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type ExchangeRate struct {
+	ID          int64     `json:"id,omitempty" db:"id"`
+	Currency    string    `json:"currency,omitempty" db:"currency"`
+	UnitsPerUSD float64   `json:"unitsPerUsd,omitempty" db:"units_per_usd"`
+	CreatedAt   time.Time `json:"createdAt,omitempty" db:"created_at"`
+	UpdatedAt   time.Time `json:"updatedAt,omitempty" db:"updated_at"`
+}
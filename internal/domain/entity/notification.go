@@ -19,21 +19,39 @@ type Notification struct {
 }
 
 type notificationType struct {
-	FriendRequestReceived  string
-	FriendRequestAccepted  string
-	TripInvitationReceived string
-	TripGenerated          string
-	TripGeneratedFailed    string
-	TripStartingSoon       string
+	FriendRequestReceived    string
+	FriendRequestAccepted    string
+	TripInvitationReceived   string
+	TripGenerated            string
+	TripGeneratedFailed      string
+	TripStartingSoon         string
+	ChecklistItemsPending    string
+	TripCommentMention       string
+	TripPollOpened           string
+	TripPollClosed           string
+	TripMemberLeft           string
+	TripOwnershipTransferred string
+	TripCancelled            string
+	TripArchived             string
+	TripUnarchived           string
 }
 
 var NotificationType = notificationType{
-	FriendRequestReceived:  "friendRequestReceived",
-	FriendRequestAccepted:  "friendRequestAccepted",
-	TripInvitationReceived: "tripInvitationReceived",
-	TripGenerated:          "tripGenerated",
-	TripGeneratedFailed:    "tripGeneratedFailed",
-	TripStartingSoon:       "tripStartingSoon",
+	FriendRequestReceived:    "friendRequestReceived",
+	FriendRequestAccepted:    "friendRequestAccepted",
+	TripInvitationReceived:   "tripInvitationReceived",
+	TripGenerated:            "tripGenerated",
+	TripGeneratedFailed:      "tripGeneratedFailed",
+	TripStartingSoon:         "tripStartingSoon",
+	ChecklistItemsPending:    "checklistItemsPending",
+	TripCommentMention:       "tripCommentMention",
+	TripPollOpened:           "tripPollOpened",
+	TripPollClosed:           "tripPollClosed",
+	TripMemberLeft:           "tripMemberLeft",
+	TripOwnershipTransferred: "tripOwnershipTransferred",
+	TripCancelled:            "tripCancelled",
+	TripArchived:             "tripArchived",
+	TripUnarchived:           "tripUnarchived",
 }
 
 type notificationReferenceType struct {
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// SyncTombstone records a hard-deleted row so offline clients learn about the deletion on their next sync.
+// A tombstone is visible to UserID when set, otherwise to every current member of TripID
+type SyncTombstone struct {
+	ID         int64     `json:"id,omitempty" db:"id"`
+	EntityType string    `json:"entityType,omitempty" db:"entity_type"`
+	EntityID   int64     `json:"entityId,omitempty" db:"entity_id"`
+	TripID     *int64    `json:"tripId,omitempty" db:"trip_id"`
+	UserID     *int64    `json:"userId,omitempty" db:"user_id"`
+	DeletedAt  time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
+}
+
+type syncEntityType struct {
+	Trip         string
+	TripItem     string
+	TripMember   string
+	TripImage    string
+	Invitation   string
+	Notification string
+}
+
+var SyncEntityType = syncEntityType{
+	Trip:         "trip",
+	TripItem:     "tripItem",
+	TripMember:   "tripMember",
+	TripImage:    "tripImage",
+	Invitation:   "invitation",
+	Notification: "notification",
+}
@@ -12,8 +12,10 @@ type Trip struct {
 	Title                 string                        `json:"title,omitempty" db:"title"`
 	City                  string                        `json:"city,omitempty" db:"city"`
 	StartDate             time.Time                     `json:"startDate,omitempty" db:"start_date"`
+	TimeZone              string                        `json:"timeZone,omitempty" db:"time_zone"`
 	Days                  int                           `json:"days,omitempty" db:"days"`
 	Budget                float64                       `json:"budget,omitempty" db:"budget"`
+	BudgetCurrency        string                        `json:"budgetCurrency,omitempty" db:"budget_currency"`
 	ViLocationAttributes  stringlistutils.SqlListString `json:"viLocationAttributes,omitempty" db:"vi_location_attributes"`
 	ViFoodAttributes      stringlistutils.SqlListString `json:"viFoodAttributes,omitempty" db:"vi_food_attributes"`
 	ViSpecialRequirements stringlistutils.SqlListString `json:"viSpecialRequirements,omitempty" db:"vi_special_requirements"`
@@ -23,6 +25,7 @@ type Trip struct {
 	EnSpecialRequirements stringlistutils.SqlListString `json:"enSpecialRequirements,omitempty" db:"en_special_requirements"`
 	EnMedicalConditions   stringlistutils.SqlListString `json:"enMedicalConditions,omitempty" db:"en_medical_conditions"`
 	Status                string                        `json:"status,omitempty" db:"status"`
+	StatusBeforeArchive   *string                       `json:"-" db:"status_before_archive"`
 	ReferenceID           *string                       `json:"referenceId" db:"reference_id"`
 	CreatedAt             time.Time                     `json:"createdAt,omitempty" db:"created_at"`
 	UpdatedAt             time.Time                     `json:"updatedAt,omitempty" db:"updated_at"`
@@ -35,11 +38,17 @@ type TripWithRole struct {
 }
 
 type tripRole struct {
+	Owner         string
 	Administrator string
+	Editor        string
 	Member        string
+	Viewer        string
 }
 
 var TripRole = tripRole{
+	Owner:         "owner",
 	Administrator: "administrator",
+	Editor:        "editor",
 	Member:        "member",
+	Viewer:        "viewer",
 }
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type TripActivity struct {
+	ID         int64     `json:"id,omitempty" db:"id"`
+	TripID     int64     `json:"tripId,omitempty" db:"trip_id"`
+	ActorID    *int64    `json:"actorId,omitempty" db:"actor_id"`
+	Action     string    `json:"action,omitempty" db:"action"`
+	EntityType string    `json:"entityType,omitempty" db:"entity_type"`
+	EntityID   *int64    `json:"entityId,omitempty" db:"entity_id"`
+	Before     *string   `json:"before,omitempty" db:"before_value"`
+	After      *string   `json:"after,omitempty" db:"after_value"`
+	CreatedAt  time.Time `json:"createdAt,omitempty" db:"created_at"`
+}
+
+type TripActivityWithActor struct {
+	TripActivity
+	ActorName     *string `db:"actor_name"`
+	ActorPhotoURL *string `db:"actor_photo_url"`
+}
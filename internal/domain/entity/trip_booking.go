@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	stringlistutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/string_list_utils"
+)
+
+type TripBooking struct {
+	ID                 int64                         `json:"id,omitempty" db:"id"`
+	TripID             int64                         `json:"tripId,omitempty" db:"trip_id"`
+	CreatedBy          int64                         `json:"createdBy,omitempty" db:"created_by"`
+	Type               string                        `json:"type,omitempty" db:"type"`
+	Title              string                        `json:"title,omitempty" db:"title"`
+	Provider           *string                       `json:"provider,omitempty" db:"provider"`
+	ConfirmationNumber *string                       `json:"confirmationNumber,omitempty" db:"confirmation_number"`
+	Address            *string                       `json:"address,omitempty" db:"address"`
+	Lat                *float64                      `json:"lat,omitempty" db:"lat"`
+	Long               *float64                      `json:"long,omitempty" db:"long"`
+	FromLocation       *string                       `json:"fromLocation,omitempty" db:"from_location"`
+	ToLocation         *string                       `json:"toLocation,omitempty" db:"to_location"`
+	StartAt            time.Time                     `json:"startAt,omitempty" db:"start_at"`
+	EndAt              *time.Time                    `json:"endAt,omitempty" db:"end_at"`
+	Note               *string                       `json:"note,omitempty" db:"note"`
+	Attachments        stringlistutils.SqlListString `json:"attachments,omitempty" db:"attachments"`
+	CreatedAt          time.Time                     `json:"createdAt,omitempty" db:"created_at"`
+	UpdatedAt          time.Time                     `json:"updatedAt,omitempty" db:"updated_at"`
+	DeletedAt          sql.NullTime                  `json:"deletedAt,omitempty" db:"deleted_at"`
+}
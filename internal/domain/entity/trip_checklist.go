@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TripChecklist struct {
+	ID        int64        `json:"id,omitempty" db:"id"`
+	TripID    int64        `json:"tripId,omitempty" db:"trip_id"`
+	CreatedBy int64        `json:"createdBy,omitempty" db:"created_by"`
+	Type      string       `json:"type,omitempty" db:"type"`
+	Title     string       `json:"title,omitempty" db:"title"`
+	CreatedAt time.Time    `json:"createdAt,omitempty" db:"created_at"`
+	UpdatedAt time.Time    `json:"updatedAt,omitempty" db:"updated_at"`
+	DeletedAt sql.NullTime `json:"deletedAt,omitempty" db:"deleted_at"`
+}
+
+type TripChecklistItem struct {
+	ID          int64        `json:"id,omitempty" db:"id"`
+	ChecklistID int64        `json:"checklistId,omitempty" db:"checklist_id"`
+	TripID      int64        `json:"tripId,omitempty" db:"trip_id"`
+	CreatedBy   int64        `json:"createdBy,omitempty" db:"created_by"`
+	Title       string       `json:"title,omitempty" db:"title"`
+	AssigneeID  *int64       `json:"assigneeId,omitempty" db:"assignee_id"`
+	DueDate     *time.Time   `json:"dueDate,omitempty" db:"due_date"`
+	IsDone      bool         `json:"isDone,omitempty" db:"is_done"`
+	DoneBy      *int64       `json:"doneBy,omitempty" db:"done_by"`
+	DoneAt      *time.Time   `json:"doneAt,omitempty" db:"done_at"`
+	CreatedAt   time.Time    `json:"createdAt,omitempty" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updatedAt,omitempty" db:"updated_at"`
+	DeletedAt   sql.NullTime `json:"deletedAt,omitempty" db:"deleted_at"`
+}
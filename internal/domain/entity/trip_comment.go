@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TripComment struct {
+	ID         int64        `json:"id,omitempty" db:"id"`
+	TripID     int64        `json:"tripId,omitempty" db:"trip_id"`
+	TripItemID *int64       `json:"tripItemId,omitempty" db:"trip_item_id"`
+	ParentID   *int64       `json:"parentId,omitempty" db:"parent_id"`
+	AuthorID   int64        `json:"authorId,omitempty" db:"author_id"`
+	Content    string       `json:"content,omitempty" db:"content"`
+	EditedAt   *time.Time   `json:"editedAt,omitempty" db:"edited_at"`
+	CreatedAt  time.Time    `json:"createdAt,omitempty" db:"created_at"`
+	UpdatedAt  time.Time    `json:"updatedAt,omitempty" db:"updated_at"`
+	DeletedAt  sql.NullTime `json:"deletedAt,omitempty" db:"deleted_at"`
+}
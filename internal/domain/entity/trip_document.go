@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TripDocument struct {
+	ID            int64        `json:"id,omitempty" db:"id"`
+	TripID        int64        `json:"tripId,omitempty" db:"trip_id"`
+	UploadedBy    int64        `json:"uploadedBy,omitempty" db:"uploaded_by"`
+	Title         string       `json:"title,omitempty" db:"title"`
+	FileName      string       `json:"fileName,omitempty" db:"file_name"`
+	ContentType   string       `json:"contentType,omitempty" db:"content_type"`
+	S3Key         string       `json:"s3Key,omitempty" db:"s3_key"`
+	Visibility    string       `json:"visibility,omitempty" db:"visibility"`
+	TripItemID    *int64       `json:"tripItemId,omitempty" db:"trip_item_id"`
+	TripBookingID *int64       `json:"tripBookingId,omitempty" db:"trip_booking_id"`
+	CreatedAt     time.Time    `json:"createdAt,omitempty" db:"created_at"`
+	UpdatedAt     time.Time    `json:"updatedAt,omitempty" db:"updated_at"`
+	DeletedAt     sql.NullTime `json:"deletedAt,omitempty" db:"deleted_at"`
+}
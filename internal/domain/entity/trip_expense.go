@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TripExpense struct {
+	ID           int64        `json:"id,omitempty" db:"id"`
+	TripID       int64        `json:"tripId,omitempty" db:"trip_id"`
+	TripItemID   *int64       `json:"tripItemId,omitempty" db:"trip_item_id"`
+	PayerID      int64        `json:"payerId,omitempty" db:"payer_id"`
+	CreatedBy    int64        `json:"createdBy,omitempty" db:"created_by"`
+	Description  string       `json:"description,omitempty" db:"description"`
+	Amount       float64      `json:"amount,omitempty" db:"amount"`
+	Currency     string       `json:"currency,omitempty" db:"currency"`
+	SplitType    string       `json:"splitType,omitempty" db:"split_type"`
+	Category     string       `json:"category,omitempty" db:"category"`
+	TripDay      *int64       `json:"tripDay,omitempty" db:"trip_day"`
+	BaseCurrency *string      `json:"baseCurrency,omitempty" db:"base_currency"`
+	ExchangeRate *float64     `json:"exchangeRate,omitempty" db:"exchange_rate"`
+	BaseAmount   *float64     `json:"baseAmount,omitempty" db:"base_amount"`
+	CreatedAt    time.Time    `json:"createdAt,omitempty" db:"created_at"`
+	UpdatedAt    time.Time    `json:"updatedAt,omitempty" db:"updated_at"`
+	DeletedAt    sql.NullTime `json:"deletedAt,omitempty" db:"deleted_at"`
+}
+
+type TripExpenseSplit struct {
+	ID        int64   `json:"id,omitempty" db:"id"`
+	ExpenseID int64   `json:"expenseId,omitempty" db:"expense_id"`
+	UserID    int64   `json:"userId,omitempty" db:"user_id"`
+	Share     float64 `json:"share,omitempty" db:"share"`
+	Amount    float64 `json:"amount,omitempty" db:"amount"`
+}
+
+type TripSettlement struct {
+	ID         int64     `json:"id,omitempty" db:"id"`
+	TripID     int64     `json:"tripId,omitempty" db:"trip_id"`
+	FromUserID int64     `json:"fromUserId,omitempty" db:"from_user_id"`
+	ToUserID   int64     `json:"toUserId,omitempty" db:"to_user_id"`
+	CreatedBy  int64     `json:"createdBy,omitempty" db:"created_by"`
+	Amount     float64   `json:"amount,omitempty" db:"amount"`
+	Currency   string    `json:"currency,omitempty" db:"currency"`
+	CreatedAt  time.Time `json:"createdAt,omitempty" db:"created_at"`
+	UpdatedAt  time.Time `json:"updatedAt,omitempty" db:"updated_at"`
+}
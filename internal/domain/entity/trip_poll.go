@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TripPoll struct {
+	ID                int64        `json:"id,omitempty" db:"id"`
+	TripID            int64        `json:"tripId,omitempty" db:"trip_id"`
+	CreatedBy         int64        `json:"createdBy,omitempty" db:"created_by"`
+	Question          string       `json:"question,omitempty" db:"question"`
+	OptionType        string       `json:"optionType,omitempty" db:"option_type"`
+	AllowMultiple     bool         `json:"allowMultiple,omitempty" db:"allow_multiple"`
+	IsAnonymous       bool         `json:"isAnonymous,omitempty" db:"is_anonymous"`
+	Deadline          *time.Time   `json:"deadline,omitempty" db:"deadline"`
+	Status            string       `json:"status,omitempty" db:"status"`
+	ClosedAt          *time.Time   `json:"closedAt,omitempty" db:"closed_at"`
+	ApplyToTripItemID *int64       `json:"applyToTripItemId,omitempty" db:"apply_to_trip_item_id"`
+	WinningOptionID   *int64       `json:"winningOptionId,omitempty" db:"winning_option_id"`
+	CreatedAt         time.Time    `json:"createdAt,omitempty" db:"created_at"`
+	UpdatedAt         time.Time    `json:"updatedAt,omitempty" db:"updated_at"`
+	DeletedAt         sql.NullTime `json:"deletedAt,omitempty" db:"deleted_at"`
+}
+
+type TripPollOption struct {
+	ID          int64   `json:"id,omitempty" db:"id"`
+	PollID      int64   `json:"pollId,omitempty" db:"poll_id"`
+	Label       string  `json:"label,omitempty" db:"label"`
+	PlaceID     *string `json:"placeId,omitempty" db:"place_id"`
+	OptionOrder int     `json:"optionOrder,omitempty" db:"option_order"`
+}
+
+type TripPollVote struct {
+	ID        int64     `json:"id,omitempty" db:"id"`
+	PollID    int64     `json:"pollId,omitempty" db:"poll_id"`
+	OptionID  int64     `json:"optionId,omitempty" db:"option_id"`
+	UserID    int64     `json:"userId,omitempty" db:"user_id"`
+	CreatedAt time.Time `json:"createdAt,omitempty" db:"created_at"`
+}
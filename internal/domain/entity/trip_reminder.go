@@ -0,0 +1,12 @@
+package entity
+
+type tripReminderType struct {
+	StartingSoon     string
+	ChecklistPending string
+}
+
+// TripReminderType names the reminders sent once per trip before it starts
+var TripReminderType = tripReminderType{
+	StartingSoon:     "startingSoon",
+	ChecklistPending: "checklistPending",
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	stringlistutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/string_list_utils"
+)
+
+type TripSegment struct {
+	ID                   int64                         `db:"id"`
+	TripID               int64                         `db:"trip_id"`
+	SegmentOrder         int                           `db:"segment_order"`
+	City                 string                        `db:"city"`
+	StartDay             int                           `db:"start_day"`
+	Days                 int                           `db:"days"`
+	ViLocationAttributes stringlistutils.SqlListString `db:"vi_location_attributes"`
+	ViFoodAttributes     stringlistutils.SqlListString `db:"vi_food_attributes"`
+	EnLocationAttributes stringlistutils.SqlListString `db:"en_location_attributes"`
+	EnFoodAttributes     stringlistutils.SqlListString `db:"en_food_attributes"`
+	ReferenceID          *string                       `db:"reference_id"`
+	CreatedAt            time.Time                     `db:"created_at"`
+	UpdatedAt            time.Time                     `db:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type ExchangeRateImportRequest struct {
+	// Base is the currency the rates are quoted against, USD when empty
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates" binding:"required,min=1"`
+}
+
+type ExchangeRateImportResponse struct {
+	Imported int `json:"imported"`
+}
+
+type ExchangeRateResponse struct {
+	Currency    string    `json:"currency"`
+	UnitsPerUSD float64   `json:"unitsPerUsd"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SyncResponse holds everything that changed for the user since the cursor.
+// Clients apply Tombstones first, then upsert the changed rows, and send Cursor with the next sync
+type SyncResponse struct {
+	Cursor string `json:"cursor"`
+	// FullResync is set when the cursor was missing or too old; the client must replace its local copy with this response
+	FullResync    bool                       `json:"fullResync"`
+	Trips         []SyncTripResponse         `json:"trips"`
+	TripItems     []SyncTripItemResponse     `json:"tripItems"`
+	TripMembers   []SyncTripMemberResponse   `json:"tripMembers"`
+	TripImages    []SyncTripImageResponse    `json:"tripImages"`
+	Invitations   []SyncInvitationResponse   `json:"invitations"`
+	Notifications []SyncNotificationResponse `json:"notifications"`
+	Tombstones    []SyncTombstoneResponse    `json:"tombstones"`
+}
+
+type SyncTripResponse struct {
+	TripResponse
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type SyncTripItemResponse struct {
+	TripItemResponse
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type SyncTripMemberResponse struct {
+	TripMemberResponse
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type SyncTripImageResponse struct {
+	TripImageResponse
+	UserID    int64     `json:"userId"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type SyncInvitationResponse struct {
+	ID         int64     `json:"id"`
+	TripID     int64     `json:"tripId"`
+	SenderID   int64     `json:"senderId"`
+	ReceiverID int64     `json:"receiverId"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+type SyncNotificationResponse struct {
+	NotificationResponse
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SyncTombstoneResponse is a deleted row; a trip tombstone also drops everything that belongs to the trip
+type SyncTombstoneResponse struct {
+	EntityType string    `json:"entityType"`
+	EntityID   int64     `json:"entityId"`
+	TripID     *int64    `json:"tripId"`
+	DeletedAt  time.Time `json:"deletedAt"`
+}
+
+type syncMutationType struct {
+	UpdateTrip       string
+	ReplaceTripItems string
+	DeleteTripImage  string
+	AcceptInvitation string
+	DenyInvitation   string
+	SeenNotification string
+}
+
+var SyncMutationType = syncMutationType{
+	UpdateTrip:       "updateTrip",
+	ReplaceTripItems: "replaceTripItems",
+	DeleteTripImage:  "deleteTripImage",
+	AcceptInvitation: "acceptInvitation",
+	DenyInvitation:   "denyInvitation",
+	SeenNotification: "seenNotification",
+}
+
+type syncMutationStatus struct {
+	Applied  string
+	Conflict string
+	Failed   string
+}
+
+var SyncMutationStatus = syncMutationStatus{
+	Applied:  "applied",
+	Conflict: "conflict",
+	Failed:   "failed",
+}
+
+// SyncMutationRequest is a batch of changes queued while offline, applied in order
+type SyncMutationRequest struct {
+	Mutations []SyncMutation `json:"mutations" binding:"required,min=1,max=100,dive"`
+}
+
+// SyncMutation is one queued change. TripID is required for updateTrip, replaceTripItems and deleteTripImage,
+// EntityID names the image, invitation or notification.
+// When BaseUpdatedAt is set, the change is only applied if the server copy has not changed since then
+type SyncMutation struct {
+	ClientMutationID string          `json:"clientMutationId" binding:"required,max=64"`
+	Type             string          `json:"type" binding:"required,oneof=updateTrip replaceTripItems deleteTripImage acceptInvitation denyInvitation seenNotification"`
+	TripID           int64           `json:"tripId"`
+	EntityID         int64           `json:"entityId"`
+	BaseUpdatedAt    *time.Time      `json:"baseUpdatedAt"`
+	Payload          json.RawMessage `json:"payload"`
+}
+
+type SyncMutationResult struct {
+	ClientMutationID string `json:"clientMutationId"`
+	Status           string `json:"status"`
+	ErrorCode        string `json:"errorCode,omitempty"`
+	// ServerUpdatedAt is when the server copy last changed, set on conflicts
+	ServerUpdatedAt *time.Time `json:"serverUpdatedAt,omitempty"`
+	// Issues explain why a replaceTripItems was rejected with TRIP_ITEM_SCHEDULE_CONFLICT
+	Issues []TripValidationIssue `json:"issues,omitempty"`
+}
+
+type SyncMutationResponse struct {
+	Results []SyncMutationResult `json:"results"`
+}
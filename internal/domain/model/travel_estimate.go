@@ -0,0 +1,40 @@
+package model
+
+type travelMode struct {
+	Walk    string
+	Drive   string
+	Transit string
+}
+
+var TravelMode = travelMode{
+	Walk:    "walk",
+	Drive:   "drive",
+	Transit: "transit",
+}
+
+func IsValidTravelMode(mode string) bool {
+	return mode == TravelMode.Walk || mode == TravelMode.Drive || mode == TravelMode.Transit
+}
+
+type TravelLeg struct {
+	FromTripItemID  int64   `json:"fromTripItemId"`
+	ToTripItemID    int64   `json:"toTripItemId"`
+	Mode            string  `json:"mode"`
+	DistanceMeters  float64 `json:"distanceMeters"`
+	DurationSeconds int64   `json:"durationSeconds"`
+}
+
+type TripDayTravelSummary struct {
+	TripDay              int64       `json:"tripDay"`
+	Legs                 []TravelLeg `json:"legs"`
+	TotalDistanceMeters  float64     `json:"totalDistanceMeters"`
+	TotalDurationSeconds int64       `json:"totalDurationSeconds"`
+	UnresolvedItemIDs    []int64     `json:"unresolvedItemIds"`
+}
+
+type TripTravelSummaryResponse struct {
+	Mode                 string                 `json:"mode"`
+	Days                 []TripDayTravelSummary `json:"days"`
+	TotalDistanceMeters  float64                `json:"totalDistanceMeters"`
+	TotalDurationSeconds int64                  `json:"totalDurationSeconds"`
+}
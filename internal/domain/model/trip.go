@@ -8,9 +8,14 @@ import (
 
 type CreateTripManuallyRequest struct {
 	Title                 string                        `json:"title" binding:"required,min=1"`
-	City                  string                        `json:"city" binding:"required"`
+	City                  string                        `json:"city" binding:"required_without=Segments"`
 	StartDate             time.Time                     `json:"startDate" binding:"required"`
-	Days                  int                           `json:"days" binding:"required,min=1,max=7"`
+	TimeZone              string                        `json:"timeZone"`
+	Days                  int                           `json:"days" binding:"required,min=1,max=30"`
+	Segments              []TripSegmentRequest          `json:"segments" binding:"omitempty,dive"`
+	Budget                float64                       `json:"budget" binding:"gte=0"`
+	BudgetCurrency        string                        `json:"budgetCurrency" binding:"omitempty,len=3"`
+	Draft                 bool                          `json:"draft"`
 	ViLocationAttributes  stringlistutils.SqlListString `json:"-"`
 	ViFoodAttributes      stringlistutils.SqlListString `json:"-"`
 	ViSpecialRequirements stringlistutils.SqlListString `json:"-"`
@@ -25,9 +30,13 @@ type CreateTripManuallyRequest struct {
 
 type CreateTripByAIRequest struct {
 	Title                 string                        `json:"title" binding:"required,min=1"`
-	City                  string                        `json:"city" binding:"required"`
+	City                  string                        `json:"city" binding:"required_without=Segments"`
 	StartDate             time.Time                     `json:"startDate" binding:"required"`
-	Days                  int                           `json:"days" binding:"required,min=1,max=7"`
+	TimeZone              string                        `json:"timeZone"`
+	Days                  int                           `json:"days" binding:"required,min=1,max=30"`
+	Segments              []TripSegmentRequest          `json:"segments" binding:"omitempty,dive"`
+	Budget                float64                       `json:"budget" binding:"gte=0"`
+	BudgetCurrency        string                        `json:"budgetCurrency" binding:"omitempty,len=3"`
 	ViLocationAttributes  stringlistutils.SqlListString `json:"viLocationAttributes"`
 	ViFoodAttributes      stringlistutils.SqlListString `json:"viFoodAttributes"`
 	ViSpecialRequirements stringlistutils.SqlListString `json:"viSpecialRequirements"`
@@ -38,6 +47,7 @@ type CreateTripByAIRequest struct {
 	EnMedicalConditions   stringlistutils.SqlListString `json:"enMedicalConditions"`
 	LocationsPerDay       int                           `json:"locationsPerDay" binding:"required,min=5,max=9"`
 	LocationPreference    string                        `json:"locationPreference"`
+	Hotel                 *HotelChoiceRequest           `json:"hotel"`
 	ReferenceID           string                        `json:"referenceId,omitempty"`
 }
 
@@ -50,6 +60,7 @@ type TripToCoreRequest struct {
 	MedicalConditions   stringlistutils.SqlListString `json:"medical_conditions"`
 	LocationsPerDay     int                           `json:"locationsPerDay" binding:"required,min=1"`
 	LocationPreference  string                        `json:"locationPreference"`
+	StartLocation       *GeoPoint                     `json:"start_location,omitempty"`
 }
 
 type TripResponse struct {
@@ -57,8 +68,10 @@ type TripResponse struct {
 	Title                 string                        `json:"title" binding:"required,min=1"`
 	City                  string                        `json:"city" binding:"required"`
 	StartDate             time.Time                     `json:"startDate" binding:"required"`
+	TimeZone              string                        `json:"timeZone"`
 	Days                  int                           `json:"days" binding:"required,min=1"`
 	Budget                float64                       `json:"budget"`
+	BudgetCurrency        string                        `json:"budgetCurrency"`
 	ViLocationAttributes  stringlistutils.SqlListString `json:"viLocationAttributes"`
 	ViFoodAttributes      stringlistutils.SqlListString `json:"viFoodAttributes"`
 	ViSpecialRequirements stringlistutils.SqlListString `json:"viSpecialRequirements"`
@@ -70,18 +83,72 @@ type TripResponse struct {
 	Status                string                        `json:"status"`
 	Role                  string                        `json:"role"`
 	MemberCount           int                           `json:"memberCount"`
+	Segments              []TripSegmentResponse         `json:"segments,omitempty"`
+	CostEstimate          *TripCostEstimate             `json:"costEstimate,omitempty"`
+}
+
+type tripSort struct {
+	StartDate string
+	CreatedAt string
+	UpdatedAt string
+}
+
+var TripSort = tripSort{
+	StartDate: "startDate",
+	CreatedAt: "createdAt",
+	UpdatedAt: "updatedAt",
+}
+
+// TripListRequest filters, orders and pages the trip list; empty fields do not filter
+type TripListRequest struct {
+	Statuses []string
+	City     string
+	// From and To are inclusive days; a trip matches when any of its days falls in the range
+	From       *time.Time
+	To         *time.Time
+	Roles      []string
+	Search     string
+	Sort       string
+	Descending bool
+	Cursor     string
+	// Limit of 0 lists every trip on one page
+	Limit int
+}
+
+type TripPageResponse struct {
+	Trips []*TripResponse `json:"trips"`
+	// NextCursor is set when more trips follow; pass it back as cursor with the same filters, sort and order
+	NextCursor *string `json:"nextCursor"`
+}
+
+type TripTrashResponse struct {
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	City      string    `json:"city"`
+	StartDate time.Time `json:"startDate"`
+	Days      int       `json:"days"`
+	Status    string    `json:"status"`
+	Role      string    `json:"role"`
+	DeletedAt time.Time `json:"deletedAt"`
+	// PurgeAt is when the trip stops being restorable and is deleted for good
+	PurgeAt time.Time `json:"purgeAt"`
 }
 
 type CreateTripResponse struct {
 	ID int64 `json:"id"`
 }
 
+// TripPatchRequest updates the fields it sets. City is the city of the first segment, so a multi-city trip only
+// accepts a change of city or days through Segments
 type TripPatchRequest struct {
 	Title                 *string                        `json:"title,omitempty"`
 	City                  *string                        `json:"city,omitempty"`
 	StartDate             *time.Time                     `json:"startDate,omitempty"`
+	TimeZone              *string                        `json:"timeZone,omitempty"`
 	Days                  *int                           `json:"days,omitempty"`
+	Segments              *[]TripSegmentRequest          `json:"segments,omitempty"`
 	Budget                *float64                       `json:"budget,omitempty"`
+	BudgetCurrency        *string                        `json:"budgetCurrency,omitempty" binding:"omitempty,len=3"`
 	ViLocationAttributes  *stringlistutils.SqlListString `json:"viLocationAttributes,omitempty"`
 	ViFoodAttributes      *stringlistutils.SqlListString `json:"viFoodAttributes,omitempty"`
 	ViSpecialRequirements *stringlistutils.SqlListString `json:"viSpecialRequirements,omitempty"`
@@ -110,19 +177,23 @@ type TripAIResponseWrapper struct {
 }
 
 type tripStatus struct {
+	Draft        string
+	AIGenerating string
+	Failed       string
 	NotStarted   string
 	InProgress   string
 	Completed    string
-	Received     string
-	AIGenerating string
-	Failed       string
+	Cancelled    string
+	Archived     string
 }
 
 var TripStatus = tripStatus{
+	Draft:        "draft",
+	AIGenerating: "ai_generating",
+	Failed:       "failed",
 	NotStarted:   "not_started",
 	InProgress:   "in_progress",
 	Completed:    "completed",
-	Received:     "cancel",
-	AIGenerating: "ai_generating",
-	Failed:       "failed",
+	Cancelled:    "cancelled",
+	Archived:     "archived",
 }
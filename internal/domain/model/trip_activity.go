@@ -0,0 +1,78 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type tripActivityAction struct {
+	TripUpdated          string
+	TripStatusChanged    string
+	TripDeleted          string
+	TripRestored         string
+	ItemAdded            string
+	ItemRemoved          string
+	ItemMoved            string
+	ItemEdited           string
+	MemberRemoved        string
+	MemberRoleChanged    string
+	MemberLeft           string
+	OwnershipTransferred string
+	InvitationAccepted   string
+	InvitationDenied     string
+	ImageUploaded        string
+	ImageDeleted         string
+}
+
+var TripActivityAction = tripActivityAction{
+	TripUpdated:          "tripUpdated",
+	TripStatusChanged:    "tripStatusChanged",
+	TripDeleted:          "tripDeleted",
+	TripRestored:         "tripRestored",
+	ItemAdded:            "itemAdded",
+	ItemRemoved:          "itemRemoved",
+	ItemMoved:            "itemMoved",
+	ItemEdited:           "itemEdited",
+	MemberRemoved:        "memberRemoved",
+	MemberRoleChanged:    "memberRoleChanged",
+	MemberLeft:           "memberLeft",
+	OwnershipTransferred: "ownershipTransferred",
+	InvitationAccepted:   "invitationAccepted",
+	InvitationDenied:     "invitationDenied",
+	ImageUploaded:        "imageUploaded",
+	ImageDeleted:         "imageDeleted",
+}
+
+type tripActivityEntityType struct {
+	Trip       string
+	TripItem   string
+	Member     string
+	Invitation string
+	Image      string
+}
+
+var TripActivityEntityType = tripActivityEntityType{
+	Trip:       "trip",
+	TripItem:   "tripItem",
+	Member:     "member",
+	Invitation: "invitation",
+	Image:      "image",
+}
+
+// TripActivityResponse is one audit entry. Actor is nil for changes made by the system, e.g. a closing poll
+type TripActivityResponse struct {
+	ID         int64           `json:"id"`
+	Actor      *UserInfo       `json:"actor"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entityType"`
+	EntityID   *int64          `json:"entityID"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// TripActivityPageResponse lists activities newest first; pass NextCursor as cursor to get the next page
+type TripActivityPageResponse struct {
+	Activities []TripActivityResponse `json:"activities"`
+	NextCursor *int64                 `json:"nextCursor"`
+}
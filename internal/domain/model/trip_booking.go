@@ -0,0 +1,102 @@
+package model
+
+import "time"
+
+type bookingType struct {
+	Hotel     string
+	Flight    string
+	Train     string
+	CarRental string
+}
+
+var BookingType = bookingType{
+	Hotel:     "hotel",
+	Flight:    "flight",
+	Train:     "train",
+	CarRental: "car_rental",
+}
+
+type GeoPoint struct {
+	Lat  float64 `json:"lat" binding:"latitude"`
+	Long float64 `json:"long" binding:"longitude"`
+}
+
+// HotelChoiceRequest is the hotel a traveller has picked, used as the start of each planned day
+type HotelChoiceRequest struct {
+	Name     string   `json:"name" binding:"required,max=255"`
+	Address  string   `json:"address" binding:"max=512"`
+	Location GeoPoint `json:"location" binding:"required"`
+}
+
+// TripBookingRequest covers hotels (check-in/out), flights and trains (depart/arrive) and car rentals (pick-up/drop-off)
+type TripBookingRequest struct {
+	Type               string     `json:"type" binding:"required,oneof=hotel flight train car_rental"`
+	Title              string     `json:"title" binding:"required,max=255"`
+	Provider           *string    `json:"provider" binding:"omitempty,max=255"`
+	ConfirmationNumber *string    `json:"confirmationNumber" binding:"omitempty,max=64"`
+	Address            *string    `json:"address" binding:"omitempty,max=512"`
+	Location           *GeoPoint  `json:"location"`
+	FromLocation       *string    `json:"fromLocation" binding:"omitempty,max=255"`
+	ToLocation         *string    `json:"toLocation" binding:"omitempty,max=255"`
+	StartAt            time.Time  `json:"startAt" binding:"required"`
+	EndAt              *time.Time `json:"endAt"`
+	Note               *string    `json:"note"`
+	Attachments        []string   `json:"attachments" binding:"omitempty,max=10,dive,url"`
+}
+
+type TripBookingResponse struct {
+	ID                 int64      `json:"id"`
+	TripID             int64      `json:"tripId"`
+	CreatedBy          int64      `json:"createdBy"`
+	Type               string     `json:"type"`
+	Title              string     `json:"title"`
+	Provider           *string    `json:"provider"`
+	ConfirmationNumber *string    `json:"confirmationNumber"`
+	Address            *string    `json:"address"`
+	Location           *GeoPoint  `json:"location"`
+	FromLocation       *string    `json:"fromLocation"`
+	ToLocation         *string    `json:"toLocation"`
+	StartAt            time.Time  `json:"startAt"`
+	EndAt              *time.Time `json:"endAt"`
+	Note               *string    `json:"note"`
+	Attachments        []string   `json:"attachments"`
+}
+
+type timelineEvent struct {
+	Visit     string
+	CheckIn   string
+	CheckOut  string
+	Departure string
+	Arrival   string
+	PickUp    string
+	DropOff   string
+}
+
+var TimelineEvent = timelineEvent{
+	Visit:     "visit",
+	CheckIn:   "check_in",
+	CheckOut:  "check_out",
+	Departure: "departure",
+	Arrival:   "arrival",
+	PickUp:    "pick_up",
+	DropOff:   "drop_off",
+}
+
+// TimelineEntry is either a trip item or one end of a booking, Item or Booking is set accordingly
+type TimelineEntry struct {
+	Event   string               `json:"event"`
+	At      time.Time            `json:"at"`
+	Item    *TripItemResponse    `json:"item,omitempty"`
+	Booking *TripBookingResponse `json:"booking,omitempty"`
+}
+
+type TimelineDay struct {
+	// TripDay may fall outside 1..days for bookings before or after the trip, such as the outbound flight
+	TripDay int64           `json:"tripDay"`
+	Date    time.Time       `json:"date"`
+	Entries []TimelineEntry `json:"entries"`
+}
+
+type TripTimelineResponse struct {
+	Days []TimelineDay `json:"days"`
+}
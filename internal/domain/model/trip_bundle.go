@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// TripBundleManifest describes an offline trip bundle. Files lists every file of the trip, including those left out
+// of the archive because the client already had them, so the client can compare hashes to know what changed
+type TripBundleManifest struct {
+	TripID      int64            `json:"tripId"`
+	Language    string           `json:"language"`
+	GeneratedAt time.Time        `json:"generatedAt"`
+	Files       []TripBundleFile `json:"files"`
+	// UnresolvedItems are the items whose place info could not be fetched
+	UnresolvedItems []int64 `json:"unresolvedItems"`
+	// UnavailableFiles are the documents and photos that could not be downloaded or are too large to bundle
+	UnavailableFiles []string `json:"unavailableFiles"`
+}
+
+type TripBundleFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size"`
+}
+
+// TripBundleDocument is a document of the bundle; Path is empty when the file is unavailable
+type TripBundleDocument struct {
+	TripDocumentResponse
+	Path string `json:"path"`
+}
+
+// TripBundlePhoto is a downscaled trip image of the bundle; Path is empty when the file is unavailable
+type TripBundlePhoto struct {
+	TripImageResponse
+	Path string `json:"path"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+type checklistType struct {
+	Packing string
+	Todo    string
+}
+
+var ChecklistType = checklistType{
+	Packing: "packing",
+	Todo:    "todo",
+}
+
+// TripChecklistRequest creates a checklist, SeedDefaults fills a packing list from the trip's length and needs
+type TripChecklistRequest struct {
+	Type         string `json:"type" binding:"required,oneof=packing todo"`
+	Title        string `json:"title" binding:"required,max=255"`
+	SeedDefaults bool   `json:"seedDefaults"`
+}
+
+type TripChecklistItemRequest struct {
+	Title      string  `json:"title" binding:"required,max=255"`
+	AssigneeID *int64  `json:"assigneeID"`
+	DueDate    *string `json:"dueDate" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type TripChecklistItemCheckRequest struct {
+	IsDone *bool `json:"isDone" binding:"required"`
+}
+
+type TripChecklistItemResponse struct {
+	ID           int64      `json:"id"`
+	ChecklistID  int64      `json:"checklistID"`
+	Title        string     `json:"title"`
+	AssigneeID   *int64     `json:"assigneeID"`
+	AssigneeName *string    `json:"assigneeName"`
+	DueDate      *string    `json:"dueDate"`
+	IsDone       bool       `json:"isDone"`
+	DoneBy       *int64     `json:"doneBy"`
+	DoneByName   *string    `json:"doneByName"`
+	DoneAt       *time.Time `json:"doneAt"`
+	CreatedBy    int64      `json:"createdBy"`
+}
+
+type TripChecklistResponse struct {
+	ID        int64                       `json:"id"`
+	TripID    int64                       `json:"tripID"`
+	Type      string                      `json:"type"`
+	Title     string                      `json:"title"`
+	CreatedBy int64                       `json:"createdBy"`
+	Items     []TripChecklistItemResponse `json:"items"`
+}
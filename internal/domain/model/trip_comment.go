@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// TripCommentRequest posts on the trip, or on one of its items when TripItemID is set; ParentID makes it a reply
+type TripCommentRequest struct {
+	Content    string `json:"content" binding:"required,max=2000"`
+	TripItemID *int64 `json:"tripItemID"`
+	ParentID   *int64 `json:"parentID"`
+}
+
+type TripCommentUpdateRequest struct {
+	Content string `json:"content" binding:"required,max=2000"`
+}
+
+type TripCommentResponse struct {
+	ID         int64                 `json:"id"`
+	TripID     int64                 `json:"tripID"`
+	TripItemID *int64                `json:"tripItemID"`
+	ParentID   *int64                `json:"parentID"`
+	Author     UserInfo              `json:"author"`
+	Content    string                `json:"content"`
+	Mentions   []UserInfo            `json:"mentions"`
+	IsDeleted  bool                  `json:"isDeleted"`
+	EditedAt   *time.Time            `json:"editedAt"`
+	CreatedAt  time.Time             `json:"createdAt"`
+	Replies    []TripCommentResponse `json:"replies"`
+}
@@ -0,0 +1,25 @@
+package model
+
+type CategoryCostEstimate struct {
+	Category  string  `json:"category"`
+	Estimated float64 `json:"estimated"`
+}
+
+type DayCostEstimate struct {
+	TripDay   int64   `json:"tripDay"`
+	Estimated float64 `json:"estimated"`
+	// Allowance is the budget spread evenly over the trip days
+	Allowance  float64 `json:"allowance"`
+	OverBudget bool    `json:"overBudget"`
+}
+
+// TripCostEstimate rolls up the estimated cost of the trip items, in the trip budget currency
+type TripCostEstimate struct {
+	Currency   string                 `json:"currency"`
+	Estimated  float64                `json:"estimated"`
+	OverBudget bool                   `json:"overBudget"`
+	ByCategory []CategoryCostEstimate `json:"byCategory"`
+	Days       []DayCostEstimate      `json:"days"`
+	// UnestimatedItems counts items without a cost, so a low total isn't mistaken for a cheap trip
+	UnestimatedItems int `json:"unestimatedItems"`
+}
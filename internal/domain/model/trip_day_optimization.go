@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+type OptimizeTripDayRequest struct {
+	// StartItemID and EndItemID keep the given items at the beginning and the end of the day
+	StartItemID *int64 `json:"startItemId"`
+	EndItemID   *int64 `json:"endItemId"`
+	// PinnedItemIDs are items that must keep their current time_in_date
+	PinnedItemIDs []int64 `json:"pinnedItemIds"`
+	TravelMode    string  `json:"travelMode"`
+}
+
+type OptimizedTripItem struct {
+	TripItemID    int64     `json:"tripItemId"`
+	PlaceID       string    `json:"placeId"`
+	Name          string    `json:"name"`
+	OldOrderInDay int64     `json:"oldOrderInDay"`
+	NewOrderInDay int64     `json:"newOrderInDay"`
+	OldTimeInDate string    `json:"oldTimeInDate"`
+	NewTimeInDate string    `json:"newTimeInDate"`
+	Time          time.Time `json:"time"`
+}
+
+type OptimizeTripDayResponse struct {
+	TripDay                 int64               `json:"tripDay"`
+	TravelMode              string              `json:"travelMode"`
+	Items                   []OptimizedTripItem `json:"items"`
+	CurrentDistanceMeters   float64             `json:"currentDistanceMeters"`
+	OptimizedDistanceMeters float64             `json:"optimizedDistanceMeters"`
+	DistanceSavedMeters     float64             `json:"distanceSavedMeters"`
+	UnresolvedItemIDs       []int64             `json:"unresolvedItemIds"`
+}
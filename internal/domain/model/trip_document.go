@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+type documentVisibility struct {
+	Trip    string
+	Private string
+}
+
+var DocumentVisibility = documentVisibility{
+	Trip:    "trip",
+	Private: "private",
+}
+
+type TripDocumentRequest struct {
+	Title         string `json:"title" binding:"required,max=255"`
+	FileName      string `json:"fileName" binding:"required,max=255"`
+	ContentType   string `json:"contentType" binding:"required,oneof=application/pdf image/jpeg image/png image/heic image/webp"`
+	Visibility    string `json:"visibility" binding:"required,oneof=trip private"`
+	TripItemID    *int64 `json:"tripItemID"`
+	TripBookingID *int64 `json:"tripBookingID"`
+}
+
+type TripDocumentResponse struct {
+	ID            int64     `json:"id"`
+	TripID        int64     `json:"tripId"`
+	UploadedBy    int64     `json:"uploadedBy"`
+	Title         string    `json:"title"`
+	FileName      string    `json:"fileName"`
+	ContentType   string    `json:"contentType"`
+	Visibility    string    `json:"visibility"`
+	TripItemID    *int64    `json:"tripItemID"`
+	TripBookingID *int64    `json:"tripBookingID"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// TripDocumentUploadResponse carries the presigned URL the client PUTs the file to, with the same content type
+type TripDocumentUploadResponse struct {
+	Document  TripDocumentResponse `json:"document"`
+	UploadURL string               `json:"uploadUrl"`
+}
+
+type TripDocumentDownloadResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type tripEventType struct {
+	ItemsChanged      string
+	TripUpdated       string
+	MemberJoined      string
+	MemberLeft        string
+	MemberRoleChanged string
+	PresenceSnapshot  string
+	PresenceUpdated   string
+	PresenceLeft      string
+}
+
+var TripEventType = tripEventType{
+	ItemsChanged:      "itemsChanged",
+	TripUpdated:       "tripUpdated",
+	MemberJoined:      "memberJoined",
+	MemberLeft:        "memberLeft",
+	MemberRoleChanged: "memberRoleChanged",
+	PresenceSnapshot:  "presenceSnapshot",
+	PresenceUpdated:   "presenceUpdated",
+	PresenceLeft:      "presenceLeft",
+}
+
+// TripEvent is what live trip sockets receive; Data depends on Type
+type TripEvent struct {
+	Type      string          `json:"type"`
+	TripID    int64           `json:"tripID"`
+	ActorID   *int64          `json:"actorID"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type TripItemChange struct {
+	PlaceID    string  `json:"placeID"`
+	TripDay    int64   `json:"tripDay"`
+	OrderInDay int64   `json:"orderInDay"`
+	TimeInDate string  `json:"timeInDate"`
+	Note       *string `json:"note"`
+	StartTime  *string `json:"startTime"`
+	EndTime    *string `json:"endTime"`
+	// FromTripDay and FromOrderInDay are the previous position of a moved item
+	FromTripDay    *int64 `json:"fromTripDay,omitempty"`
+	FromOrderInDay *int64 `json:"fromOrderInDay,omitempty"`
+}
+
+type TripItemsChangedData struct {
+	Added   []TripItemChange `json:"added"`
+	Removed []TripItemChange `json:"removed"`
+	Moved   []TripItemChange `json:"moved"`
+	Edited  []TripItemChange `json:"edited"`
+}
+
+func (d TripItemsChangedData) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Moved) == 0 && len(d.Edited) == 0
+}
+
+type TripMemberRoleChangedData struct {
+	User UserInfo `json:"user"`
+	Role string   `json:"role"`
+}
+
+// TripPresenceRequest is the message a client sends over the socket; TripDay must be at least 1 and View at most 50 characters
+type TripPresenceRequest struct {
+	TripDay *int64 `json:"tripDay"`
+	// View is the screen the member has open, e.g. itinerary, expenses or photos
+	View string `json:"view"`
+}
+
+type TripPresence struct {
+	SessionID string    `json:"sessionID"`
+	User      UserInfo  `json:"user"`
+	TripDay   *int64    `json:"tripDay"`
+	View      string    `json:"view"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TripRealtimeSession is one open trip socket; Events is closed when the session ends on the server side
+type TripRealtimeSession struct {
+	ID       string
+	TripID   int64
+	User     UserInfo
+	Events   <-chan TripEvent
+	Presence []TripPresence
+}
@@ -0,0 +1,177 @@
+package model
+
+import "time"
+
+type expenseSplitType struct {
+	Equal  string
+	Shares string
+	Exact  string
+}
+
+var ExpenseSplitType = expenseSplitType{
+	Equal:  "equal",
+	Shares: "shares",
+	Exact:  "exact",
+}
+
+type expenseCategory struct {
+	Food      string
+	Tickets   string
+	Transport string
+	Lodging   string
+	Shopping  string
+	Other     string
+}
+
+var ExpenseCategory = expenseCategory{
+	Food:      "food",
+	Tickets:   "tickets",
+	Transport: "transport",
+	Lodging:   "lodging",
+	Shopping:  "shopping",
+	Other:     "other",
+}
+
+// ExpenseCategories lists the categories in the order summaries report them
+var ExpenseCategories = []string{
+	ExpenseCategory.Food,
+	ExpenseCategory.Tickets,
+	ExpenseCategory.Transport,
+	ExpenseCategory.Lodging,
+	ExpenseCategory.Shopping,
+	ExpenseCategory.Other,
+}
+
+type ExpenseSplitRequest struct {
+	UserID int64 `json:"userId" binding:"required"`
+	// Share is the weight for "shares" splits, Amount the owed amount for "exact" splits
+	Share  *float64 `json:"share" binding:"omitempty,gt=0"`
+	Amount *float64 `json:"amount" binding:"omitempty,gte=0"`
+}
+
+type TripExpenseRequest struct {
+	Description string                `json:"description" binding:"required,max=255"`
+	Amount      float64               `json:"amount" binding:"required,gt=0"`
+	Currency    string                `json:"currency" binding:"required,len=3"`
+	PayerID     *int64                `json:"payerId"`
+	TripItemID  *int64                `json:"tripItemId"`
+	SplitType   string                `json:"splitType" binding:"required,oneof=equal shares exact"`
+	Splits      []ExpenseSplitRequest `json:"splits" binding:"required,min=1,dive"`
+	Category    string                `json:"category" binding:"omitempty,oneof=food tickets transport lodging shopping other"`
+	TripDay     *int64                `json:"tripDay" binding:"omitempty,min=1"`
+}
+
+type ExpenseSplitResponse struct {
+	UserID int64   `json:"userId"`
+	Name   string  `json:"name"`
+	Share  float64 `json:"share"`
+	Amount float64 `json:"amount"`
+}
+
+type TripExpenseResponse struct {
+	ID           int64                  `json:"id"`
+	TripID       int64                  `json:"tripId"`
+	TripItemID   *int64                 `json:"tripItemId"`
+	PayerID      int64                  `json:"payerId"`
+	PayerName    string                 `json:"payerName"`
+	CreatedBy    int64                  `json:"createdBy"`
+	Description  string                 `json:"description"`
+	Amount       float64                `json:"amount"`
+	Currency     string                 `json:"currency"`
+	SplitType    string                 `json:"splitType"`
+	Category     string                 `json:"category"`
+	TripDay      *int64                 `json:"tripDay"`
+	BaseCurrency *string                `json:"baseCurrency"`
+	ExchangeRate *float64               `json:"exchangeRate"`
+	BaseAmount   *float64               `json:"baseAmount"`
+	Splits       []ExpenseSplitResponse `json:"splits"`
+	CreatedAt    time.Time              `json:"createdAt"`
+}
+
+type TripSettlementRequest struct {
+	FromUserID int64   `json:"fromUserId" binding:"required"`
+	ToUserID   int64   `json:"toUserId" binding:"required,nefield=FromUserID"`
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+	Currency   string  `json:"currency" binding:"required,len=3"`
+}
+
+type TripSettlementResponse struct {
+	ID         int64     `json:"id"`
+	FromUserID int64     `json:"fromUserId"`
+	FromName   string    `json:"fromName"`
+	ToUserID   int64     `json:"toUserId"`
+	ToName     string    `json:"toName"`
+	CreatedBy  int64     `json:"createdBy"`
+	Amount     float64   `json:"amount"`
+	Currency   string    `json:"currency"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type MemberBalance struct {
+	UserID int64   `json:"userId"`
+	Name   string  `json:"name"`
+	Paid   float64 `json:"paid"`
+	Owed   float64 `json:"owed"`
+	// Settled is the net of recorded payments, positive when the member paid others back
+	Settled float64 `json:"settled"`
+	// Net is positive when the member should receive money
+	Net float64 `json:"net"`
+}
+
+type SettlementTransfer struct {
+	FromUserID int64   `json:"fromUserId"`
+	FromName   string  `json:"fromName"`
+	ToUserID   int64   `json:"toUserId"`
+	ToName     string  `json:"toName"`
+	Amount     float64 `json:"amount"`
+}
+
+type CurrencyBalance struct {
+	Currency  string               `json:"currency"`
+	Members   []MemberBalance      `json:"members"`
+	Transfers []SettlementTransfer `json:"transfers"`
+}
+
+type TripBalancesResponse struct {
+	Balances    []CurrencyBalance        `json:"balances"`
+	Settlements []TripSettlementResponse `json:"settlements"`
+}
+
+type BudgetCategorySummary struct {
+	Category string  `json:"category"`
+	Spent    float64 `json:"spent"`
+	// Percent is the share of the whole budget spent in this category
+	Percent float64 `json:"percent"`
+}
+
+type BudgetDaySummary struct {
+	// TripDay is 0 for expenses outside the trip days
+	TripDay   int64      `json:"tripDay"`
+	Date      *time.Time `json:"date"`
+	Spent     float64    `json:"spent"`
+	Allowance float64    `json:"allowance"`
+	Remaining float64    `json:"remaining"`
+}
+
+type TripBudgetSummaryResponse struct {
+	Currency  string  `json:"currency"`
+	Budget    float64 `json:"budget"`
+	Spent     float64 `json:"spent"`
+	Remaining float64 `json:"remaining"`
+	// DailyAllowance is the budget spread evenly over the trip days
+	DailyAllowance float64                 `json:"dailyAllowance"`
+	ByCategory     []BudgetCategorySummary `json:"byCategory"`
+	ByDay          []BudgetDaySummary      `json:"byDay"`
+	// MissingRates lists currencies left out of the totals because no exchange rate is loaded
+	MissingRates []string `json:"missingRates"`
+}
+
+// TripExpenseReport gathers what the expense export needs in one place
+type TripExpenseReport struct {
+	Title          string                   `json:"title"`
+	TimeZone       string                   `json:"timeZone"`
+	BudgetCurrency string                   `json:"budgetCurrency"`
+	Expenses       []TripExpenseResponse    `json:"expenses"`
+	Balances       []CurrencyBalance        `json:"balances"`
+	Settlements    []TripSettlementResponse `json:"settlements"`
+}
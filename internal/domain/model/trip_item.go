@@ -1,10 +1,21 @@
 package model
 
 type TripItemRequest struct {
-	PlaceID    string `json:"placeID" binding:"required"`
-	TripDay    int64  `json:"tripDay" binding:"required,min=1"`
-	OrderInDay int64  `json:"orderInDay" binding:"required,min=1"`
-	TimeInDate string `json:"timeInDate" binding:"required"`
+	// ID keeps an existing item, with its comments, photos and links; without it a new item is created,
+	// unless the trip already has an item of the same place
+	ID         *int64  `json:"id" binding:"omitempty,min=1"`
+	PlaceID    string  `json:"placeID" binding:"required"`
+	TripDay    int64   `json:"tripDay" binding:"required,min=1"`
+	OrderInDay int64   `json:"orderInDay" binding:"required,min=1"`
+	TimeInDate string  `json:"timeInDate" binding:"required,oneof=morning afternoon evening night"`
+	Note       *string `json:"note"`
+	// StartTime and EndTime are "HH:MM" clock times, DurationMinutes can be sent instead of EndTime
+	StartTime       *string `json:"startTime" binding:"omitempty,datetime=15:04"`
+	EndTime         *string `json:"endTime" binding:"omitempty,datetime=15:04"`
+	DurationMinutes *int64  `json:"durationMinutes" binding:"omitempty,min=1,max=1440"`
+	// EstimatedCost is in the trip budget currency and is converted with it, both are pre-filled from the place when left out
+	EstimatedCost *float64 `json:"estimatedCost" binding:"omitempty,gte=0"`
+	CostCategory  *string  `json:"costCategory" binding:"omitempty,oneof=food tickets transport lodging shopping other"`
 }
 
 type PlaceInfo struct {
@@ -18,16 +29,49 @@ type PlaceInfo struct {
 	Name       string   `json:"name"`
 	Properties []string `json:"properties"`
 	Type       string   `json:"type"`
+	// Price is the typical spend per visit when core knows it
+	Price         *float64 `json:"price,omitempty"`
+	PriceCurrency string   `json:"price_currency,omitempty"`
 }
 
 type TripItemResponse struct {
-	ID         int64      `json:"id"`
-	TripID     int64      `json:"tripID"`
-	PlaceID    string     `json:"placeID"`
-	TripDay    int64      `json:"tripDay"`
-	OrderInDay int64      `json:"orderInDay"`
-	TimeInDate string     `json:"timeInDate"`
-	PlaceInfo  *PlaceInfo `json:"placeInfo"`
+	ID            int64      `json:"id"`
+	TripID        int64      `json:"tripID"`
+	PlaceID       string     `json:"placeID"`
+	TripDay       int64      `json:"tripDay"`
+	OrderInDay    int64      `json:"orderInDay"`
+	TimeInDate    string     `json:"timeInDate"`
+	Note          *string    `json:"note"`
+	StartTime     *string    `json:"startTime"`
+	EndTime       *string    `json:"endTime"`
+	EstimatedCost *float64   `json:"estimatedCost"`
+	CostCategory  *string    `json:"costCategory"`
+	PlaceInfo     *PlaceInfo `json:"placeInfo"`
+	// TravelFromPrevious is the estimated leg from the previous stop of the same day, only set when a
+	// travel mode is requested and nil for the first stop
+	TravelFromPrevious *TravelLeg `json:"travelFromPrevious"`
+}
+
+type timeInDate struct {
+	Morning   string
+	Afternoon string
+	Evening   string
+	Night     string
+}
+
+var TimeInDate = timeInDate{
+	Morning:   "morning",
+	Afternoon: "afternoon",
+	Evening:   "evening",
+	Night:     "night",
+}
+
+// TimeInDateStartHour maps a time_in_date slot to the hour of day it starts at
+var TimeInDateStartHour = map[string]int{
+	TimeInDate.Morning:   8,
+	TimeInDate.Afternoon: 13,
+	TimeInDate.Evening:   18,
+	TimeInDate.Night:     21,
 }
 
 type TripItemFromAIResponse struct {
@@ -0,0 +1,7 @@
+package model
+
+type TripItinerary struct {
+	Trip    TripResponse         `json:"trip"`
+	Members []TripMemberResponse `json:"members"`
+	Items   []TripItemResponse   `json:"items"`
+}
@@ -1,13 +1,19 @@
 package model
 
 type tripMemberRole struct {
+	Owner         string
 	Administrator string
+	Editor        string
 	Member        string
+	Viewer        string
 }
 
 var TripMemberRole = tripMemberRole{
+	Owner:         "owner",
 	Administrator: "administrator",
+	Editor:        "editor",
 	Member:        "member",
+	Viewer:        "viewer",
 }
 
 type TripMemberRequest struct {
@@ -24,3 +30,11 @@ type TripMemberResponse struct {
 	Name     string  `json:"name"`
 	PhotoURL *string `json:"photo_url"`
 }
+
+type TripMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=administrator editor member viewer"`
+}
+
+type TripOwnershipTransferRequest struct {
+	UserID int64 `json:"userId" binding:"required"`
+}
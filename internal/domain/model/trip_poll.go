@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+type pollOptionType struct {
+	Text  string
+	Place string
+}
+
+var PollOptionType = pollOptionType{
+	Text:  "text",
+	Place: "place",
+}
+
+type pollStatus struct {
+	Open   string
+	Closed string
+}
+
+var PollStatus = pollStatus{
+	Open:   "open",
+	Closed: "closed",
+}
+
+// TripPollOptionRequest is free text, or a candidate place when the poll's option type is place
+type TripPollOptionRequest struct {
+	Label   string  `json:"label" binding:"required,max=255"`
+	PlaceID *string `json:"placeID" binding:"omitempty,max=255"`
+}
+
+// TripPollRequest opens a poll. ApplyToTripItemID, for place polls only, swaps the winning place into that item on close,
+// unless the place is already used by an itinerary item
+type TripPollRequest struct {
+	Question          string                  `json:"question" binding:"required,max=500"`
+	OptionType        string                  `json:"optionType" binding:"required,oneof=text place"`
+	Options           []TripPollOptionRequest `json:"options" binding:"required,min=2,max=20,dive"`
+	AllowMultiple     bool                    `json:"allowMultiple"`
+	IsAnonymous       bool                    `json:"isAnonymous"`
+	Deadline          *time.Time              `json:"deadline"`
+	ApplyToTripItemID *int64                  `json:"applyToTripItemID"`
+}
+
+type TripPollVoteRequest struct {
+	OptionIDs []int64 `json:"optionIDs" binding:"required,min=1,dive,min=1"`
+}
+
+type TripPollOptionResponse struct {
+	ID        int64      `json:"id"`
+	Label     string     `json:"label"`
+	PlaceID   *string    `json:"placeID"`
+	VoteCount int        `json:"voteCount"`
+	Voters    []UserInfo `json:"voters"`
+}
+
+type TripPollResponse struct {
+	ID                int64                    `json:"id"`
+	TripID            int64                    `json:"tripID"`
+	CreatedBy         int64                    `json:"createdBy"`
+	Question          string                   `json:"question"`
+	OptionType        string                   `json:"optionType"`
+	AllowMultiple     bool                     `json:"allowMultiple"`
+	IsAnonymous       bool                     `json:"isAnonymous"`
+	Deadline          *time.Time               `json:"deadline"`
+	Status            string                   `json:"status"`
+	ClosedAt          *time.Time               `json:"closedAt"`
+	ApplyToTripItemID *int64                   `json:"applyToTripItemID"`
+	WinningOptionID   *int64                   `json:"winningOptionID"`
+	TotalVoters       int                      `json:"totalVoters"`
+	MyOptionIDs       []int64                  `json:"myOptionIDs"`
+	Options           []TripPollOptionResponse `json:"options"`
+}
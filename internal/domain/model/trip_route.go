@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+type TripRouteWaypoint struct {
+	TripItemID int64     `json:"tripItemId"`
+	PlaceID    string    `json:"placeId"`
+	Name       string    `json:"name"`
+	Address    string    `json:"address"`
+	OrderInDay int64     `json:"orderInDay"`
+	TimeInDate string    `json:"timeInDate"`
+	Time       time.Time `json:"time"`
+	Lat        float64   `json:"lat"`
+	Long       float64   `json:"long"`
+}
+
+type TripRouteDay struct {
+	TripDay   int64               `json:"tripDay"`
+	Date      time.Time           `json:"date"`
+	Waypoints []TripRouteWaypoint `json:"waypoints"`
+}
+
+type TripRouteUnresolvedItem struct {
+	TripItemID int64  `json:"tripItemId"`
+	PlaceID    string `json:"placeId"`
+	TripDay    int64  `json:"tripDay"`
+	OrderInDay int64  `json:"orderInDay"`
+}
+
+type TripRoute struct {
+	TripID          int64                     `json:"tripId"`
+	Title           string                    `json:"title"`
+	City            string                    `json:"city"`
+	Days            []TripRouteDay            `json:"days"`
+	UnresolvedItems []TripRouteUnresolvedItem `json:"unresolvedItems"`
+}
+
+type tripRouteFormat struct {
+	GPX     string
+	KML     string
+	GeoJSON string
+}
+
+var TripRouteFormat = tripRouteFormat{
+	GPX:     "gpx",
+	KML:     "kml",
+	GeoJSON: "geojson",
+}
@@ -0,0 +1,25 @@
+package model
+
+import stringlistutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/string_list_utils"
+
+type TripSegmentRequest struct {
+	City                 string                        `json:"city" binding:"required"`
+	Days                 int                           `json:"days" binding:"required,min=1"`
+	ViLocationAttributes stringlistutils.SqlListString `json:"viLocationAttributes"`
+	ViFoodAttributes     stringlistutils.SqlListString `json:"viFoodAttributes"`
+	EnLocationAttributes stringlistutils.SqlListString `json:"enLocationAttributes"`
+	EnFoodAttributes     stringlistutils.SqlListString `json:"enFoodAttributes"`
+	Hotel                *HotelChoiceRequest           `json:"hotel"`
+}
+
+type TripSegmentResponse struct {
+	Order                int                           `json:"order"`
+	City                 string                        `json:"city"`
+	StartDay             int                           `json:"startDay"`
+	EndDay               int                           `json:"endDay"`
+	Days                 int                           `json:"days"`
+	ViLocationAttributes stringlistutils.SqlListString `json:"viLocationAttributes"`
+	ViFoodAttributes     stringlistutils.SqlListString `json:"viFoodAttributes"`
+	EnLocationAttributes stringlistutils.SqlListString `json:"enLocationAttributes"`
+	EnFoodAttributes     stringlistutils.SqlListString `json:"enFoodAttributes"`
+}
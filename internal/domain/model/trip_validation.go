@@ -0,0 +1,46 @@
+package model
+
+type tripValidationIssueType struct {
+	InvalidTime       string
+	BeyondTripLength  string
+	DuplicateOrder    string
+	OutOfOrder        string
+	Overlap           string
+	UnrealisticTravel string
+	DuplicatePlace    string
+}
+
+var TripValidationIssueType = tripValidationIssueType{
+	InvalidTime:       "invalid_time",
+	BeyondTripLength:  "beyond_trip_length",
+	DuplicateOrder:    "duplicate_order",
+	OutOfOrder:        "out_of_order",
+	Overlap:           "overlap",
+	UnrealisticTravel: "unrealistic_travel",
+	DuplicatePlace:    "duplicate_place",
+}
+
+type tripValidationSeverity struct {
+	Error   string
+	Warning string
+}
+
+// Errors are rejected when trip items are saved, warnings are only reported
+var TripValidationSeverity = tripValidationSeverity{
+	Error:   "error",
+	Warning: "warning",
+}
+
+type TripValidationIssue struct {
+	Type        string  `json:"type"`
+	Severity    string  `json:"severity"`
+	TripDay     int64   `json:"tripDay"`
+	TripItemIDs []int64 `json:"tripItemIds"`
+	OrderInDay  []int64 `json:"orderInDay"`
+	Message     string  `json:"message"`
+}
+
+type TripValidationResponse struct {
+	Valid  bool                  `json:"valid"`
+	Issues []TripValidationIssue `json:"issues"`
+}
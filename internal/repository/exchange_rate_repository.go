@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type ExchangeRateRepository interface {
+	UpsertCommand(ctx context.Context, rate *entity.ExchangeRate, tx *sqlx.Tx) error
+	GetAllQuery(ctx context.Context, tx *sqlx.Tx) ([]entity.ExchangeRate, error)
+}
@@ -0,0 +1,44 @@
+package repositoryimplement
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+)
+
+type ExchangeRateRepository struct {
+	db *sqlx.DB
+}
+
+func NewExchangeRateRepository(db database.Db) repository.ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+func (repo *ExchangeRateRepository) UpsertCommand(ctx context.Context, rate *entity.ExchangeRate, tx *sqlx.Tx) error {
+	query := `
+	INSERT INTO exchange_rates(currency, units_per_usd)
+	VALUES (:currency, :units_per_usd)
+	ON DUPLICATE KEY UPDATE units_per_usd = VALUES(units_per_usd), updated_at = CURRENT_TIMESTAMP
+	`
+	if tx != nil {
+		_, err := tx.NamedExecContext(ctx, query, rate)
+		return err
+	}
+
+	_, err := repo.db.NamedExecContext(ctx, query, rate)
+	return err
+}
+
+func (repo *ExchangeRateRepository) GetAllQuery(ctx context.Context, tx *sqlx.Tx) ([]entity.ExchangeRate, error) {
+	rates := make([]entity.ExchangeRate, 0)
+	query := "SELECT * FROM exchange_rates ORDER BY currency ASC"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &rates, query)
+		return rates, err
+	}
+	err := repo.db.SelectContext(ctx, &rates, query)
+	return rates, err
+}
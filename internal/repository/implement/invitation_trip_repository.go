@@ -3,6 +3,7 @@ package repositoryimplement
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
@@ -142,3 +143,14 @@ func (repo *InvitationTripRepository) GetOneByReceiverIdAndTripIDQuery(ctx conte
 	}
 	return &invitation, err
 }
+
+func (repo *InvitationTripRepository) GetChangedByUserIdQuery(ctx context.Context, userId int64, since time.Time, tx *sqlx.Tx) ([]entity.InvitationTrip, error) {
+	invitations := make([]entity.InvitationTrip, 0)
+	query := "SELECT * FROM invitation_trips WHERE (sender_id = ? OR receiver_id = ?) AND updated_at >= ?"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &invitations, query, userId, userId, since)
+		return invitations, err
+	}
+	err := repo.db.SelectContext(ctx, &invitations, query, userId, userId, since)
+	return invitations, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"strings"
+	"time"
 
 	myDatabase "github.com/swefinal-travel-planner/travel-app-be/internal/database"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
@@ -180,3 +181,35 @@ func (r *notificationRepository) DeleteTripNotificationCommand(ctx context.Conte
 
 	return err
 }
+
+func (r *notificationRepository) GetTripNotificationsQuery(ctx context.Context, receiverId, senderId, tripId int64, tx *sqlx.Tx) ([]*entity.Notification, error) {
+	query := `
+		SELECT * FROM notifications WHERE type = 'tripInvitationReceived' AND trigger_entity_id = ? AND user_id = ? AND reference_entity_id = ?
+	`
+
+	var notifications []*entity.Notification
+	var err error
+	if tx != nil {
+		err = tx.SelectContext(ctx, &notifications, query, senderId, receiverId, tripId)
+	} else {
+		err = r.db.SelectContext(ctx, &notifications, query, senderId, receiverId, tripId)
+	}
+
+	return notifications, err
+}
+
+func (r *notificationRepository) GetChangedByUserIdQuery(ctx context.Context, userID int64, since time.Time, tx *sqlx.Tx) ([]*entity.Notification, error) {
+	query := `
+		SELECT * FROM notifications WHERE user_id = ? AND updated_at >= ? ORDER BY id ASC
+	`
+
+	notifications := make([]*entity.Notification, 0)
+	var err error
+	if tx != nil {
+		err = tx.SelectContext(ctx, &notifications, query, userID, since)
+	} else {
+		err = r.db.SelectContext(ctx, &notifications, query, userID, since)
+	}
+
+	return notifications, err
+}
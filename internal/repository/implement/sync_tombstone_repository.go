@@ -0,0 +1,120 @@
+package repositoryimplement
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+)
+
+type SyncTombstoneRepository struct {
+	db *sqlx.DB
+}
+
+func NewSyncTombstoneRepository(db database.Db) repository.SyncTombstoneRepository {
+	return &SyncTombstoneRepository{db: db}
+}
+
+func (repo *SyncTombstoneRepository) CurrentTimeQuery(ctx context.Context, tx *sqlx.Tx) (time.Time, error) {
+	var now time.Time
+	query := "SELECT CURRENT_TIMESTAMP"
+	if tx != nil {
+		err := tx.GetContext(ctx, &now, query)
+		return now, err
+	}
+	err := repo.db.GetContext(ctx, &now, query)
+	return now, err
+}
+
+func (repo *SyncTombstoneRepository) CreateCommand(ctx context.Context, tombstone *entity.SyncTombstone, tx *sqlx.Tx) error {
+	insertQuery := `
+	INSERT INTO sync_tombstones(entity_type, entity_id, trip_id, user_id) 
+	VALUES (:entity_type, :entity_id, :trip_id, :user_id)
+	`
+	if tx != nil {
+		_, err := tx.NamedExecContext(ctx, insertQuery, tombstone)
+		return err
+	}
+
+	_, err := repo.db.NamedExecContext(ctx, insertQuery, tombstone)
+	return err
+}
+
+func (repo *SyncTombstoneRepository) CreateForTripMemberCommand(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) error {
+	query := `
+		INSERT INTO sync_tombstones(entity_type, entity_id, trip_id, user_id)
+		SELECT ?, id, trip_id, NULL FROM trip_members WHERE trip_id = ? AND user_id = ?
+		UNION ALL
+		SELECT ?, trip_id, NULL, user_id FROM trip_members WHERE trip_id = ? AND user_id = ?
+	`
+	args := []interface{}{
+		entity.SyncEntityType.TripMember, tripID, userID,
+		entity.SyncEntityType.Trip, tripID, userID,
+	}
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, args...)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (repo *SyncTombstoneRepository) CreateForTripMembersCommand(ctx context.Context, tripID int64, tx *sqlx.Tx) error {
+	query := `
+		INSERT INTO sync_tombstones(entity_type, entity_id, trip_id, user_id)
+		SELECT ?, trip_id, NULL, user_id FROM trip_members WHERE trip_id = ? AND deleted_at IS NULL
+	`
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, entity.SyncEntityType.Trip, tripID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, entity.SyncEntityType.Trip, tripID)
+	return err
+}
+
+// GetAllForUserSinceQuery returns the tombstones of the user and of the trips the user is currently in, oldest first
+func (repo *SyncTombstoneRepository) GetAllForUserSinceQuery(ctx context.Context, userID int64, since time.Time, tx *sqlx.Tx) ([]entity.SyncTombstone, error) {
+	tombstones := make([]entity.SyncTombstone, 0)
+	query := `
+		SELECT st.* FROM sync_tombstones st
+		WHERE st.deleted_at >= ? AND (
+			st.user_id = ?
+			OR st.trip_id IN (
+				SELECT tm.trip_id FROM trip_members tm
+				JOIN trips t ON t.id = tm.trip_id
+				WHERE tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NULL
+			)
+		)
+		ORDER BY st.id ASC
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &tombstones, query, since, userID, userID)
+		return tombstones, err
+	}
+	err := repo.db.SelectContext(ctx, &tombstones, query, since, userID, userID)
+	return tombstones, err
+}
+
+func (repo *SyncTombstoneRepository) GetLastDeletedAtQuery(ctx context.Context, entityType string, tripID int64, tx *sqlx.Tx) (*time.Time, error) {
+	var deletedAt *time.Time
+	query := "SELECT MAX(deleted_at) FROM sync_tombstones WHERE entity_type = ? AND trip_id = ?"
+	if tx != nil {
+		err := tx.GetContext(ctx, &deletedAt, query, entityType, tripID)
+		return deletedAt, err
+	}
+	err := repo.db.GetContext(ctx, &deletedAt, query, entityType, tripID)
+	return deletedAt, err
+}
+
+func (repo *SyncTombstoneRepository) DeleteBeforeCommand(ctx context.Context, before time.Time, tx *sqlx.Tx) error {
+	query := "DELETE FROM sync_tombstones WHERE deleted_at < ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, before)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, before)
+	return err
+}
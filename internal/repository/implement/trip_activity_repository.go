@@ -0,0 +1,56 @@
+package repositoryimplement
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+)
+
+type TripActivityRepository struct {
+	db *sqlx.DB
+}
+
+func NewTripActivityRepository(db database.Db) repository.TripActivityRepository {
+	return &TripActivityRepository{db: db}
+}
+
+func (repo *TripActivityRepository) CreateCommand(ctx context.Context, activity *entity.TripActivity, tx *sqlx.Tx) error {
+	insertQuery := `
+	INSERT INTO trip_activities(trip_id, actor_id, action, entity_type, entity_id, before_value, after_value) 
+	VALUES (:trip_id, :actor_id, :action, :entity_type, :entity_id, :before_value, :after_value)
+	`
+	if tx != nil {
+		_, err := tx.NamedExecContext(ctx, insertQuery, activity)
+		return err
+	}
+
+	_, err := repo.db.NamedExecContext(ctx, insertQuery, activity)
+	return err
+}
+
+func (repo *TripActivityRepository) GetPageByTripIDQuery(ctx context.Context, tripID int64, beforeID *int64, limit int, tx *sqlx.Tx) ([]entity.TripActivityWithActor, error) {
+	activities := make([]entity.TripActivityWithActor, 0)
+	query := `
+		SELECT a.*, u.name AS actor_name, u.photo_url AS actor_photo_url
+		FROM trip_activities a
+		LEFT JOIN users u ON u.id = a.actor_id
+		WHERE a.trip_id = ?
+	`
+	args := []interface{}{tripID}
+	if beforeID != nil {
+		query += " AND a.id < ?"
+		args = append(args, *beforeID)
+	}
+	query += " ORDER BY a.id DESC LIMIT ?"
+	args = append(args, limit)
+
+	if tx != nil {
+		err := tx.SelectContext(ctx, &activities, query, args...)
+		return activities, err
+	}
+	err := repo.db.SelectContext(ctx, &activities, query, args...)
+	return activities, err
+}
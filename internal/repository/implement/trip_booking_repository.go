@@ -0,0 +1,113 @@
+package repositoryimplement
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+type TripBookingRepository struct {
+	db *sqlx.DB
+}
+
+func NewTripBookingRepository(db database.Db) repository.TripBookingRepository {
+	return &TripBookingRepository{db: db}
+}
+
+func (repo *TripBookingRepository) CreateCommand(ctx context.Context, booking *entity.TripBooking, tx *sqlx.Tx) (int64, error) {
+	insertQuery := `
+	INSERT INTO trip_bookings(
+		trip_id, created_by, type, title, provider, confirmation_number, address, lat, ` + "`long`" + `,
+		from_location, to_location, start_at, end_at, note, attachments
+	) 
+	VALUES (
+		:trip_id, :created_by, :type, :title, :provider, :confirmation_number, :address, :lat, :long,
+		:from_location, :to_location, :start_at, :end_at, :note, :attachments
+	)
+	`
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.NamedExecContext(ctx, insertQuery, booking)
+	} else {
+		result, err = repo.db.NamedExecContext(ctx, insertQuery, booking)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (repo *TripBookingRepository) GetOneByIDQuery(ctx context.Context, tripID int64, bookingID int64, tx *sqlx.Tx) (*entity.TripBooking, error) {
+	var booking entity.TripBooking
+	query := "SELECT * FROM trip_bookings WHERE id = ? AND trip_id = ? AND deleted_at IS NULL"
+
+	var err error
+	if tx != nil {
+		err = tx.GetContext(ctx, &booking, query, bookingID, tripID)
+	} else {
+		err = repo.db.GetContext(ctx, &booking, query, bookingID, tripID)
+	}
+	if err != nil {
+		if err.Error() == error_utils.SystemErrorMessage.SqlxNoRow {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &booking, nil
+}
+
+func (repo *TripBookingRepository) GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripBooking, error) {
+	bookings := make([]entity.TripBooking, 0)
+	query := "SELECT * FROM trip_bookings WHERE trip_id = ? AND deleted_at IS NULL ORDER BY start_at ASC, id ASC"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &bookings, query, tripID)
+		return bookings, err
+	}
+	err := repo.db.SelectContext(ctx, &bookings, query, tripID)
+	return bookings, err
+}
+
+func (repo *TripBookingRepository) UpdateCommand(ctx context.Context, booking *entity.TripBooking, tx *sqlx.Tx) error {
+	updateQuery := `
+		UPDATE trip_bookings SET
+			type = :type,
+			title = :title,
+			provider = :provider,
+			confirmation_number = :confirmation_number,
+			address = :address,
+			lat = :lat,
+			` + "`long`" + ` = :long,
+			from_location = :from_location,
+			to_location = :to_location,
+			start_at = :start_at,
+			end_at = :end_at,
+			note = :note,
+			attachments = :attachments,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = :id
+	`
+	if tx != nil {
+		_, err := tx.NamedExecContext(ctx, updateQuery, booking)
+		return err
+	}
+
+	_, err := repo.db.NamedExecContext(ctx, updateQuery, booking)
+	return err
+}
+
+func (repo *TripBookingRepository) DeleteByIDCommand(ctx context.Context, bookingID int64, tx *sqlx.Tx) error {
+	query := "UPDATE trip_bookings SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, bookingID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, bookingID)
+	return err
+}
@@ -0,0 +1,164 @@
+package repositoryimplement
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+type TripChecklistRepository struct {
+	db *sqlx.DB
+}
+
+func NewTripChecklistRepository(db database.Db) repository.TripChecklistRepository {
+	return &TripChecklistRepository{db: db}
+}
+
+func (repo *TripChecklistRepository) CreateCommand(ctx context.Context, checklist *entity.TripChecklist, tx *sqlx.Tx) (int64, error) {
+	insertQuery := `
+	INSERT INTO trip_checklists(trip_id, created_by, type, title) 
+	VALUES (:trip_id, :created_by, :type, :title)
+	`
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.NamedExecContext(ctx, insertQuery, checklist)
+	} else {
+		result, err = repo.db.NamedExecContext(ctx, insertQuery, checklist)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (repo *TripChecklistRepository) GetOneByIDQuery(ctx context.Context, tripID int64, checklistID int64, tx *sqlx.Tx) (*entity.TripChecklist, error) {
+	var checklist entity.TripChecklist
+	query := "SELECT * FROM trip_checklists WHERE id = ? AND trip_id = ? AND deleted_at IS NULL"
+
+	var err error
+	if tx != nil {
+		err = tx.GetContext(ctx, &checklist, query, checklistID, tripID)
+	} else {
+		err = repo.db.GetContext(ctx, &checklist, query, checklistID, tripID)
+	}
+	if err != nil {
+		if err.Error() == error_utils.SystemErrorMessage.SqlxNoRow {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &checklist, nil
+}
+
+func (repo *TripChecklistRepository) GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripChecklist, error) {
+	checklists := make([]entity.TripChecklist, 0)
+	query := "SELECT * FROM trip_checklists WHERE trip_id = ? AND deleted_at IS NULL ORDER BY id ASC"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &checklists, query, tripID)
+		return checklists, err
+	}
+	err := repo.db.SelectContext(ctx, &checklists, query, tripID)
+	return checklists, err
+}
+
+func (repo *TripChecklistRepository) DeleteByIDCommand(ctx context.Context, checklistID int64, tx *sqlx.Tx) error {
+	query := "UPDATE trip_checklists SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, checklistID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, checklistID)
+	return err
+}
+
+func (repo *TripChecklistRepository) CreateItemCommand(ctx context.Context, item *entity.TripChecklistItem, tx *sqlx.Tx) (int64, error) {
+	insertQuery := `
+	INSERT INTO trip_checklist_items(checklist_id, trip_id, created_by, title, assignee_id, due_date) 
+	VALUES (:checklist_id, :trip_id, :created_by, :title, :assignee_id, :due_date)
+	`
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.NamedExecContext(ctx, insertQuery, item)
+	} else {
+		result, err = repo.db.NamedExecContext(ctx, insertQuery, item)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (repo *TripChecklistRepository) GetOneItemByIDQuery(ctx context.Context, checklistID int64, itemID int64, tx *sqlx.Tx) (*entity.TripChecklistItem, error) {
+	var item entity.TripChecklistItem
+	query := "SELECT * FROM trip_checklist_items WHERE id = ? AND checklist_id = ? AND deleted_at IS NULL"
+
+	var err error
+	if tx != nil {
+		err = tx.GetContext(ctx, &item, query, itemID, checklistID)
+	} else {
+		err = repo.db.GetContext(ctx, &item, query, itemID, checklistID)
+	}
+	if err != nil {
+		if err.Error() == error_utils.SystemErrorMessage.SqlxNoRow {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (repo *TripChecklistRepository) GetAllItemsByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripChecklistItem, error) {
+	items := make([]entity.TripChecklistItem, 0)
+	query := `
+		SELECT i.* FROM trip_checklist_items i
+		JOIN trip_checklists c ON c.id = i.checklist_id AND c.deleted_at IS NULL
+		WHERE i.trip_id = ? AND i.deleted_at IS NULL
+		ORDER BY i.checklist_id ASC, i.id ASC
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &items, query, tripID)
+		return items, err
+	}
+	err := repo.db.SelectContext(ctx, &items, query, tripID)
+	return items, err
+}
+
+func (repo *TripChecklistRepository) UpdateItemCommand(ctx context.Context, item *entity.TripChecklistItem, tx *sqlx.Tx) error {
+	updateQuery := `
+		UPDATE trip_checklist_items SET
+			title = :title,
+			assignee_id = :assignee_id,
+			due_date = :due_date,
+			is_done = :is_done,
+			done_by = :done_by,
+			done_at = :done_at,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = :id
+	`
+	if tx != nil {
+		_, err := tx.NamedExecContext(ctx, updateQuery, item)
+		return err
+	}
+
+	_, err := repo.db.NamedExecContext(ctx, updateQuery, item)
+	return err
+}
+
+func (repo *TripChecklistRepository) DeleteItemByIDCommand(ctx context.Context, itemID int64, tx *sqlx.Tx) error {
+	query := "UPDATE trip_checklist_items SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, itemID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, itemID)
+	return err
+}
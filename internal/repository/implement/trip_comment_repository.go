@@ -0,0 +1,95 @@
+package repositoryimplement
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+type TripCommentRepository struct {
+	db *sqlx.DB
+}
+
+func NewTripCommentRepository(db database.Db) repository.TripCommentRepository {
+	return &TripCommentRepository{db: db}
+}
+
+func (repo *TripCommentRepository) CreateCommand(ctx context.Context, comment *entity.TripComment, tx *sqlx.Tx) (int64, error) {
+	insertQuery := `
+	INSERT INTO trip_comments(trip_id, trip_item_id, parent_id, author_id, content) 
+	VALUES (:trip_id, :trip_item_id, :parent_id, :author_id, :content)
+	`
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.NamedExecContext(ctx, insertQuery, comment)
+	} else {
+		result, err = repo.db.NamedExecContext(ctx, insertQuery, comment)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (repo *TripCommentRepository) GetOneByIDQuery(ctx context.Context, tripID int64, commentID int64, tx *sqlx.Tx) (*entity.TripComment, error) {
+	var comment entity.TripComment
+	query := "SELECT * FROM trip_comments WHERE id = ? AND trip_id = ? AND deleted_at IS NULL"
+
+	var err error
+	if tx != nil {
+		err = tx.GetContext(ctx, &comment, query, commentID, tripID)
+	} else {
+		err = repo.db.GetContext(ctx, &comment, query, commentID, tripID)
+	}
+	if err != nil {
+		if err.Error() == error_utils.SystemErrorMessage.SqlxNoRow {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (repo *TripCommentRepository) GetAllByTargetQuery(ctx context.Context, tripID int64, tripItemID *int64, tx *sqlx.Tx) ([]entity.TripComment, error) {
+	comments := make([]entity.TripComment, 0)
+	query := "SELECT * FROM trip_comments WHERE trip_id = ? AND trip_item_id IS NULL ORDER BY created_at ASC, id ASC"
+	args := []interface{}{tripID}
+	if tripItemID != nil {
+		query = "SELECT * FROM trip_comments WHERE trip_id = ? AND trip_item_id = ? ORDER BY created_at ASC, id ASC"
+		args = append(args, *tripItemID)
+	}
+
+	if tx != nil {
+		err := tx.SelectContext(ctx, &comments, query, args...)
+		return comments, err
+	}
+	err := repo.db.SelectContext(ctx, &comments, query, args...)
+	return comments, err
+}
+
+func (repo *TripCommentRepository) UpdateContentCommand(ctx context.Context, commentID int64, content string, tx *sqlx.Tx) error {
+	query := "UPDATE trip_comments SET content = ?, edited_at = CURRENT_TIMESTAMP WHERE id = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, content, commentID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, content, commentID)
+	return err
+}
+
+func (repo *TripCommentRepository) DeleteByIDCommand(ctx context.Context, commentID int64, tx *sqlx.Tx) error {
+	query := "UPDATE trip_comments SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, commentID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, commentID)
+	return err
+}
@@ -0,0 +1,98 @@
+package repositoryimplement
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+type TripDocumentRepository struct {
+	db *sqlx.DB
+}
+
+func NewTripDocumentRepository(db database.Db) repository.TripDocumentRepository {
+	return &TripDocumentRepository{db: db}
+}
+
+func (repo *TripDocumentRepository) CreateCommand(ctx context.Context, document *entity.TripDocument, tx *sqlx.Tx) (int64, error) {
+	insertQuery := `
+	INSERT INTO trip_documents(
+		trip_id, uploaded_by, title, file_name, content_type, s3_key, visibility, trip_item_id, trip_booking_id
+	) 
+	VALUES (
+		:trip_id, :uploaded_by, :title, :file_name, :content_type, :s3_key, :visibility, :trip_item_id, :trip_booking_id
+	)
+	`
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.NamedExecContext(ctx, insertQuery, document)
+	} else {
+		result, err = repo.db.NamedExecContext(ctx, insertQuery, document)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (repo *TripDocumentRepository) GetOneByIDQuery(ctx context.Context, tripID int64, documentID int64, tx *sqlx.Tx) (*entity.TripDocument, error) {
+	var document entity.TripDocument
+	query := "SELECT * FROM trip_documents WHERE id = ? AND trip_id = ? AND deleted_at IS NULL"
+
+	var err error
+	if tx != nil {
+		err = tx.GetContext(ctx, &document, query, documentID, tripID)
+	} else {
+		err = repo.db.GetContext(ctx, &document, query, documentID, tripID)
+	}
+	if err != nil {
+		if err.Error() == error_utils.SystemErrorMessage.SqlxNoRow {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (repo *TripDocumentRepository) GetAllVisibleByTripIDQuery(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) ([]entity.TripDocument, error) {
+	documents := make([]entity.TripDocument, 0)
+	query := `
+		SELECT * FROM trip_documents
+		WHERE trip_id = ? AND deleted_at IS NULL AND (visibility = 'trip' OR uploaded_by = ?)
+		ORDER BY created_at DESC, id DESC
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &documents, query, tripID, userID)
+		return documents, err
+	}
+	err := repo.db.SelectContext(ctx, &documents, query, tripID, userID)
+	return documents, err
+}
+
+func (repo *TripDocumentRepository) GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripDocument, error) {
+	documents := make([]entity.TripDocument, 0)
+	query := "SELECT * FROM trip_documents WHERE trip_id = ? AND deleted_at IS NULL"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &documents, query, tripID)
+		return documents, err
+	}
+	err := repo.db.SelectContext(ctx, &documents, query, tripID)
+	return documents, err
+}
+
+func (repo *TripDocumentRepository) DeleteByIDCommand(ctx context.Context, documentID int64, tx *sqlx.Tx) error {
+	query := "UPDATE trip_documents SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, documentID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, documentID)
+	return err
+}
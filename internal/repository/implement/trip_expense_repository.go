@@ -0,0 +1,120 @@
+package repositoryimplement
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+type TripExpenseRepository struct {
+	db *sqlx.DB
+}
+
+func NewTripExpenseRepository(db database.Db) repository.TripExpenseRepository {
+	return &TripExpenseRepository{db: db}
+}
+
+func (repo *TripExpenseRepository) CreateCommand(ctx context.Context, expense *entity.TripExpense, tx *sqlx.Tx) (int64, error) {
+	insertQuery := `
+	INSERT INTO trip_expenses(
+		trip_id, trip_item_id, payer_id, created_by, description, amount, currency, split_type,
+		category, trip_day, base_currency, exchange_rate, base_amount
+	) 
+	VALUES (
+		:trip_id, :trip_item_id, :payer_id, :created_by, :description, :amount, :currency, :split_type,
+		:category, :trip_day, :base_currency, :exchange_rate, :base_amount
+	)
+	`
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.NamedExecContext(ctx, insertQuery, expense)
+	} else {
+		result, err = repo.db.NamedExecContext(ctx, insertQuery, expense)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (repo *TripExpenseRepository) CreateSplitCommand(ctx context.Context, split *entity.TripExpenseSplit, tx *sqlx.Tx) error {
+	insertQuery := `
+	INSERT INTO trip_expense_splits(
+		expense_id, user_id, share, amount
+	) 
+	VALUES (
+		:expense_id, :user_id, :share, :amount
+	)
+	`
+	if tx != nil {
+		_, err := tx.NamedExecContext(ctx, insertQuery, split)
+		return err
+	}
+
+	_, err := repo.db.NamedExecContext(ctx, insertQuery, split)
+	return err
+}
+
+func (repo *TripExpenseRepository) GetOneByIDQuery(ctx context.Context, tripID int64, expenseID int64, tx *sqlx.Tx) (*entity.TripExpense, error) {
+	var expense entity.TripExpense
+	query := "SELECT * FROM trip_expenses WHERE id = ? AND trip_id = ? AND deleted_at IS NULL"
+
+	var err error
+	if tx != nil {
+		err = tx.GetContext(ctx, &expense, query, expenseID, tripID)
+	} else {
+		err = repo.db.GetContext(ctx, &expense, query, expenseID, tripID)
+	}
+	if err != nil {
+		if err.Error() == error_utils.SystemErrorMessage.SqlxNoRow {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &expense, nil
+}
+
+func (repo *TripExpenseRepository) GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripExpense, error) {
+	expenses := make([]entity.TripExpense, 0)
+	query := "SELECT * FROM trip_expenses WHERE trip_id = ? AND deleted_at IS NULL ORDER BY created_at ASC, id ASC"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &expenses, query, tripID)
+		return expenses, err
+	}
+	err := repo.db.SelectContext(ctx, &expenses, query, tripID)
+	return expenses, err
+}
+
+func (repo *TripExpenseRepository) GetAllSplitsByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripExpenseSplit, error) {
+	splits := make([]entity.TripExpenseSplit, 0)
+	query := `
+		SELECT s.*
+		FROM trip_expense_splits s
+		JOIN trip_expenses e ON e.id = s.expense_id
+		WHERE e.trip_id = ? AND e.deleted_at IS NULL
+		ORDER BY s.expense_id ASC, s.id ASC
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &splits, query, tripID)
+		return splits, err
+	}
+	err := repo.db.SelectContext(ctx, &splits, query, tripID)
+	return splits, err
+}
+
+func (repo *TripExpenseRepository) DeleteByIDCommand(ctx context.Context, expenseID int64, tx *sqlx.Tx) error {
+	query := "UPDATE trip_expenses SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, expenseID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, expenseID)
+	return err
+}
@@ -2,11 +2,14 @@ package repositoryimplement
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
 )
 
 type TripImageRepository struct {
@@ -26,15 +29,39 @@ func (repo *TripImageRepository) CreateCommand(ctx context.Context, tripImage *e
 		:trip_id, :image_url, :user_id, :trip_item_id
 	)
 	`
+	var result sql.Result
+	var err error
 	if tx != nil {
-		_, err := tx.NamedExecContext(ctx, insertQuery, tripImage)
+		result, err = tx.NamedExecContext(ctx, insertQuery, tripImage)
+	} else {
+		result, err = repo.db.NamedExecContext(ctx, insertQuery, tripImage)
+	}
+	if err != nil {
 		return err
 	}
-
-	_, err := repo.db.NamedExecContext(ctx, insertQuery, tripImage)
+	tripImage.ID, err = result.LastInsertId()
 	return err
 }
 
+func (repo *TripImageRepository) GetOneByIDQuery(ctx context.Context, tripID int64, id int64, tx *sqlx.Tx) (*entity.TripImage, error) {
+	var tripImage entity.TripImage
+	query := "SELECT * FROM trip_images WHERE id = ? AND trip_id = ? AND deleted_at IS NULL"
+
+	var err error
+	if tx != nil {
+		err = tx.GetContext(ctx, &tripImage, query, id, tripID)
+	} else {
+		err = repo.db.GetContext(ctx, &tripImage, query, id, tripID)
+	}
+	if err != nil {
+		if err.Error() == error_utils.SystemErrorMessage.SqlxNoRow {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tripImage, nil
+}
+
 func (repo *TripImageRepository) GetAllQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripImage, error) {
 	var tripImages []entity.TripImage
 	query := "SELECT * FROM trip_images WHERE trip_id = ? AND deleted_at IS NULL ORDER BY created_at ASC"
@@ -96,3 +123,22 @@ func (repo *TripImageRepository) GetAllByTripIDAndTripItemIDQuery(ctx context.Co
 	err := repo.db.SelectContext(ctx, &tripImages, query, tripID, tripItemID)
 	return tripImages, err
 }
+
+func (repo *TripImageRepository) GetChangedByUserIdQuery(ctx context.Context, userId int64, since time.Time, tx *sqlx.Tx) ([]entity.TripImage, error) {
+	tripImages := make([]entity.TripImage, 0)
+	query := `
+		SELECT ti.* 
+		FROM trip_images ti
+		JOIN trips t ON t.id = ti.trip_id
+		JOIN trip_members tm ON tm.trip_id = ti.trip_id
+		WHERE tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NULL AND ti.deleted_at IS NULL
+			AND (ti.updated_at >= ? OR tm.updated_at >= ?)
+		ORDER BY ti.created_at ASC
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &tripImages, query, userId, since, since)
+		return tripImages, err
+	}
+	err := repo.db.SelectContext(ctx, &tripImages, query, userId, since, since)
+	return tripImages, err
+}
@@ -2,7 +2,9 @@ package repositoryimplement
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
@@ -23,28 +25,80 @@ func (repo *TripItemRepository) CreateCommand(ctx context.Context, tripItem *ent
 	// Insert the new trip item
 	insertQuery := `
 	INSERT INTO trip_items(
-		id, trip_id, place_id, trip_day, order_in_day, time_in_date
+		id, trip_id, place_id, trip_day, order_in_day, time_in_date, note, start_time, end_time,
+		estimated_cost, cost_category
 	) 
 	VALUES (
-		:id, :trip_id, :place_id, :trip_day, :order_in_day, :time_in_date  
+		:id, :trip_id, :place_id, :trip_day, :order_in_day, :time_in_date, :note, :start_time, :end_time,
+		:estimated_cost, :cost_category
 	)
 	`
+	var result sql.Result
+	var err error
 	if tx != nil {
-		_, err := tx.NamedExecContext(ctx, insertQuery, tripItem)
+		result, err = tx.NamedExecContext(ctx, insertQuery, tripItem)
+	} else {
+		result, err = repo.db.NamedExecContext(ctx, insertQuery, tripItem)
+	}
+	if err != nil {
 		return err
 	}
+	if tripItem.ID == 0 {
+		tripItem.ID, err = result.LastInsertId()
+	}
+	return err
+}
 
-	_, err := repo.db.NamedExecContext(ctx, insertQuery, tripItem)
+func (repo *TripItemRepository) UpdateCommand(ctx context.Context, tripItem *entity.TripItem, tx *sqlx.Tx) error {
+	// updated_at is left to ON UPDATE, so items saved unchanged are not sent again to syncing clients
+	query := `
+		UPDATE trip_items SET
+			place_id = :place_id,
+			trip_day = :trip_day,
+			order_in_day = :order_in_day,
+			time_in_date = :time_in_date,
+			note = :note,
+			start_time = :start_time,
+			end_time = :end_time,
+			estimated_cost = :estimated_cost,
+			cost_category = :cost_category
+		WHERE id = :id AND trip_id = :trip_id
+	`
+	if tx != nil {
+		_, err := tx.NamedExecContext(ctx, query, tripItem)
+		return err
+	}
+	_, err := repo.db.NamedExecContext(ctx, query, tripItem)
 	return err
 }
 
-func (repo *TripItemRepository) DeleteByTripIDCommand(ctx context.Context, tripID int64, tx *sqlx.Tx) error {
-	query := "DELETE FROM trip_items WHERE trip_id = ?"
+func (repo *TripItemRepository) DeleteByIDCommand(ctx context.Context, tripID int64, tripItemID int64, tx *sqlx.Tx) error {
+	query := "DELETE FROM trip_items WHERE trip_id = ? AND id = ?"
 	if tx != nil {
-		_, err := tx.ExecContext(ctx, query, tripID)
+		_, err := tx.ExecContext(ctx, query, tripID, tripItemID)
 		return err
 	}
-	_, err := repo.db.ExecContext(ctx, query, tripID)
+	_, err := repo.db.ExecContext(ctx, query, tripID, tripItemID)
+	return err
+}
+
+func (repo *TripItemRepository) ReleasePlaceIDsCommand(ctx context.Context, tripID int64, tripItemIDs []int64, tx *sqlx.Tx) error {
+	if len(tripItemIDs) == 0 {
+		return nil
+	}
+	// place_id is unique and not null, the item id keeps the placeholders apart
+	query, args, err := sqlx.In(`
+		UPDATE trip_items SET place_id = CONCAT('released:', id)
+		WHERE trip_id = ? AND id IN (?)
+	`, tripID, tripItemIDs)
+	if err != nil {
+		return err
+	}
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, tx.Rebind(query), args...)
+		return err
+	}
+	_, err = repo.db.ExecContext(ctx, repo.db.Rebind(query), args...)
 	return err
 }
 
@@ -81,16 +135,40 @@ func (repo *TripItemRepository) GetTripItemsByTripIDCommand(ctx context.Context,
 	}
 
 	for _, tripItem := range tripItemsWithUserId {
+		var note *string
+		if tripItem.Note.Valid {
+			note = &tripItem.Note.String
+		}
+		var startTime, endTime *string
+		if tripItem.StartTime.Valid {
+			startTime = &tripItem.StartTime.String
+		}
+		if tripItem.EndTime.Valid {
+			endTime = &tripItem.EndTime.String
+		}
+		var estimatedCost *float64
+		if tripItem.EstimatedCost.Valid {
+			estimatedCost = &tripItem.EstimatedCost.Float64
+		}
+		var costCategory *string
+		if tripItem.CostCategory.Valid {
+			costCategory = &tripItem.CostCategory.String
+		}
 		tripItems = append(tripItems, entity.TripItem{
-			ID:         tripItem.ID.Int64,
-			TripID:     tripItem.TripID.Int64,
-			PlaceID:    tripItem.PlaceID.String,
-			TripDay:    tripItem.TripDay.Int64,
-			OrderInDay: tripItem.OrderInDay.Int64,
-			TimeInDate: tripItem.TimeInDate.String,
-			CreatedAt:  tripItem.CreatedAt.Time,
-			UpdatedAt:  tripItem.UpdatedAt.Time,
-			DeletedAt:  tripItem.DeletedAt,
+			ID:            tripItem.ID.Int64,
+			TripID:        tripItem.TripID.Int64,
+			PlaceID:       tripItem.PlaceID.String,
+			TripDay:       tripItem.TripDay.Int64,
+			OrderInDay:    tripItem.OrderInDay.Int64,
+			TimeInDate:    tripItem.TimeInDate.String,
+			Note:          note,
+			StartTime:     startTime,
+			EndTime:       endTime,
+			EstimatedCost: estimatedCost,
+			CostCategory:  costCategory,
+			CreatedAt:     tripItem.CreatedAt.Time,
+			UpdatedAt:     tripItem.UpdatedAt.Time,
+			DeletedAt:     tripItem.DeletedAt,
 		})
 	}
 	return tripItems, nil
@@ -107,3 +185,98 @@ func (repo *TripItemRepository) ExistsByTripIDAndTripItemIDCommand(ctx context.C
 	err := repo.db.GetContext(ctx, &exists, query, tripID, tripItemID)
 	return exists, err
 }
+
+func (repo *TripItemRepository) GetOneByIDQuery(ctx context.Context, tripID int64, tripItemID int64, tx *sqlx.Tx) (*entity.TripItem, error) {
+	var tripItem entity.TripItem
+	query := "SELECT * FROM trip_items WHERE trip_id = ? AND id = ? AND deleted_at IS NULL"
+
+	var err error
+	if tx != nil {
+		err = tx.GetContext(ctx, &tripItem, query, tripID, tripItemID)
+	} else {
+		err = repo.db.GetContext(ctx, &tripItem, query, tripID, tripItemID)
+	}
+	if err != nil {
+		if err.Error() == error_utils.SystemErrorMessage.SqlxNoRow {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tripItem, nil
+}
+
+func (repo *TripItemRepository) ExistsByPlaceIDQuery(ctx context.Context, placeID string, tx *sqlx.Tx) (bool, error) {
+	query := "SELECT EXISTS(SELECT 1 FROM trip_items WHERE place_id = ?)"
+	var exists bool
+
+	if tx != nil {
+		err := tx.GetContext(ctx, &exists, query, placeID)
+		return exists, err
+	}
+	err := repo.db.GetContext(ctx, &exists, query, placeID)
+	return exists, err
+}
+
+// UpdatePlaceIDCommand swaps the place of an item; the cost estimate belonged to the old place, so it is cleared
+func (repo *TripItemRepository) UpdatePlaceIDCommand(ctx context.Context, tripItemID int64, placeID string, tx *sqlx.Tx) error {
+	query := `
+		UPDATE trip_items SET
+			place_id = ?,
+			estimated_cost = NULL,
+			cost_category = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND deleted_at IS NULL
+	`
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, placeID, tripItemID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, placeID, tripItemID)
+	return err
+}
+
+func (repo *TripItemRepository) ConvertEstimatedCostsCommand(ctx context.Context, tripID int64, rate float64, tx *sqlx.Tx) error {
+	query := `
+		UPDATE trip_items SET
+			estimated_cost = ROUND(estimated_cost * ?, 2),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE trip_id = ? AND estimated_cost IS NOT NULL AND deleted_at IS NULL
+	`
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, rate, tripID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, rate, tripID)
+	return err
+}
+
+func (repo *TripItemRepository) GetChangedByUserIdQuery(ctx context.Context, userId int64, since time.Time, tx *sqlx.Tx) ([]entity.TripItem, error) {
+	tripItems := make([]entity.TripItem, 0)
+	query := `
+		SELECT ti.* 
+		FROM trip_items ti
+		JOIN trips t ON t.id = ti.trip_id
+		JOIN trip_members tm ON tm.trip_id = ti.trip_id
+		WHERE tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NULL
+			AND (ti.updated_at >= ? OR tm.updated_at >= ?)
+		ORDER BY ti.trip_id, ti.trip_day, ti.order_in_day
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &tripItems, query, userId, since, since)
+		return tripItems, err
+	}
+	err := repo.db.SelectContext(ctx, &tripItems, query, userId, since, since)
+	return tripItems, err
+}
+
+// GetLastUpdatedAtQuery returns when an item of the trip was last written, nil when the trip has no items
+func (repo *TripItemRepository) GetLastUpdatedAtQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) (*time.Time, error) {
+	var updatedAt *time.Time
+	query := "SELECT MAX(updated_at) FROM trip_items WHERE trip_id = ?"
+	if tx != nil {
+		err := tx.GetContext(ctx, &updatedAt, query, tripID)
+		return updatedAt, err
+	}
+	err := repo.db.GetContext(ctx, &updatedAt, query, tripID)
+	return updatedAt, err
+}
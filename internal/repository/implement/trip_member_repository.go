@@ -2,6 +2,7 @@ package repositoryimplement
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
@@ -39,8 +40,9 @@ func (repo *TripMemberRepository) CreateCommand(ctx context.Context, tripMember
 func (repo *TripMemberRepository) IsUserInTripQuery(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) (bool, error) {
 	var count int
 	query := `
-		SELECT COUNT(*) FROM trip_members 
-		WHERE trip_id = ? AND user_id = ? AND deleted_at IS NULL
+		SELECT COUNT(*) FROM trip_members tm
+		JOIN trips t ON t.id = tm.trip_id
+		WHERE tm.trip_id = ? AND tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NULL
 	`
 	if tx != nil {
 		err := tx.GetContext(ctx, &count, query, tripID, userID)
@@ -53,8 +55,9 @@ func (repo *TripMemberRepository) IsUserInTripQuery(ctx context.Context, tripID
 func (repo *TripMemberRepository) IsUserTripAdminQuery(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) (bool, error) {
 	var count int
 	query := `
-		SELECT COUNT(*) FROM trip_members 
-		WHERE trip_id = ? AND user_id = ? AND role IN ('administrator') AND deleted_at IS NULL
+		SELECT COUNT(*) FROM trip_members tm
+		JOIN trips t ON t.id = tm.trip_id
+		WHERE tm.trip_id = ? AND tm.user_id = ? AND tm.role IN ('owner', 'administrator') AND tm.deleted_at IS NULL AND t.deleted_at IS NULL
 	`
 	if tx != nil {
 		err := tx.GetContext(ctx, &count, query, tripID, userID)
@@ -64,6 +67,26 @@ func (repo *TripMemberRepository) IsUserTripAdminQuery(ctx context.Context, trip
 	return count > 0, err
 }
 
+func (repo *TripMemberRepository) GetMemberRoleQuery(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) (string, error) {
+	var roles []string
+	query := `
+		SELECT tm.role FROM trip_members tm
+		JOIN trips t ON t.id = tm.trip_id
+		WHERE tm.trip_id = ? AND tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NULL
+		LIMIT 1
+	`
+	var err error
+	if tx != nil {
+		err = tx.SelectContext(ctx, &roles, query, tripID, userID)
+	} else {
+		err = repo.db.SelectContext(ctx, &roles, query, tripID, userID)
+	}
+	if err != nil || len(roles) == 0 {
+		return "", err
+	}
+	return roles[0], nil
+}
+
 func (repo *TripMemberRepository) GetTripMembersQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripMemberWithUser, error) {
 	members := make([]entity.TripMemberWithUser, 0)
 	query := `
@@ -81,6 +104,39 @@ func (repo *TripMemberRepository) GetTripMembersQuery(ctx context.Context, tripI
 	return members, err
 }
 
+// CountByTripIDsQuery returns the number of members of each of the trips in one aggregate query
+func (repo *TripMemberRepository) CountByTripIDsQuery(ctx context.Context, tripIDs []int64, tx *sqlx.Tx) (map[int64]int, error) {
+	counts := make(map[int64]int, len(tripIDs))
+	if len(tripIDs) == 0 {
+		return counts, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT trip_id, COUNT(*) AS member_count FROM trip_members 
+		WHERE trip_id IN (?) AND deleted_at IS NULL
+		GROUP BY trip_id
+	`, tripIDs)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		TripID      int64 `db:"trip_id"`
+		MemberCount int   `db:"member_count"`
+	}
+	if tx != nil {
+		err = tx.SelectContext(ctx, &rows, tx.Rebind(query), args...)
+	} else {
+		err = repo.db.SelectContext(ctx, &rows, repo.db.Rebind(query), args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.TripID] = row.MemberCount
+	}
+	return counts, nil
+}
+
 func (repo *TripMemberRepository) DeleteMemberCommand(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) error {
 	query := `
 		DELETE FROM trip_members 
@@ -93,3 +149,51 @@ func (repo *TripMemberRepository) DeleteMemberCommand(ctx context.Context, tripI
 	_, err := repo.db.ExecContext(ctx, query, tripID, userID)
 	return err
 }
+
+func (repo *TripMemberRepository) UpdateRoleCommand(ctx context.Context, tripID int64, userID int64, role string, tx *sqlx.Tx) error {
+	query := `
+		UPDATE trip_members 
+		SET role = ? 
+		WHERE trip_id = ? AND user_id = ? AND deleted_at IS NULL
+	`
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, role, tripID, userID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, role, tripID, userID)
+	return err
+}
+
+func (repo *TripMemberRepository) TouchByTripIDCommand(ctx context.Context, tripID int64, tx *sqlx.Tx) error {
+	query := `
+		UPDATE trip_members 
+		SET updated_at = CURRENT_TIMESTAMP 
+		WHERE trip_id = ? AND deleted_at IS NULL
+	`
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, tripID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, tripID)
+	return err
+}
+
+func (repo *TripMemberRepository) GetChangedByUserIdQuery(ctx context.Context, userId int64, since time.Time, tx *sqlx.Tx) ([]entity.TripMemberWithUser, error) {
+	members := make([]entity.TripMemberWithUser, 0)
+	query := `
+		SELECT m.*, u.name as name, u.photo_url 
+		FROM trip_members m
+		JOIN users u ON m.user_id = u.id
+		JOIN trips t ON t.id = m.trip_id
+		JOIN trip_members tm ON tm.trip_id = m.trip_id
+		WHERE tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NULL AND m.deleted_at IS NULL
+			AND (m.updated_at >= ? OR u.updated_at >= ? OR tm.updated_at >= ?)
+		ORDER BY m.trip_id, m.created_at ASC
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &members, query, userId, since, since, since)
+		return members, err
+	}
+	err := repo.db.SelectContext(ctx, &members, query, userId, since, since, since)
+	return members, err
+}
@@ -0,0 +1,216 @@
+package repositoryimplement
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+type TripPollRepository struct {
+	db *sqlx.DB
+}
+
+func NewTripPollRepository(db database.Db) repository.TripPollRepository {
+	return &TripPollRepository{db: db}
+}
+
+func (repo *TripPollRepository) CreateCommand(ctx context.Context, poll *entity.TripPoll, tx *sqlx.Tx) (int64, error) {
+	insertQuery := `
+	INSERT INTO trip_polls(
+		trip_id, created_by, question, option_type, allow_multiple, is_anonymous, deadline, status, apply_to_trip_item_id
+	) 
+	VALUES (
+		:trip_id, :created_by, :question, :option_type, :allow_multiple, :is_anonymous, :deadline, :status, :apply_to_trip_item_id
+	)
+	`
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.NamedExecContext(ctx, insertQuery, poll)
+	} else {
+		result, err = repo.db.NamedExecContext(ctx, insertQuery, poll)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (repo *TripPollRepository) CreateOptionCommand(ctx context.Context, option *entity.TripPollOption, tx *sqlx.Tx) (int64, error) {
+	insertQuery := `
+	INSERT INTO trip_poll_options(poll_id, label, place_id, option_order) 
+	VALUES (:poll_id, :label, :place_id, :option_order)
+	`
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.NamedExecContext(ctx, insertQuery, option)
+	} else {
+		result, err = repo.db.NamedExecContext(ctx, insertQuery, option)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (repo *TripPollRepository) GetOneByIDQuery(ctx context.Context, tripID int64, pollID int64, tx *sqlx.Tx) (*entity.TripPoll, error) {
+	var poll entity.TripPoll
+	query := "SELECT * FROM trip_polls WHERE id = ? AND trip_id = ? AND deleted_at IS NULL"
+
+	var err error
+	if tx != nil {
+		err = tx.GetContext(ctx, &poll, query, pollID, tripID)
+	} else {
+		err = repo.db.GetContext(ctx, &poll, query, pollID, tripID)
+	}
+	if err != nil {
+		if err.Error() == error_utils.SystemErrorMessage.SqlxNoRow {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &poll, nil
+}
+
+func (repo *TripPollRepository) GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripPoll, error) {
+	polls := make([]entity.TripPoll, 0)
+	query := "SELECT * FROM trip_polls WHERE trip_id = ? AND deleted_at IS NULL ORDER BY created_at DESC, id DESC"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &polls, query, tripID)
+		return polls, err
+	}
+	err := repo.db.SelectContext(ctx, &polls, query, tripID)
+	return polls, err
+}
+
+func (repo *TripPollRepository) GetAllOpenPastDeadlineQuery(ctx context.Context, now time.Time, tx *sqlx.Tx) ([]entity.TripPoll, error) {
+	polls := make([]entity.TripPoll, 0)
+	query := `
+		SELECT * FROM trip_polls
+		WHERE status = 'open' AND deadline IS NOT NULL AND deadline <= ? AND deleted_at IS NULL
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &polls, query, now)
+		return polls, err
+	}
+	err := repo.db.SelectContext(ctx, &polls, query, now)
+	return polls, err
+}
+
+func (repo *TripPollRepository) GetOptionsByPollIDQuery(ctx context.Context, pollID int64, tx *sqlx.Tx) ([]entity.TripPollOption, error) {
+	options := make([]entity.TripPollOption, 0)
+	query := "SELECT * FROM trip_poll_options WHERE poll_id = ? ORDER BY option_order ASC"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &options, query, pollID)
+		return options, err
+	}
+	err := repo.db.SelectContext(ctx, &options, query, pollID)
+	return options, err
+}
+
+func (repo *TripPollRepository) GetOptionsByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripPollOption, error) {
+	options := make([]entity.TripPollOption, 0)
+	query := `
+		SELECT o.* FROM trip_poll_options o
+		JOIN trip_polls p ON p.id = o.poll_id AND p.deleted_at IS NULL
+		WHERE p.trip_id = ?
+		ORDER BY o.poll_id ASC, o.option_order ASC
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &options, query, tripID)
+		return options, err
+	}
+	err := repo.db.SelectContext(ctx, &options, query, tripID)
+	return options, err
+}
+
+func (repo *TripPollRepository) GetVotesByPollIDQuery(ctx context.Context, pollID int64, tx *sqlx.Tx) ([]entity.TripPollVote, error) {
+	votes := make([]entity.TripPollVote, 0)
+	query := "SELECT * FROM trip_poll_votes WHERE poll_id = ? ORDER BY created_at ASC, id ASC"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &votes, query, pollID)
+		return votes, err
+	}
+	err := repo.db.SelectContext(ctx, &votes, query, pollID)
+	return votes, err
+}
+
+func (repo *TripPollRepository) GetVotesByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripPollVote, error) {
+	votes := make([]entity.TripPollVote, 0)
+	query := `
+		SELECT v.* FROM trip_poll_votes v
+		JOIN trip_polls p ON p.id = v.poll_id AND p.deleted_at IS NULL
+		WHERE p.trip_id = ?
+		ORDER BY v.created_at ASC, v.id ASC
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &votes, query, tripID)
+		return votes, err
+	}
+	err := repo.db.SelectContext(ctx, &votes, query, tripID)
+	return votes, err
+}
+
+func (repo *TripPollRepository) ReplaceVotesCommand(ctx context.Context, pollID int64, userID int64, optionIDs []int64, tx *sqlx.Tx) error {
+	deleteQuery := "DELETE FROM trip_poll_votes WHERE poll_id = ? AND user_id = ?"
+	insertQuery := "INSERT INTO trip_poll_votes(poll_id, option_id, user_id) VALUES (?, ?, ?)"
+
+	exec := repo.db.ExecContext
+	if tx != nil {
+		exec = tx.ExecContext
+	}
+	if _, err := exec(ctx, deleteQuery, pollID, userID); err != nil {
+		return err
+	}
+	for _, optionID := range optionIDs {
+		if _, err := exec(ctx, insertQuery, pollID, optionID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (repo *TripPollRepository) CloseCommand(ctx context.Context, pollID int64, winningOptionID *int64, tx *sqlx.Tx) (bool, error) {
+	query := `
+		UPDATE trip_polls SET
+			status = 'closed',
+			closed_at = CURRENT_TIMESTAMP,
+			winning_option_id = ?
+		WHERE id = ? AND status = 'open' AND deleted_at IS NULL
+	`
+
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, winningOptionID, pollID)
+	} else {
+		result, err = repo.db.ExecContext(ctx, query, winningOptionID, pollID)
+	}
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (repo *TripPollRepository) DeleteByIDCommand(ctx context.Context, pollID int64, tx *sqlx.Tx) error {
+	query := "UPDATE trip_polls SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, pollID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, pollID)
+	return err
+}
@@ -0,0 +1,45 @@
+package repositoryimplement
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+)
+
+type TripReminderRepository struct {
+	db *sqlx.DB
+}
+
+func NewTripReminderRepository(db database.Db) repository.TripReminderRepository {
+	return &TripReminderRepository{db: db}
+}
+
+func (repo *TripReminderRepository) ClaimCommand(ctx context.Context, tripID int64, reminderType string, startDate time.Time, tx *sqlx.Tx) (bool, error) {
+	query := "INSERT IGNORE INTO trip_reminders(trip_id, type, start_date) VALUES (?, ?, ?)"
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, tripID, reminderType, startDate)
+	} else {
+		result, err = repo.db.ExecContext(ctx, query, tripID, reminderType, startDate)
+	}
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+func (repo *TripReminderRepository) ReleaseCommand(ctx context.Context, tripID int64, reminderType string, startDate time.Time, tx *sqlx.Tx) error {
+	query := "DELETE FROM trip_reminders WHERE trip_id = ? AND type = ? AND start_date = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, tripID, reminderType, startDate)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, tripID, reminderType, startDate)
+	return err
+}
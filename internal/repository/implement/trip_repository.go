@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -25,13 +26,13 @@ func (repo *TripRepository) CreateCommand(ctx context.Context, trip *entity.Trip
 	// Insert the new trip
 	insertQuery := `
 	INSERT INTO trips(
-		title, city, start_date, days, budget, 
+		title, city, start_date, time_zone, days, budget, budget_currency, 
 		vi_location_attributes, vi_food_attributes, vi_special_requirements, vi_medical_conditions,
 		en_location_attributes, en_food_attributes, en_special_requirements, en_medical_conditions,
 		status, reference_id
 	) 
 	VALUES (
-		:title, :city, :start_date, :days, :budget, 
+		:title, :city, :start_date, :time_zone, :days, :budget, :budget_currency, 
 		:vi_location_attributes, :vi_food_attributes, :vi_special_requirements, :vi_medical_conditions,
 		:en_location_attributes, :en_food_attributes, :en_special_requirements, :en_medical_conditions,
 		:status, :reference_id
@@ -60,7 +61,7 @@ func (repo *TripRepository) CreateCommand(ctx context.Context, trip *entity.Trip
 
 func (repo *TripRepository) GetOneByIDQuery(ctx context.Context, id int64, tx *sqlx.Tx) (*entity.Trip, error) {
 	var trip entity.Trip
-	query := "SELECT * FROM trips WHERE id = ?"
+	query := "SELECT * FROM trips WHERE id = ? AND deleted_at IS NULL"
 	if tx != nil {
 		err := tx.GetContext(ctx, &trip, query, id)
 		if err != nil {
@@ -85,7 +86,7 @@ func (repo *TripRepository) GetOneByIDQuery(ctx context.Context, id int64, tx *s
 
 func (repo *TripRepository) SelectForUpdateById(ctx context.Context, id int64, tx *sqlx.Tx) (*entity.Trip, error) {
 	var trip entity.Trip
-	query := "SELECT * FROM trips WHERE id = ? FOR UPDATE"
+	query := "SELECT * FROM trips WHERE id = ? AND deleted_at IS NULL FOR UPDATE"
 	if tx == nil {
 		return nil, errors.New("must use transactions")
 	}
@@ -105,7 +106,7 @@ func (repo *TripRepository) GetAllByUserIDQuery(ctx context.Context, userId int6
 	query := `
 		SELECT t.* FROM trips t
 		JOIN trip_members tm ON t.id = tm.trip_id
-		WHERE tm.user_id = ? AND tm.deleted_at IS NULL
+		WHERE tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NULL
 	`
 	if tx != nil {
 		err := tx.SelectContext(ctx, &trips, query, userId)
@@ -115,19 +116,77 @@ func (repo *TripRepository) GetAllByUserIDQuery(ctx context.Context, userId int6
 	return trips, err
 }
 
-func (repo *TripRepository) GetAllWithUserRoleByUserIdQuery(ctx context.Context, userId int64, tx *sqlx.Tx) ([]*entity.TripWithRole, error) {
-	var trips []*entity.TripWithRole
-	query := `
+// tripSortColumns are the only columns a trip list may be ordered by
+var tripSortColumns = map[string]bool{
+	"start_date": true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (repo *TripRepository) GetPageWithUserRoleByUserIdQuery(ctx context.Context, userId int64, filter repository.TripListFilter, tx *sqlx.Tx) ([]*entity.TripWithRole, error) {
+	trips := make([]*entity.TripWithRole, 0)
+	sortColumn := "t.start_date"
+	if tripSortColumns[filter.SortColumn] {
+		sortColumn = "t." + filter.SortColumn
+	}
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	var query strings.Builder
+	query.WriteString(`
 		SELECT t.*, tm.role 
 		FROM trips t
 		JOIN trip_members tm ON t.id = tm.trip_id
-		WHERE tm.user_id = ? AND tm.deleted_at IS NULL
-	`
+		WHERE tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NULL`)
+	args := []interface{}{userId}
+	if len(filter.Statuses) > 0 {
+		query.WriteString(" AND t.status IN (?" + strings.Repeat(", ?", len(filter.Statuses)-1) + ")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if len(filter.Roles) > 0 {
+		query.WriteString(" AND tm.role IN (?" + strings.Repeat(", ?", len(filter.Roles)-1) + ")")
+		for _, role := range filter.Roles {
+			args = append(args, role)
+		}
+	}
+	if filter.City != "" {
+		// multi-city trips match on any of their segments
+		query.WriteString(" AND (t.city = ? OR EXISTS (SELECT 1 FROM trip_segments ts WHERE ts.trip_id = t.id AND ts.city = ?))")
+		args = append(args, filter.City, filter.City)
+	}
+	if filter.From != nil {
+		query.WriteString(" AND DATE_ADD(t.start_date, INTERVAL t.days DAY) > ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		query.WriteString(" AND t.start_date < ?")
+		args = append(args, *filter.To)
+	}
+	if filter.Search != "" {
+		query.WriteString(" AND t.title LIKE ?")
+		args = append(args, "%"+likeEscaper.Replace(filter.Search)+"%")
+	}
+	if filter.After != nil {
+		query.WriteString(" AND (" + sortColumn + " " + comparison + " ? OR (" + sortColumn + " = ? AND t.id " + comparison + " ?))")
+		args = append(args, filter.After.SortValue, filter.After.SortValue, filter.After.ID)
+	}
+	query.WriteString(" ORDER BY " + sortColumn + " " + direction + ", t.id " + direction)
+	if filter.Limit > 0 {
+		query.WriteString(" LIMIT ?")
+		args = append(args, filter.Limit)
+	}
+
 	if tx != nil {
-		err := tx.SelectContext(ctx, &trips, query, userId)
+		err := tx.SelectContext(ctx, &trips, query.String(), args...)
 		return trips, err
 	}
-	err := repo.db.SelectContext(ctx, &trips, query, userId)
+	err := repo.db.SelectContext(ctx, &trips, query.String(), args...)
 	return trips, err
 }
 
@@ -137,7 +196,7 @@ func (repo *TripRepository) GetOneWithUserRoleByIDQuery(ctx context.Context, tri
 		SELECT t.*, tm.role 
 		FROM trips t
 		JOIN trip_members tm ON t.id = tm.trip_id
-		WHERE t.id = ? AND tm.user_id = ? AND tm.deleted_at IS NULL
+		WHERE t.id = ? AND tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NULL
 	`
 	if tx != nil {
 		err := tx.GetContext(ctx, &trip, query, tripId, userId)
@@ -163,7 +222,7 @@ func (repo *TripRepository) GetOneWithUserRoleByIDQuery(ctx context.Context, tri
 
 func (repo *TripRepository) SelectForShareById(ctx context.Context, id int64, tx *sqlx.Tx) (*entity.Trip, error) {
 	var trip entity.Trip
-	query := "SELECT * FROM trips WHERE id = ? FOR SHARE"
+	query := "SELECT * FROM trips WHERE id = ? AND deleted_at IS NULL FOR SHARE"
 	if tx == nil {
 		return nil, errors.New("must use transactions")
 	}
@@ -177,8 +236,10 @@ func (repo *TripRepository) UpdateCommand(ctx context.Context, trip *entity.Trip
 			title = :title,
 			city = :city,
 			start_date = :start_date,
+			time_zone = :time_zone,
 			days = :days,
 			budget = :budget,
+			budget_currency = :budget_currency,
 			vi_location_attributes = :vi_location_attributes,
 			vi_food_attributes = :vi_food_attributes,
 			vi_special_requirements = :vi_special_requirements,
@@ -188,6 +249,7 @@ func (repo *TripRepository) UpdateCommand(ctx context.Context, trip *entity.Trip
 			en_special_requirements = :en_special_requirements,
 			en_medical_conditions = :en_medical_conditions,
 			status = :status,
+			status_before_archive = :status_before_archive,
 			reference_id = :reference_id,
 			updated_at = CURRENT_TIMESTAMP
 		WHERE id = :id
@@ -202,8 +264,9 @@ func (repo *TripRepository) UpdateCommand(ctx context.Context, trip *entity.Trip
 	return err
 }
 
+// DeleteByIDCommand moves the trip to the trash; its items, members and images are kept so it can be restored
 func (repo *TripRepository) DeleteByIDCommand(ctx context.Context, id int64, tx *sqlx.Tx) error {
-	deleteQuery := "DELETE FROM trips WHERE id = ?"
+	deleteQuery := "UPDATE trips SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL"
 	if tx != nil {
 		_, err := tx.ExecContext(ctx, deleteQuery, id)
 		return err
@@ -212,35 +275,127 @@ func (repo *TripRepository) DeleteByIDCommand(ctx context.Context, id int64, tx
 	return err
 }
 
-func (repo *TripRepository) GetAllNotStartedByStartDateQuery(ctx context.Context, today time.Time, tx *sqlx.Tx) ([]*entity.Trip, error) {
+func (repo *TripRepository) RestoreByIDCommand(ctx context.Context, id int64, tx *sqlx.Tx) error {
+	query := "UPDATE trips SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NOT NULL"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, id)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// PurgeByIDCommand deletes a trashed trip for good, together with everything that belongs to it
+func (repo *TripRepository) PurgeByIDCommand(ctx context.Context, id int64, tx *sqlx.Tx) error {
+	query := "DELETE FROM trips WHERE id = ? AND deleted_at IS NOT NULL"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, id)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// GetAllDeletedWithUserRoleByUserIdQuery returns the trashed trips of the user deleted after the given time, latest first
+func (repo *TripRepository) GetAllDeletedWithUserRoleByUserIdQuery(ctx context.Context, userId int64, deletedAfter time.Time, tx *sqlx.Tx) ([]*entity.TripWithRole, error) {
+	trips := make([]*entity.TripWithRole, 0)
+	query := `
+		SELECT t.*, tm.role 
+		FROM trips t
+		JOIN trip_members tm ON t.id = tm.trip_id
+		WHERE tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at > ?
+		ORDER BY t.deleted_at DESC
+	`
+	if tx != nil {
+		err := tx.SelectContext(ctx, &trips, query, userId, deletedAfter)
+		return trips, err
+	}
+	err := repo.db.SelectContext(ctx, &trips, query, userId, deletedAfter)
+	return trips, err
+}
+
+func (repo *TripRepository) GetOneDeletedWithUserRoleByIDQuery(ctx context.Context, tripId int64, userId int64, tx *sqlx.Tx) (*entity.TripWithRole, error) {
+	var trip entity.TripWithRole
+	query := `
+		SELECT t.*, tm.role 
+		FROM trips t
+		JOIN trip_members tm ON t.id = tm.trip_id
+		WHERE t.id = ? AND tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NOT NULL
+	`
+	var err error
+	if tx != nil {
+		err = tx.GetContext(ctx, &trip, query, tripId, userId)
+	} else {
+		err = repo.db.GetContext(ctx, &trip, query, tripId, userId)
+	}
+	if err != nil {
+		if err.Error() == error_utils.SystemErrorMessage.SqlxNoRow {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &trip, nil
+}
+
+func (repo *TripRepository) GetAllDeletedBeforeQuery(ctx context.Context, before time.Time, tx *sqlx.Tx) ([]*entity.Trip, error) {
+	var trips []*entity.Trip
+	query := "SELECT * FROM trips WHERE deleted_at IS NOT NULL AND deleted_at <= ?"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &trips, query, before)
+		return trips, err
+	}
+	err := repo.db.SelectContext(ctx, &trips, query, before)
+	return trips, err
+}
+
+// candidates only, the caller decides per trip time zone whether the trip has started
+func (repo *TripRepository) GetAllNotStartedStartingBeforeQuery(ctx context.Context, before time.Time, tx *sqlx.Tx) ([]*entity.Trip, error) {
 	var trips []*entity.Trip
-	query := "SELECT * FROM trips WHERE DATE(start_date) = DATE(?) AND status = 'not_started'"
+	query := "SELECT * FROM trips WHERE start_date <= ? AND status = 'not_started' AND deleted_at IS NULL"
 	if tx != nil {
-		err := tx.SelectContext(ctx, &trips, query, today)
+		err := tx.SelectContext(ctx, &trips, query, before)
 		return trips, err
 	}
-	err := repo.db.SelectContext(ctx, &trips, query, today)
+	err := repo.db.SelectContext(ctx, &trips, query, before)
 	return trips, err
 }
 
-func (repo *TripRepository) GetAllInProgressEndedBeforeQuery(ctx context.Context, today time.Time, tx *sqlx.Tx) ([]*entity.Trip, error) {
+// candidates only, the caller decides per trip time zone whether the trip has ended
+func (repo *TripRepository) GetAllInProgressEndingBeforeQuery(ctx context.Context, before time.Time, tx *sqlx.Tx) ([]*entity.Trip, error) {
 	var trips []*entity.Trip
-	query := "SELECT * FROM trips WHERE status = 'in_progress' AND DATE(DATE_ADD(start_date, INTERVAL days-1 DAY)) < DATE(?)"
+	query := "SELECT * FROM trips WHERE status = 'in_progress' AND DATE_ADD(start_date, INTERVAL days DAY) <= ? AND deleted_at IS NULL"
 	if tx != nil {
-		err := tx.SelectContext(ctx, &trips, query, today)
+		err := tx.SelectContext(ctx, &trips, query, before)
 		return trips, err
 	}
-	err := repo.db.SelectContext(ctx, &trips, query, today)
+	err := repo.db.SelectContext(ctx, &trips, query, before)
 	return trips, err
 }
 
-func (repo *TripRepository) GetAllByStartDateQuery(ctx context.Context, date time.Time, tx *sqlx.Tx) ([]*entity.Trip, error) {
+func (repo *TripRepository) GetAllStartingBetweenQuery(ctx context.Context, from time.Time, to time.Time, tx *sqlx.Tx) ([]*entity.Trip, error) {
 	var trips []*entity.Trip
-	query := "SELECT * FROM trips WHERE DATE(start_date) = DATE(?) AND deleted_at IS NULL"
+	query := "SELECT * FROM trips WHERE start_date >= ? AND start_date < ? AND status = 'not_started' AND deleted_at IS NULL"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &trips, query, from, to)
+		return trips, err
+	}
+	err := repo.db.SelectContext(ctx, &trips, query, from, to)
+	return trips, err
+}
+
+func (repo *TripRepository) GetChangedWithUserRoleByUserIdQuery(ctx context.Context, userId int64, since time.Time, tx *sqlx.Tx) ([]*entity.TripWithRole, error) {
+	trips := make([]*entity.TripWithRole, 0)
+	query := `
+		SELECT t.*, tm.role 
+		FROM trips t
+		JOIN trip_members tm ON t.id = tm.trip_id
+		WHERE tm.user_id = ? AND tm.deleted_at IS NULL AND t.deleted_at IS NULL
+			AND (t.updated_at >= ? OR tm.updated_at >= ?)
+	`
 	if tx != nil {
-		err := tx.SelectContext(ctx, &trips, query, date)
+		err := tx.SelectContext(ctx, &trips, query, userId, since, since)
 		return trips, err
 	}
-	err := repo.db.SelectContext(ctx, &trips, query, date)
+	err := repo.db.SelectContext(ctx, &trips, query, userId, since, since)
 	return trips, err
 }
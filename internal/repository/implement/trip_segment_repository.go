@@ -0,0 +1,71 @@
+package repositoryimplement
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+)
+
+type TripSegmentRepository struct {
+	db *sqlx.DB
+}
+
+func NewTripSegmentRepository(db database.Db) repository.TripSegmentRepository {
+	return &TripSegmentRepository{db: db}
+}
+
+func (repo *TripSegmentRepository) CreateCommand(ctx context.Context, tripSegment *entity.TripSegment, tx *sqlx.Tx) error {
+	insertQuery := `
+	INSERT INTO trip_segments(
+		trip_id, segment_order, city, start_day, days,
+		vi_location_attributes, vi_food_attributes, en_location_attributes, en_food_attributes,
+		reference_id
+	) 
+	VALUES (
+		:trip_id, :segment_order, :city, :start_day, :days,
+		:vi_location_attributes, :vi_food_attributes, :en_location_attributes, :en_food_attributes,
+		:reference_id
+	)
+	`
+	if tx != nil {
+		_, err := tx.NamedExecContext(ctx, insertQuery, tripSegment)
+		return err
+	}
+
+	_, err := repo.db.NamedExecContext(ctx, insertQuery, tripSegment)
+	return err
+}
+
+func (repo *TripSegmentRepository) GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripSegment, error) {
+	var tripSegments []entity.TripSegment
+	query := "SELECT * FROM trip_segments WHERE trip_id = ? ORDER BY segment_order"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &tripSegments, query, tripID)
+		return tripSegments, err
+	}
+	err := repo.db.SelectContext(ctx, &tripSegments, query, tripID)
+	return tripSegments, err
+}
+
+func (repo *TripSegmentRepository) UpdateReferenceIDCommand(ctx context.Context, tripID int64, segmentOrder int, referenceID string, tx *sqlx.Tx) error {
+	query := "UPDATE trip_segments SET reference_id = ? WHERE trip_id = ? AND segment_order = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, referenceID, tripID, segmentOrder)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, referenceID, tripID, segmentOrder)
+	return err
+}
+
+func (repo *TripSegmentRepository) DeleteByTripIDCommand(ctx context.Context, tripID int64, tx *sqlx.Tx) error {
+	query := "DELETE FROM trip_segments WHERE trip_id = ?"
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, tripID)
+		return err
+	}
+	_, err := repo.db.ExecContext(ctx, query, tripID)
+	return err
+}
@@ -0,0 +1,47 @@
+package repositoryimplement
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/database"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+)
+
+type TripSettlementRepository struct {
+	db *sqlx.DB
+}
+
+func NewTripSettlementRepository(db database.Db) repository.TripSettlementRepository {
+	return &TripSettlementRepository{db: db}
+}
+
+func (repo *TripSettlementRepository) CreateCommand(ctx context.Context, settlement *entity.TripSettlement, tx *sqlx.Tx) error {
+	insertQuery := `
+	INSERT INTO trip_settlements(
+		trip_id, from_user_id, to_user_id, created_by, amount, currency
+	) 
+	VALUES (
+		:trip_id, :from_user_id, :to_user_id, :created_by, :amount, :currency
+	)
+	`
+	if tx != nil {
+		_, err := tx.NamedExecContext(ctx, insertQuery, settlement)
+		return err
+	}
+
+	_, err := repo.db.NamedExecContext(ctx, insertQuery, settlement)
+	return err
+}
+
+func (repo *TripSettlementRepository) GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripSettlement, error) {
+	settlements := make([]entity.TripSettlement, 0)
+	query := "SELECT * FROM trip_settlements WHERE trip_id = ? ORDER BY created_at ASC, id ASC"
+	if tx != nil {
+		err := tx.SelectContext(ctx, &settlements, query, tripID)
+		return settlements, err
+	}
+	err := repo.db.SelectContext(ctx, &settlements, query, tripID)
+	return settlements, err
+}
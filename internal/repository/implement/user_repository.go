@@ -114,6 +114,28 @@ func (repo *UserRepository) GetOneByIDQuery(ctx context.Context, id int64, tx *s
 	return &customer, err
 }
 
+// GetAllByIDsQuery returns the users with the given ids in one query, skipping deleted accounts
+func (repo *UserRepository) GetAllByIDsQuery(ctx context.Context, ids []int64, tx *sqlx.Tx) ([]entity.User, error) {
+	var users []entity.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM users WHERE id IN (?) AND users.deleted_at IS NULL", ids)
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil {
+		err = tx.SelectContext(ctx, &users, tx.Rebind(query), args...)
+	} else {
+		err = repo.db.SelectContext(ctx, &users, repo.db.Rebind(query), args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (repo *UserRepository) UpdateNotificationTokenCommand(ctx context.Context, id int64, token *string, tx *sqlx.Tx) error {
 	query := "UPDATE users SET notification_token = ? WHERE id = ?"
 	if tx != nil {
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
@@ -16,4 +17,6 @@ type InvitationTripRepository interface {
 	GetOneByIDQuery(ctx context.Context, id int64, tx *sqlx.Tx) (*entity.InvitationTrip, error)
 	GetOneByReceiverIdAndTripIDQuery(ctx context.Context, userId int64, tripId int64, tx *sqlx.Tx) (*entity.InvitationTrip, error)
 	DeleteByIDCommand(ctx context.Context, id int64, tx *sqlx.Tx) error
+	// GetChangedByUserIdQuery returns the invitations sent or received by the user changed since the given time
+	GetChangedByUserIdQuery(ctx context.Context, userId int64, since time.Time, tx *sqlx.Tx) ([]entity.InvitationTrip, error)
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
@@ -14,4 +15,6 @@ type NotificationRepository interface {
 	GetOneByUserIdAndTypeAndTriggerEntityIDQuery(ctx context.Context, userId int64, typeFilter string, triggerEntityID int64, tx *sqlx.Tx) (*entity.Notification, error)
 	DeleteNotificationCommand(ctx context.Context, notificationID int64, tx *sqlx.Tx) error
 	DeleteTripNotificationCommand(ctx context.Context, receiverId, sender, tripId int64, tx *sqlx.Tx) error
+	GetTripNotificationsQuery(ctx context.Context, receiverId, senderId, tripId int64, tx *sqlx.Tx) ([]*entity.Notification, error)
+	GetChangedByUserIdQuery(ctx context.Context, userID int64, since time.Time, tx *sqlx.Tx) ([]*entity.Notification, error)
 }
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type SyncTombstoneRepository interface {
+	// CurrentTimeQuery returns the database clock, which stamps every updated_at and deleted_at read by a sync
+	CurrentTimeQuery(ctx context.Context, tx *sqlx.Tx) (time.Time, error)
+	CreateCommand(ctx context.Context, tombstone *entity.SyncTombstone, tx *sqlx.Tx) error
+	// CreateForTripMemberCommand records a membership for the other members, and the whole trip for the member, to be called right before it is deleted
+	CreateForTripMemberCommand(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) error
+	// CreateForTripMembersCommand records the whole trip for each of its members, to be called right before the trip is purged
+	CreateForTripMembersCommand(ctx context.Context, tripID int64, tx *sqlx.Tx) error
+	GetAllForUserSinceQuery(ctx context.Context, userID int64, since time.Time, tx *sqlx.Tx) ([]entity.SyncTombstone, error)
+	// GetLastDeletedAtQuery returns when a row of the given type was last deleted from the trip, nil when never
+	GetLastDeletedAtQuery(ctx context.Context, entityType string, tripID int64, tx *sqlx.Tx) (*time.Time, error)
+	DeleteBeforeCommand(ctx context.Context, before time.Time, tx *sqlx.Tx) error
+}
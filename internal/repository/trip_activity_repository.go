@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type TripActivityRepository interface {
+	CreateCommand(ctx context.Context, activity *entity.TripActivity, tx *sqlx.Tx) error
+	// GetPageByTripIDQuery returns up to limit activities older than beforeID (all when nil), newest first
+	GetPageByTripIDQuery(ctx context.Context, tripID int64, beforeID *int64, limit int, tx *sqlx.Tx) ([]entity.TripActivityWithActor, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type TripBookingRepository interface {
+	CreateCommand(ctx context.Context, booking *entity.TripBooking, tx *sqlx.Tx) (int64, error)
+	GetOneByIDQuery(ctx context.Context, tripID int64, bookingID int64, tx *sqlx.Tx) (*entity.TripBooking, error)
+	GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripBooking, error)
+	UpdateCommand(ctx context.Context, booking *entity.TripBooking, tx *sqlx.Tx) error
+	DeleteByIDCommand(ctx context.Context, bookingID int64, tx *sqlx.Tx) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type TripChecklistRepository interface {
+	CreateCommand(ctx context.Context, checklist *entity.TripChecklist, tx *sqlx.Tx) (int64, error)
+	GetOneByIDQuery(ctx context.Context, tripID int64, checklistID int64, tx *sqlx.Tx) (*entity.TripChecklist, error)
+	GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripChecklist, error)
+	DeleteByIDCommand(ctx context.Context, checklistID int64, tx *sqlx.Tx) error
+	CreateItemCommand(ctx context.Context, item *entity.TripChecklistItem, tx *sqlx.Tx) (int64, error)
+	GetOneItemByIDQuery(ctx context.Context, checklistID int64, itemID int64, tx *sqlx.Tx) (*entity.TripChecklistItem, error)
+	GetAllItemsByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripChecklistItem, error)
+	UpdateItemCommand(ctx context.Context, item *entity.TripChecklistItem, tx *sqlx.Tx) error
+	DeleteItemByIDCommand(ctx context.Context, itemID int64, tx *sqlx.Tx) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type TripCommentRepository interface {
+	CreateCommand(ctx context.Context, comment *entity.TripComment, tx *sqlx.Tx) (int64, error)
+	GetOneByIDQuery(ctx context.Context, tripID int64, commentID int64, tx *sqlx.Tx) (*entity.TripComment, error)
+	// GetAllByTargetQuery also returns deleted comments so that their replies keep a place in the thread
+	GetAllByTargetQuery(ctx context.Context, tripID int64, tripItemID *int64, tx *sqlx.Tx) ([]entity.TripComment, error)
+	UpdateContentCommand(ctx context.Context, commentID int64, content string, tx *sqlx.Tx) error
+	DeleteByIDCommand(ctx context.Context, commentID int64, tx *sqlx.Tx) error
+}
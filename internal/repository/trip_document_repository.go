@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type TripDocumentRepository interface {
+	CreateCommand(ctx context.Context, document *entity.TripDocument, tx *sqlx.Tx) (int64, error)
+	GetOneByIDQuery(ctx context.Context, tripID int64, documentID int64, tx *sqlx.Tx) (*entity.TripDocument, error)
+	GetAllVisibleByTripIDQuery(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) ([]entity.TripDocument, error)
+	GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripDocument, error)
+	DeleteByIDCommand(ctx context.Context, documentID int64, tx *sqlx.Tx) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type TripExpenseRepository interface {
+	CreateCommand(ctx context.Context, expense *entity.TripExpense, tx *sqlx.Tx) (int64, error)
+	CreateSplitCommand(ctx context.Context, split *entity.TripExpenseSplit, tx *sqlx.Tx) error
+	GetOneByIDQuery(ctx context.Context, tripID int64, expenseID int64, tx *sqlx.Tx) (*entity.TripExpense, error)
+	GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripExpense, error)
+	GetAllSplitsByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripExpenseSplit, error)
+	DeleteByIDCommand(ctx context.Context, expenseID int64, tx *sqlx.Tx) error
+}
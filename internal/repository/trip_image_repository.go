@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
@@ -9,8 +10,11 @@ import (
 
 type TripImageRepository interface {
 	CreateCommand(ctx context.Context, tripImage *entity.TripImage, tx *sqlx.Tx) error
+	GetOneByIDQuery(ctx context.Context, tripID int64, id int64, tx *sqlx.Tx) (*entity.TripImage, error)
 	GetAllQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripImage, error)
 	GetAllWithUserInfoQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripImageWithUserInfo, error)
 	DeleteOneByIDCommand(ctx context.Context, id int64, tx *sqlx.Tx) error
 	GetAllByTripIDAndTripItemIDQuery(ctx context.Context, tripID int64, tripItemID int64, tx *sqlx.Tx) ([]entity.TripImage, error)
+	// GetChangedByUserIdQuery returns the images changed since the given time in the trips of the user, or all images of the trips the user joined since then
+	GetChangedByUserIdQuery(ctx context.Context, userId int64, since time.Time, tx *sqlx.Tx) ([]entity.TripImage, error)
 }
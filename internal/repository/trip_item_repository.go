@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
@@ -9,7 +10,21 @@ import (
 
 type TripItemRepository interface {
 	CreateCommand(ctx context.Context, tripItem *entity.TripItem, tx *sqlx.Tx) error
-	DeleteByTripIDCommand(ctx context.Context, tripID int64, tx *sqlx.Tx) error
+	// UpdateCommand rewrites an item in place, keeping its id and everything linked to it
+	UpdateCommand(ctx context.Context, tripItem *entity.TripItem, tx *sqlx.Tx) error
+	DeleteByIDCommand(ctx context.Context, tripID int64, tripItemID int64, tx *sqlx.Tx) error
+	// ReleasePlaceIDsCommand parks the items on a placeholder place unique to each of them, so the places
+	// they hold can be taken by other items of the same save
+	ReleasePlaceIDsCommand(ctx context.Context, tripID int64, tripItemIDs []int64, tx *sqlx.Tx) error
 	GetTripItemsByTripIDCommand(ctx context.Context, tripID int64, userId int64, tx *sqlx.Tx) ([]entity.TripItem, error)
 	ExistsByTripIDAndTripItemIDCommand(ctx context.Context, tripID int64, tripItemID int64, tx *sqlx.Tx) (bool, error)
+	GetOneByIDQuery(ctx context.Context, tripID int64, tripItemID int64, tx *sqlx.Tx) (*entity.TripItem, error)
+	// ExistsByPlaceIDQuery tells whether any item, of any trip, already uses the place
+	ExistsByPlaceIDQuery(ctx context.Context, placeID string, tx *sqlx.Tx) (bool, error)
+	UpdatePlaceIDCommand(ctx context.Context, tripItemID int64, placeID string, tx *sqlx.Tx) error
+	// ConvertEstimatedCostsCommand multiplies the cost estimates of a trip by the rate, rounded to cents
+	ConvertEstimatedCostsCommand(ctx context.Context, tripID int64, rate float64, tx *sqlx.Tx) error
+	// GetChangedByUserIdQuery returns the items changed since the given time in the trips of the user, or all items of the trips the user joined since then
+	GetChangedByUserIdQuery(ctx context.Context, userId int64, since time.Time, tx *sqlx.Tx) ([]entity.TripItem, error)
+	GetLastUpdatedAtQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) (*time.Time, error)
 }
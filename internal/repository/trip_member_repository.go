@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
@@ -11,6 +12,13 @@ type TripMemberRepository interface {
 	CreateCommand(ctx context.Context, tripMember *entity.TripMember, tx *sqlx.Tx) error
 	IsUserInTripQuery(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) (bool, error)
 	IsUserTripAdminQuery(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) (bool, error)
+	GetMemberRoleQuery(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) (string, error)
 	GetTripMembersQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripMemberWithUser, error)
+	CountByTripIDsQuery(ctx context.Context, tripIDs []int64, tx *sqlx.Tx) (map[int64]int, error)
+	UpdateRoleCommand(ctx context.Context, tripID int64, userID int64, role string, tx *sqlx.Tx) error
 	DeleteMemberCommand(ctx context.Context, tripID int64, userID int64, tx *sqlx.Tx) error
+	// TouchByTripIDCommand marks every membership of the trip as changed, so that sync clients fetch the whole trip again
+	TouchByTripIDCommand(ctx context.Context, tripID int64, tx *sqlx.Tx) error
+	// GetChangedByUserIdQuery returns the members changed since the given time in the trips of the user, or all members of the trips the user joined since then
+	GetChangedByUserIdQuery(ctx context.Context, userId int64, since time.Time, tx *sqlx.Tx) ([]entity.TripMemberWithUser, error)
 }
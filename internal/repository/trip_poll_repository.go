@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type TripPollRepository interface {
+	CreateCommand(ctx context.Context, poll *entity.TripPoll, tx *sqlx.Tx) (int64, error)
+	CreateOptionCommand(ctx context.Context, option *entity.TripPollOption, tx *sqlx.Tx) (int64, error)
+	GetOneByIDQuery(ctx context.Context, tripID int64, pollID int64, tx *sqlx.Tx) (*entity.TripPoll, error)
+	GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripPoll, error)
+	GetAllOpenPastDeadlineQuery(ctx context.Context, now time.Time, tx *sqlx.Tx) ([]entity.TripPoll, error)
+	GetOptionsByPollIDQuery(ctx context.Context, pollID int64, tx *sqlx.Tx) ([]entity.TripPollOption, error)
+	GetOptionsByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripPollOption, error)
+	GetVotesByPollIDQuery(ctx context.Context, pollID int64, tx *sqlx.Tx) ([]entity.TripPollVote, error)
+	GetVotesByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripPollVote, error)
+	ReplaceVotesCommand(ctx context.Context, pollID int64, userID int64, optionIDs []int64, tx *sqlx.Tx) error
+	// CloseCommand only closes a poll that is still open and reports whether it did, so concurrent closes notify once
+	CloseCommand(ctx context.Context, pollID int64, winningOptionID *int64, tx *sqlx.Tx) (bool, error)
+	DeleteByIDCommand(ctx context.Context, pollID int64, tx *sqlx.Tx) error
+}
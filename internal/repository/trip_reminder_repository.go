@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type TripReminderRepository interface {
+	// ClaimCommand marks the reminder as sent for the trip starting on startDate, and reports false when it already was.
+	// A trip moved to another start date is reminded again
+	ClaimCommand(ctx context.Context, tripID int64, reminderType string, startDate time.Time, tx *sqlx.Tx) (bool, error)
+	// ReleaseCommand gives a claim back when the reminder could not be sent, so the next run tries again
+	ReleaseCommand(ctx context.Context, tripID int64, reminderType string, startDate time.Time, tx *sqlx.Tx) error
+}
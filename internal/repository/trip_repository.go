@@ -12,13 +12,43 @@ type TripRepository interface {
 	CreateCommand(ctx context.Context, trip *entity.Trip, tx *sqlx.Tx) (int64, error)
 	GetOneByIDQuery(ctx context.Context, id int64, tx *sqlx.Tx) (*entity.Trip, error)
 	GetAllByUserIDQuery(ctx context.Context, userId int64, tx *sqlx.Tx) ([]*entity.Trip, error)
-	GetAllWithUserRoleByUserIdQuery(ctx context.Context, userId int64, tx *sqlx.Tx) ([]*entity.TripWithRole, error)
+	GetPageWithUserRoleByUserIdQuery(ctx context.Context, userId int64, filter TripListFilter, tx *sqlx.Tx) ([]*entity.TripWithRole, error)
 	GetOneWithUserRoleByIDQuery(ctx context.Context, tripId int64, userId int64, tx *sqlx.Tx) (*entity.TripWithRole, error)
 	SelectForUpdateById(ctx context.Context, id int64, tx *sqlx.Tx) (*entity.Trip, error)
 	SelectForShareById(ctx context.Context, id int64, tx *sqlx.Tx) (*entity.Trip, error)
 	UpdateCommand(ctx context.Context, trip *entity.Trip, tx *sqlx.Tx) error
 	DeleteByIDCommand(ctx context.Context, id int64, tx *sqlx.Tx) error
-	GetAllNotStartedByStartDateQuery(ctx context.Context, today time.Time, tx *sqlx.Tx) ([]*entity.Trip, error)
-	GetAllInProgressEndedBeforeQuery(ctx context.Context, today time.Time, tx *sqlx.Tx) ([]*entity.Trip, error)
-	GetAllByStartDateQuery(ctx context.Context, date time.Time, tx *sqlx.Tx) ([]*entity.Trip, error)
+	RestoreByIDCommand(ctx context.Context, id int64, tx *sqlx.Tx) error
+	PurgeByIDCommand(ctx context.Context, id int64, tx *sqlx.Tx) error
+	GetAllDeletedWithUserRoleByUserIdQuery(ctx context.Context, userId int64, deletedAfter time.Time, tx *sqlx.Tx) ([]*entity.TripWithRole, error)
+	GetOneDeletedWithUserRoleByIDQuery(ctx context.Context, tripId int64, userId int64, tx *sqlx.Tx) (*entity.TripWithRole, error)
+	// GetChangedWithUserRoleByUserIdQuery returns the trips of the user changed since the given time, or all of those the user joined since then
+	GetChangedWithUserRoleByUserIdQuery(ctx context.Context, userId int64, since time.Time, tx *sqlx.Tx) ([]*entity.TripWithRole, error)
+	GetAllDeletedBeforeQuery(ctx context.Context, before time.Time, tx *sqlx.Tx) ([]*entity.Trip, error)
+	GetAllNotStartedStartingBeforeQuery(ctx context.Context, before time.Time, tx *sqlx.Tx) ([]*entity.Trip, error)
+	GetAllInProgressEndingBeforeQuery(ctx context.Context, before time.Time, tx *sqlx.Tx) ([]*entity.Trip, error)
+	GetAllStartingBetweenQuery(ctx context.Context, from time.Time, to time.Time, tx *sqlx.Tx) ([]*entity.Trip, error)
+}
+
+// TripListFilter narrows and orders the trips of a user; empty fields do not filter
+type TripListFilter struct {
+	Statuses []string
+	City     string
+	// From and To keep the trips with at least one day in the range
+	From   *time.Time
+	To     *time.Time
+	Roles  []string
+	Search string
+	// SortColumn is one of start_date, created_at or updated_at
+	SortColumn string
+	Descending bool
+	// After continues the list right after the given trip
+	After *TripListCursor
+	// Limit of 0 returns every matching trip
+	Limit int
+}
+
+type TripListCursor struct {
+	SortValue time.Time
+	ID        int64
 }
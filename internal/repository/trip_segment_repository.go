@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type TripSegmentRepository interface {
+	CreateCommand(ctx context.Context, tripSegment *entity.TripSegment, tx *sqlx.Tx) error
+	GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripSegment, error)
+	UpdateReferenceIDCommand(ctx context.Context, tripID int64, segmentOrder int, referenceID string, tx *sqlx.Tx) error
+	DeleteByTripIDCommand(ctx context.Context, tripID int64, tx *sqlx.Tx) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+type TripSettlementRepository interface {
+	CreateCommand(ctx context.Context, settlement *entity.TripSettlement, tx *sqlx.Tx) error
+	GetAllByTripIDQuery(ctx context.Context, tripID int64, tx *sqlx.Tx) ([]entity.TripSettlement, error)
+}
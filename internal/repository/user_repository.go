@@ -14,6 +14,7 @@ type UserRepository interface {
 	GetIdByEmailQuery(ctx context.Context, email string, tx *sqlx.Tx) (int64, error)
 	UpdatePasswordByIdQuery(ctx context.Context, id int64, password string, tx *sqlx.Tx) error
 	GetOneByIDQuery(ctx context.Context, id int64, tx *sqlx.Tx) (*entity.User, error)
+	GetAllByIDsQuery(ctx context.Context, ids []int64, tx *sqlx.Tx) ([]entity.User, error)
 	UpdateNotificationTokenCommand(ctx context.Context, id int64, token *string, tx *sqlx.Tx) error
 	GetNotificationTokenByIDQuery(ctx context.Context, id int64, tx *sqlx.Tx) (*string, error)
 	UpdateCommand(ctx context.Context, user *entity.User, tx *sqlx.Tx) error
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type ExchangeRateService interface {
+	GetExchangeRates(ctx *gin.Context) ([]model.ExchangeRateResponse, string)
+	ImportExchangeRates(ctx *gin.Context, importRequest model.ExchangeRateImportRequest) (*model.ExchangeRateImportResponse, string)
+	ImportExchangeRatesFile(ctx *gin.Context, fileName string, base string, content []byte) (*model.ExchangeRateImportResponse, string)
+}
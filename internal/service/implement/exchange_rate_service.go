@@ -0,0 +1,115 @@
+package serviceimplement
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	currencyutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/currency_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+type ExchangeRateService struct {
+	exchangeRateRepository repository.ExchangeRateRepository
+	unitOfWork             repository.UnitOfWork
+}
+
+func NewExchangeRateService(
+	exchangeRateRepository repository.ExchangeRateRepository,
+	unitOfWork repository.UnitOfWork,
+) service.ExchangeRateService {
+	return &ExchangeRateService{
+		exchangeRateRepository: exchangeRateRepository,
+		unitOfWork:             unitOfWork,
+	}
+}
+
+func (service *ExchangeRateService) GetExchangeRates(ctx *gin.Context) ([]model.ExchangeRateResponse, string) {
+	rates, err := service.exchangeRateRepository.GetAllQuery(ctx, nil)
+	if err != nil {
+		log.Error("ExchangeRateService.GetExchangeRates GetAllQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	responses := make([]model.ExchangeRateResponse, 0, len(rates))
+	for _, rate := range rates {
+		responses = append(responses, model.ExchangeRateResponse{
+			Currency:    rate.Currency,
+			UnitsPerUSD: rate.UnitsPerUSD,
+			UpdatedAt:   rate.UpdatedAt,
+		})
+	}
+	return responses, ""
+}
+
+func (service *ExchangeRateService) ImportExchangeRates(ctx *gin.Context, importRequest model.ExchangeRateImportRequest) (*model.ExchangeRateImportResponse, string) {
+	if importRequest.Base == "" {
+		importRequest.Base = currencyutils.PivotCurrency
+	}
+	return service.importRates(ctx, importRequest.Base, importRequest.Rates)
+}
+
+func (service *ExchangeRateService) ImportExchangeRatesFile(ctx *gin.Context, fileName string, base string, content []byte) (*model.ExchangeRateImportResponse, string) {
+	var rates map[string]float64
+	var err error
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		var fileBase string
+		fileBase, rates, err = currencyutils.ParseJSON(content)
+		if base == "" {
+			base = fileBase
+		}
+	case ".csv":
+		rates, err = currencyutils.ParseCSV(content)
+	default:
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	if err != nil {
+		log.Info("ExchangeRateService.ImportExchangeRatesFile parse error: " + err.Error())
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	if base == "" {
+		base = currencyutils.PivotCurrency
+	}
+	return service.importRates(ctx, base, rates)
+}
+
+func (service *ExchangeRateService) importRates(ctx *gin.Context, base string, rates map[string]float64) (*model.ExchangeRateImportResponse, string) {
+	unitsPerUSD, err := currencyutils.Rebase(base, rates)
+	if err != nil {
+		log.Info("ExchangeRateService.importRates Rebase error: " + err.Error())
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("ExchangeRateService.importRates Begin error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	for currency, rate := range unitsPerUSD {
+		err = service.exchangeRateRepository.UpsertCommand(ctx, &entity.ExchangeRate{
+			Currency:    currency,
+			UnitsPerUSD: rate,
+		}, tx)
+		if err != nil {
+			log.Error("ExchangeRateService.importRates UpsertCommand error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+	}
+
+	err = service.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("ExchangeRateService.importRates Commit error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	return &model.ExchangeRateImportResponse{Imported: len(unitsPerUSD)}, ""
+}
@@ -13,21 +13,23 @@ import (
 )
 
 type ExpoNotificationService struct {
-	expoClient             *expo.PushClient
-	notificationRepository repository.NotificationRepository
-	userRepository         repository.UserRepository
+	expoClient              *expo.PushClient
+	notificationRepository  repository.NotificationRepository
+	userRepository          repository.UserRepository
+	syncTombstoneRepository repository.SyncTombstoneRepository
 }
 
 // NewNotificationService creates a new instance of NotificationService
-func NewExpoNotificationService(notificationRepository repository.NotificationRepository, userRepository repository.UserRepository) service.NotificationService {
+func NewExpoNotificationService(notificationRepository repository.NotificationRepository, userRepository repository.UserRepository, syncTombstoneRepository repository.SyncTombstoneRepository) service.NotificationService {
 	expoConfig := expo.ClientConfig{
 		AccessToken: os.Getenv("NOTIFICATION_ACCESS_TOKEN"),
 	}
 	expoClient := expo.NewPushClient(&expoConfig)
 	return &ExpoNotificationService{
-		expoClient:             expoClient,
-		notificationRepository: notificationRepository,
-		userRepository:         userRepository,
+		expoClient:              expoClient,
+		notificationRepository:  notificationRepository,
+		userRepository:          userRepository,
+		syncTombstoneRepository: syncTombstoneRepository,
 	}
 }
 
@@ -86,6 +88,33 @@ func (n *ExpoNotificationService) GeneratePushNotification(pushToken expo.Expone
 	case entity.NotificationType.TripStartingSoon:
 		title = "Trip Starting Soon"
 		body = "Your trip will begin in 3 days"
+	case entity.NotificationType.ChecklistItemsPending:
+		title = "Checklist Not Done Yet"
+		body = "Your trip begins in 3 days and some checklist items are still open"
+	case entity.NotificationType.TripCommentMention:
+		title = "You Were Mentioned"
+		body = notification.TriggerEntityName + " mentioned you in a trip comment"
+	case entity.NotificationType.TripPollOpened:
+		title = "New Trip Poll"
+		body = notification.TriggerEntityName + " started a poll on your trip. Cast your vote"
+	case entity.NotificationType.TripPollClosed:
+		title = "Trip Poll Closed"
+		body = "A poll on your trip has closed. See which option won"
+	case entity.NotificationType.TripMemberLeft:
+		title = "Member Left Trip"
+		body = notification.TriggerEntityName + " has left your trip"
+	case entity.NotificationType.TripOwnershipTransferred:
+		title = "Trip Ownership Changed"
+		body = notification.TriggerEntityName + " handed over ownership of your trip"
+	case entity.NotificationType.TripCancelled:
+		title = "Trip Cancelled"
+		body = notification.TriggerEntityName + " cancelled your trip"
+	case entity.NotificationType.TripArchived:
+		title = "Trip Archived"
+		body = notification.TriggerEntityName + " archived your trip"
+	case entity.NotificationType.TripUnarchived:
+		title = "Trip Restored From Archive"
+		body = notification.TriggerEntityName + " moved your trip out of the archive"
 	}
 
 	return &expo.PushMessage{
@@ -210,16 +239,43 @@ func (n *ExpoNotificationService) DeleteFriendInvitation(ctx *gin.Context, userI
 		log.Error("ExpoNotificationService.DeleteFriendInvitation err: ", err)
 		return err.Error()
 	}
+	err = n.recordNotificationTombstone(ctx, notification)
+	if err != nil {
+		log.Error("ExpoNotificationService.DeleteFriendInvitation err: ", err)
+		return err.Error()
+	}
 
 	return ""
 }
 
 func (n *ExpoNotificationService) DeleteTripInvitation(ctx *gin.Context, userId int64, tripId int64, triggerEntityID int64) string {
-	err := n.notificationRepository.DeleteTripNotificationCommand(ctx, userId, triggerEntityID, tripId, nil)
+	notifications, err := n.notificationRepository.GetTripNotificationsQuery(ctx, userId, triggerEntityID, tripId, nil)
+	if err != nil {
+		log.Error("ExpoNotificationService.DeleteTripInvitation err: ", err)
+		return err.Error()
+	}
+
+	err = n.notificationRepository.DeleteTripNotificationCommand(ctx, userId, triggerEntityID, tripId, nil)
 	if err != nil {
 		log.Error("ExpoNotificationService.DeleteFriendInvitation err: ", err)
 		return err.Error()
 	}
 
+	for _, notification := range notifications {
+		err = n.recordNotificationTombstone(ctx, notification)
+		if err != nil {
+			log.Error("ExpoNotificationService.DeleteTripInvitation err: ", err)
+			return err.Error()
+		}
+	}
+
 	return ""
 }
+
+func (n *ExpoNotificationService) recordNotificationTombstone(ctx *gin.Context, notification *entity.Notification) error {
+	return n.syncTombstoneRepository.CreateCommand(ctx, &entity.SyncTombstone{
+		EntityType: entity.SyncEntityType.Notification,
+		EntityID:   notification.ID,
+		UserID:     &notification.UserID,
+	}, nil)
+}
@@ -1,21 +1,28 @@
 package serviceimplement
 
 import (
+	"context"
+
 	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
 )
 
 type InvitationTripService struct {
-	invitationTripRepo  repository.InvitationTripRepository
-	tripRepo            repository.TripRepository
-	tripMemberRepo      repository.TripMemberRepository
-	unitOfWork          repository.UnitOfWork
-	notificationService service.NotificationService
+	invitationTripRepo      repository.InvitationTripRepository
+	tripRepo                repository.TripRepository
+	tripMemberRepo          repository.TripMemberRepository
+	unitOfWork              repository.UnitOfWork
+	notificationService     service.NotificationService
+	tripRealtimeService     service.TripRealtimeService
+	tripActivityRepository  repository.TripActivityRepository
+	syncTombstoneRepository repository.SyncTombstoneRepository
 }
 
 func NewInvitationTripService(
@@ -24,13 +31,19 @@ func NewInvitationTripService(
 	tripMemberRepo repository.TripMemberRepository,
 	unitOfWork repository.UnitOfWork,
 	notificationService service.NotificationService,
+	tripRealtimeService service.TripRealtimeService,
+	tripActivityRepository repository.TripActivityRepository,
+	syncTombstoneRepository repository.SyncTombstoneRepository,
 ) service.InvitationTripService {
 	return &InvitationTripService{
-		invitationTripRepo:  invitationTripRepo,
-		tripRepo:            tripRepo,
-		tripMemberRepo:      tripMemberRepo,
-		unitOfWork:          unitOfWork,
-		notificationService: notificationService,
+		invitationTripRepo:      invitationTripRepo,
+		tripRepo:                tripRepo,
+		tripMemberRepo:          tripMemberRepo,
+		unitOfWork:              unitOfWork,
+		notificationService:     notificationService,
+		tripRealtimeService:     tripRealtimeService,
+		tripActivityRepository:  tripActivityRepository,
+		syncTombstoneRepository: syncTombstoneRepository,
 	}
 }
 
@@ -46,13 +59,13 @@ func (s *InvitationTripService) SendInvitation(ctx *gin.Context, invitation mode
 	}
 	defer s.unitOfWork.Rollback(tx)
 
-	// Check if sender is a member of the trip
-	isMember, err := s.tripMemberRepo.IsUserInTripQuery(ctx, invitation.TripID, senderId, tx)
+	// Check if sender may invite to the trip
+	senderRole, err := s.tripMemberRepo.GetMemberRoleQuery(ctx, invitation.TripID, senderId, tx)
 	if err != nil {
-		log.Error("InvitationTripService.SendInvitation IsUserInTripQuery error: " + err.Error())
+		log.Error("InvitationTripService.SendInvitation GetMemberRoleQuery error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
-	if !isMember {
+	if !trippermissionutils.CanInvite(senderRole) {
 		return error_utils.ErrorCode.FORBIDDEN
 	}
 
@@ -241,6 +254,11 @@ func (s *InvitationTripService) AcceptInvitation(ctx *gin.Context, invitationId
 		log.Error("InvitationTripService.AcceptInvitation UpdateCommand error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
+	err = recordInvitationTombstones(ctx, s.syncTombstoneRepository, invitation, tx)
+	if err != nil {
+		log.Error("InvitationTripService.AcceptInvitation recordInvitationTombstones error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
 
 	// Add user to trip members
 	tripMember := &entity.TripMember{
@@ -254,6 +272,22 @@ func (s *InvitationTripService) AcceptInvitation(ctx *gin.Context, invitationId
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 
+	err = recordTripActivity(ctx, s.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     invitation.TripID,
+			ActorID:    &userId,
+			Action:     model.TripActivityAction.InvitationAccepted,
+			EntityType: model.TripActivityEntityType.Invitation,
+			EntityID:   &invitationId,
+		},
+		before: invitationActivityValue(invitation.SenderID, invitation.ReceiverID, invitation.Status),
+		after:  invitationActivityValue(invitation.SenderID, invitation.ReceiverID, "accepted"),
+	}, tx)
+	if err != nil {
+		log.Error("InvitationTripService.AcceptInvitation recordTripActivity error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
 	// Commit transaction
 	err = s.unitOfWork.Commit(tx)
 	if err != nil {
@@ -263,6 +297,9 @@ func (s *InvitationTripService) AcceptInvitation(ctx *gin.Context, invitationId
 
 	s.notificationService.DeleteTripInvitation(ctx, invitation.ReceiverID, invitationId, invitation.SenderID)
 
+	member := tripMemberInfo(ctx, s.tripMemberRepo, invitation.TripID, userId)
+	s.tripRealtimeService.PublishEvent(ctx, invitation.TripID, &userId, model.TripEventType.MemberJoined, member)
+
 	return ""
 }
 
@@ -301,6 +338,27 @@ func (s *InvitationTripService) DenyInvitation(ctx *gin.Context, invitationId in
 		log.Error("InvitationTripService.DenyInvitation UpdateCommand error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
+	err = recordInvitationTombstones(ctx, s.syncTombstoneRepository, invitation, tx)
+	if err != nil {
+		log.Error("InvitationTripService.DenyInvitation recordInvitationTombstones error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	err = recordTripActivity(ctx, s.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     invitation.TripID,
+			ActorID:    &userId,
+			Action:     model.TripActivityAction.InvitationDenied,
+			EntityType: model.TripActivityEntityType.Invitation,
+			EntityID:   &invitationId,
+		},
+		before: invitationActivityValue(invitation.SenderID, invitation.ReceiverID, invitation.Status),
+		after:  invitationActivityValue(invitation.SenderID, invitation.ReceiverID, "denied"),
+	}, tx)
+	if err != nil {
+		log.Error("InvitationTripService.DenyInvitation recordTripActivity error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
 
 	// Commit transaction
 	err = s.unitOfWork.Commit(tx)
@@ -349,6 +407,11 @@ func (s *InvitationTripService) WithdrawInvitation(ctx *gin.Context, invitationI
 		log.Error("InvitationTripService.WithdrawInvitation UpdateCommand error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
+	err = recordInvitationTombstones(ctx, s.syncTombstoneRepository, invitation, tx)
+	if err != nil {
+		log.Error("InvitationTripService.WithdrawInvitation recordInvitationTombstones error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
 
 	// Commit transaction
 	err = s.unitOfWork.Commit(tx)
@@ -361,3 +424,26 @@ func (s *InvitationTripService) WithdrawInvitation(ctx *gin.Context, invitationI
 
 	return ""
 }
+
+func invitationActivityValue(senderId int64, receiverId int64, status string) map[string]interface{} {
+	return map[string]interface{}{
+		"senderID":   senderId,
+		"receiverID": receiverId,
+		"status":     status,
+	}
+}
+
+// recordInvitationTombstones tells both the sender and the receiver that the invitation is gone
+func recordInvitationTombstones(ctx context.Context, syncTombstoneRepository repository.SyncTombstoneRepository, invitation *entity.InvitationTrip, tx *sqlx.Tx) error {
+	for _, userID := range []int64{invitation.SenderID, invitation.ReceiverID} {
+		err := syncTombstoneRepository.CreateCommand(ctx, &entity.SyncTombstone{
+			EntityType: entity.SyncEntityType.Invitation,
+			EntityID:   invitation.ID,
+			UserID:     &userID,
+		}, tx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,507 @@
+package serviceimplement
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/constants"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+type SyncService struct {
+	tripRepository           repository.TripRepository
+	tripItemRepository       repository.TripItemRepository
+	tripMemberRepository     repository.TripMemberRepository
+	tripImageRepository      repository.TripImageRepository
+	invitationTripRepository repository.InvitationTripRepository
+	notificationRepository   repository.NotificationRepository
+	syncTombstoneRepository  repository.SyncTombstoneRepository
+	tripService              service.TripService
+	tripItemService          service.TripItemService
+	tripImageService         service.TripImageService
+	invitationTripService    service.InvitationTripService
+	notificationService      service.NotificationService
+}
+
+func NewSyncService(
+	tripRepository repository.TripRepository,
+	tripItemRepository repository.TripItemRepository,
+	tripMemberRepository repository.TripMemberRepository,
+	tripImageRepository repository.TripImageRepository,
+	invitationTripRepository repository.InvitationTripRepository,
+	notificationRepository repository.NotificationRepository,
+	syncTombstoneRepository repository.SyncTombstoneRepository,
+	tripService service.TripService,
+	tripItemService service.TripItemService,
+	tripImageService service.TripImageService,
+	invitationTripService service.InvitationTripService,
+	notificationService service.NotificationService,
+) service.SyncService {
+	return &SyncService{
+		tripRepository:           tripRepository,
+		tripItemRepository:       tripItemRepository,
+		tripMemberRepository:     tripMemberRepository,
+		tripImageRepository:      tripImageRepository,
+		invitationTripRepository: invitationTripRepository,
+		notificationRepository:   notificationRepository,
+		syncTombstoneRepository:  syncTombstoneRepository,
+		tripService:              tripService,
+		tripItemService:          tripItemService,
+		tripImageService:         tripImageService,
+		invitationTripService:    invitationTripService,
+		notificationService:      notificationService,
+	}
+}
+
+// syncCursor is the database time the next sync continues from, opaque to clients
+type syncCursor struct {
+	Since time.Time `json:"since"`
+}
+
+func (s *SyncService) GetChanges(ctx *gin.Context, userId int64, cursor string) (*model.SyncResponse, string) {
+	var since time.Time
+	fullResync := cursor == ""
+	if !fullResync {
+		decoded, ok := decodeSyncCursor(cursor)
+		if !ok {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+		since = decoded.Since
+	}
+
+	// read before the changes so that nothing written meanwhile falls between this sync and the next
+	now, err := s.syncTombstoneRepository.CurrentTimeQuery(ctx, nil)
+	if err != nil {
+		log.Error("SyncService.GetChanges CurrentTimeQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	// deletions older than the retention are forgotten, so the client cannot be caught up with tombstones
+	if !fullResync && since.Before(now.Add(-constants.SYNC_TOMBSTONE_RETENTION)) {
+		fullResync = true
+	}
+	if fullResync {
+		since = time.Time{}
+	}
+
+	nextCursor, err := encodeSyncCursor(syncCursor{Since: now.Add(-constants.SYNC_CURSOR_OVERLAP)})
+	if err != nil {
+		log.Error("SyncService.GetChanges encodeSyncCursor error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	response := &model.SyncResponse{
+		Cursor:     nextCursor,
+		FullResync: fullResync,
+		Tombstones: make([]model.SyncTombstoneResponse, 0),
+	}
+
+	if errCode := s.getChangedTripsHelper(ctx, userId, since, response); errCode != "" {
+		return nil, errCode
+	}
+	if errCode := s.getChangedTripContentHelper(ctx, userId, since, response); errCode != "" {
+		return nil, errCode
+	}
+	if errCode := s.getChangedUserContentHelper(ctx, userId, since, response); errCode != "" {
+		return nil, errCode
+	}
+	if fullResync {
+		return response, ""
+	}
+
+	tombstones, err := s.syncTombstoneRepository.GetAllForUserSinceQuery(ctx, userId, since, nil)
+	if err != nil {
+		log.Error("SyncService.GetChanges GetAllForUserSinceQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	for _, tombstone := range tombstones {
+		response.Tombstones = append(response.Tombstones, model.SyncTombstoneResponse{
+			EntityType: tombstone.EntityType,
+			EntityID:   tombstone.EntityID,
+			TripID:     tombstone.TripID,
+			DeletedAt:  tombstone.DeletedAt,
+		})
+	}
+	// trashed trips still exist and can be restored, but offline clients drop them like deleted ones
+	trashedTrips, err := s.tripRepository.GetAllDeletedWithUserRoleByUserIdQuery(ctx, userId, since, nil)
+	if err != nil {
+		log.Error("SyncService.GetChanges GetAllDeletedWithUserRoleByUserIdQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	for _, trip := range trashedTrips {
+		response.Tombstones = append(response.Tombstones, model.SyncTombstoneResponse{
+			EntityType: entity.SyncEntityType.Trip,
+			EntityID:   trip.ID,
+			DeletedAt:  trip.DeletedAt.Time,
+		})
+	}
+
+	return response, ""
+}
+
+func (s *SyncService) getChangedTripsHelper(ctx *gin.Context, userId int64, since time.Time, response *model.SyncResponse) string {
+	trips, err := s.tripRepository.GetChangedWithUserRoleByUserIdQuery(ctx, userId, since, nil)
+	if err != nil {
+		log.Error("SyncService.getChangedTripsHelper GetChangedWithUserRoleByUserIdQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	tripIDs := make([]int64, len(trips))
+	for i, trip := range trips {
+		tripIDs[i] = trip.ID
+	}
+	memberCounts, err := s.tripMemberRepository.CountByTripIDsQuery(ctx, tripIDs, nil)
+	if err != nil {
+		log.Error("SyncService.getChangedTripsHelper CountByTripIDsQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	response.Trips = make([]model.SyncTripResponse, 0, len(trips))
+	for _, trip := range trips {
+		response.Trips = append(response.Trips, model.SyncTripResponse{
+			TripResponse: model.TripResponse{
+				ID:                    trip.ID,
+				Title:                 trip.Title,
+				City:                  trip.City,
+				StartDate:             trip.StartDate,
+				TimeZone:              trip.TimeZone,
+				Days:                  trip.Days,
+				Budget:                trip.Budget,
+				BudgetCurrency:        trip.BudgetCurrency,
+				ViLocationAttributes:  trip.ViLocationAttributes,
+				ViFoodAttributes:      trip.ViFoodAttributes,
+				ViSpecialRequirements: trip.ViSpecialRequirements,
+				ViMedicalConditions:   trip.ViMedicalConditions,
+				EnLocationAttributes:  trip.EnLocationAttributes,
+				EnFoodAttributes:      trip.EnFoodAttributes,
+				EnSpecialRequirements: trip.EnSpecialRequirements,
+				EnMedicalConditions:   trip.EnMedicalConditions,
+				Status:                trip.Status,
+				Role:                  trip.Role,
+				MemberCount:           memberCounts[trip.ID],
+			},
+			CreatedAt: trip.CreatedAt,
+			UpdatedAt: trip.UpdatedAt,
+		})
+	}
+	return ""
+}
+
+// getChangedTripContentHelper collects the items, members and images of the user's trips
+func (s *SyncService) getChangedTripContentHelper(ctx *gin.Context, userId int64, since time.Time, response *model.SyncResponse) string {
+	items, err := s.tripItemRepository.GetChangedByUserIdQuery(ctx, userId, since, nil)
+	if err != nil {
+		log.Error("SyncService.getChangedTripContentHelper GetChangedByUserIdQuery items error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	response.TripItems = make([]model.SyncTripItemResponse, 0, len(items))
+	for _, item := range items {
+		response.TripItems = append(response.TripItems, model.SyncTripItemResponse{
+			TripItemResponse: model.TripItemResponse{
+				ID:            item.ID,
+				TripID:        item.TripID,
+				PlaceID:       item.PlaceID,
+				TripDay:       item.TripDay,
+				OrderInDay:    item.OrderInDay,
+				TimeInDate:    item.TimeInDate,
+				Note:          item.Note,
+				StartTime:     item.StartTime,
+				EndTime:       item.EndTime,
+				EstimatedCost: item.EstimatedCost,
+				CostCategory:  item.CostCategory,
+			},
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+
+	members, err := s.tripMemberRepository.GetChangedByUserIdQuery(ctx, userId, since, nil)
+	if err != nil {
+		log.Error("SyncService.getChangedTripContentHelper GetChangedByUserIdQuery members error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	response.TripMembers = make([]model.SyncTripMemberResponse, 0, len(members))
+	for _, member := range members {
+		response.TripMembers = append(response.TripMembers, model.SyncTripMemberResponse{
+			TripMemberResponse: model.TripMemberResponse{
+				ID:       member.ID,
+				TripID:   member.TripID,
+				UserID:   member.UserID,
+				Role:     member.Role,
+				Name:     member.Name,
+				PhotoURL: member.PhotoURL,
+			},
+			UpdatedAt: member.UpdatedAt,
+		})
+	}
+
+	images, err := s.tripImageRepository.GetChangedByUserIdQuery(ctx, userId, since, nil)
+	if err != nil {
+		log.Error("SyncService.getChangedTripContentHelper GetChangedByUserIdQuery images error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	response.TripImages = make([]model.SyncTripImageResponse, 0, len(images))
+	for _, image := range images {
+		response.TripImages = append(response.TripImages, model.SyncTripImageResponse{
+			TripImageResponse: model.TripImageResponse{
+				ID:         image.ID,
+				TripID:     image.TripID,
+				TripItemID: image.TripItemID,
+				ImageURL:   image.ImageURL,
+				CreatedAt:  image.CreatedAt,
+			},
+			UserID:    image.UserID,
+			UpdatedAt: image.UpdatedAt,
+		})
+	}
+	return ""
+}
+
+// getChangedUserContentHelper collects the invitations and notifications addressed to or sent by the user
+func (s *SyncService) getChangedUserContentHelper(ctx *gin.Context, userId int64, since time.Time, response *model.SyncResponse) string {
+	invitations, err := s.invitationTripRepository.GetChangedByUserIdQuery(ctx, userId, since, nil)
+	if err != nil {
+		log.Error("SyncService.getChangedUserContentHelper GetChangedByUserIdQuery invitations error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	response.Invitations = make([]model.SyncInvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		response.Invitations = append(response.Invitations, model.SyncInvitationResponse{
+			ID:         invitation.ID,
+			TripID:     invitation.TripID,
+			SenderID:   invitation.SenderID,
+			ReceiverID: invitation.ReceiverID,
+			Status:     invitation.Status,
+			CreatedAt:  invitation.CreatedAt,
+			UpdatedAt:  invitation.UpdatedAt,
+		})
+	}
+
+	notifications, err := s.notificationRepository.GetChangedByUserIdQuery(ctx, userId, since, nil)
+	if err != nil {
+		log.Error("SyncService.getChangedUserContentHelper GetChangedByUserIdQuery notifications error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	response.Notifications = make([]model.SyncNotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		response.Notifications = append(response.Notifications, model.SyncNotificationResponse{
+			NotificationResponse: model.NotificationResponse{
+				ID:        notification.ID,
+				Type:      notification.Type,
+				IsSeen:    notification.IsSeen,
+				CreatedAt: notification.CreatedAt,
+				TriggerEntity: model.NotificationTriggerEntity{
+					Type:   notification.TriggerEntityType,
+					Avatar: notification.TriggerEntityAvatar,
+					Name:   notification.TriggerEntityName,
+					ID:     notification.TriggerEntityID,
+				},
+				ReferenceEntity: model.NotificationReferenceEntity{
+					Type: notification.ReferenceEntityType,
+					ID:   notification.ReferenceEntityID,
+				},
+			},
+			UpdatedAt: notification.UpdatedAt,
+		})
+	}
+	return ""
+}
+
+func encodeSyncCursor(cursor syncCursor) (string, error) {
+	encoded, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+func decodeSyncCursor(value string) (syncCursor, bool) {
+	var cursor syncCursor
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, false
+	}
+	if err := json.Unmarshal(decoded, &cursor); err != nil || cursor.Since.IsZero() {
+		return cursor, false
+	}
+	return cursor, true
+}
+
+// ApplyMutations replays queued offline changes in order; one failing or conflicting change does not stop the others
+func (s *SyncService) ApplyMutations(ctx *gin.Context, userId int64, mutations []model.SyncMutation) *model.SyncMutationResponse {
+	response := &model.SyncMutationResponse{
+		Results: make([]model.SyncMutationResult, 0, len(mutations)),
+	}
+	for _, mutation := range mutations {
+		result := s.applyMutationHelper(ctx, userId, mutation)
+		result.ClientMutationID = mutation.ClientMutationID
+		response.Results = append(response.Results, result)
+	}
+	return response
+}
+
+func (s *SyncService) applyMutationHelper(ctx *gin.Context, userId int64, mutation model.SyncMutation) model.SyncMutationResult {
+	var errCode string
+	switch mutation.Type {
+	case model.SyncMutationType.UpdateTrip:
+		return s.applyTripUpdateHelper(ctx, userId, mutation)
+	case model.SyncMutationType.ReplaceTripItems:
+		return s.applyTripItemsReplaceHelper(ctx, userId, mutation)
+	case model.SyncMutationType.DeleteTripImage:
+		return s.applyTripImageDeleteHelper(ctx, userId, mutation)
+	case model.SyncMutationType.AcceptInvitation:
+		if mutation.EntityID < 1 {
+			return failedSyncMutation(error_utils.ErrorCode.BAD_REQUEST)
+		}
+		errCode = s.invitationTripService.AcceptInvitation(ctx, mutation.EntityID, userId)
+	case model.SyncMutationType.DenyInvitation:
+		if mutation.EntityID < 1 {
+			return failedSyncMutation(error_utils.ErrorCode.BAD_REQUEST)
+		}
+		errCode = s.invitationTripService.DenyInvitation(ctx, mutation.EntityID, userId)
+	case model.SyncMutationType.SeenNotification:
+		if mutation.EntityID < 1 {
+			return failedSyncMutation(error_utils.ErrorCode.BAD_REQUEST)
+		}
+		errCode = s.notificationService.SeenNotification(ctx, userId, mutation.EntityID)
+	default:
+		errCode = error_utils.ErrorCode.BAD_REQUEST
+	}
+	if errCode != "" {
+		return failedSyncMutation(errCode)
+	}
+	return model.SyncMutationResult{Status: model.SyncMutationStatus.Applied}
+}
+
+func (s *SyncService) applyTripUpdateHelper(ctx *gin.Context, userId int64, mutation model.SyncMutation) model.SyncMutationResult {
+	var request model.TripPatchRequest
+	if mutation.TripID < 1 || !decodeSyncPayload(mutation.Payload, &request) {
+		return failedSyncMutation(error_utils.ErrorCode.BAD_REQUEST)
+	}
+
+	trip, err := s.tripRepository.GetOneWithUserRoleByIDQuery(ctx, mutation.TripID, userId, nil)
+	if err != nil {
+		log.Error("SyncService.applyTripUpdateHelper GetOneWithUserRoleByIDQuery error: " + err.Error())
+		return failedSyncMutation(error_utils.ErrorCode.INTERNAL_SERVER_ERROR)
+	}
+	if trip == nil {
+		return failedSyncMutation(error_utils.ErrorCode.TRIP_NOT_FOUND)
+	}
+	if mutation.BaseUpdatedAt != nil && trip.UpdatedAt.After(*mutation.BaseUpdatedAt) {
+		return conflictingSyncMutation(trip.UpdatedAt)
+	}
+
+	if errCode := s.tripService.UpdateTrip(ctx, mutation.TripID, userId, request); errCode != "" {
+		return failedSyncMutation(errCode)
+	}
+	return model.SyncMutationResult{Status: model.SyncMutationStatus.Applied}
+}
+
+func (s *SyncService) applyTripItemsReplaceHelper(ctx *gin.Context, userId int64, mutation model.SyncMutation) model.SyncMutationResult {
+	var requests []model.TripItemRequest
+	if mutation.TripID < 1 || !decodeSyncPayload(mutation.Payload, &requests) {
+		return failedSyncMutation(error_utils.ErrorCode.BAD_REQUEST)
+	}
+
+	isMember, err := s.tripMemberRepository.IsUserInTripQuery(ctx, mutation.TripID, userId, nil)
+	if err != nil {
+		log.Error("SyncService.applyTripItemsReplaceHelper IsUserInTripQuery error: " + err.Error())
+		return failedSyncMutation(error_utils.ErrorCode.INTERNAL_SERVER_ERROR)
+	}
+	if !isMember {
+		return failedSyncMutation(error_utils.ErrorCode.FORBIDDEN)
+	}
+
+	if mutation.BaseUpdatedAt != nil {
+		// the itinerary changed when an item was written or removed
+		lastUpdatedAt, err := s.tripItemRepository.GetLastUpdatedAtQuery(ctx, mutation.TripID, nil)
+		if err != nil {
+			log.Error("SyncService.applyTripItemsReplaceHelper GetLastUpdatedAtQuery error: " + err.Error())
+			return failedSyncMutation(error_utils.ErrorCode.INTERNAL_SERVER_ERROR)
+		}
+		lastDeletedAt, err := s.syncTombstoneRepository.GetLastDeletedAtQuery(ctx, entity.SyncEntityType.TripItem, mutation.TripID, nil)
+		if err != nil {
+			log.Error("SyncService.applyTripItemsReplaceHelper GetLastDeletedAtQuery error: " + err.Error())
+			return failedSyncMutation(error_utils.ErrorCode.INTERNAL_SERVER_ERROR)
+		}
+		for _, changedAt := range []*time.Time{lastUpdatedAt, lastDeletedAt} {
+			if changedAt != nil && changedAt.After(*mutation.BaseUpdatedAt) {
+				return conflictingSyncMutation(*changedAt)
+			}
+		}
+	}
+
+	if issues, errCode := s.tripItemService.CreateTripItems(ctx, userId, mutation.TripID, requests); errCode != "" {
+		result := failedSyncMutation(errCode)
+		result.Issues = issues
+		return result
+	}
+	return model.SyncMutationResult{Status: model.SyncMutationStatus.Applied}
+}
+
+func (s *SyncService) applyTripImageDeleteHelper(ctx *gin.Context, userId int64, mutation model.SyncMutation) model.SyncMutationResult {
+	if mutation.TripID < 1 || mutation.EntityID < 1 {
+		return failedSyncMutation(error_utils.ErrorCode.BAD_REQUEST)
+	}
+
+	isMember, err := s.tripMemberRepository.IsUserInTripQuery(ctx, mutation.TripID, userId, nil)
+	if err != nil {
+		log.Error("SyncService.applyTripImageDeleteHelper IsUserInTripQuery error: " + err.Error())
+		return failedSyncMutation(error_utils.ErrorCode.INTERNAL_SERVER_ERROR)
+	}
+	if !isMember {
+		return failedSyncMutation(error_utils.ErrorCode.FORBIDDEN)
+	}
+	// a replayed delete of an image that is already gone has nothing left to do
+	image, err := s.tripImageRepository.GetOneByIDQuery(ctx, mutation.TripID, mutation.EntityID, nil)
+	if err != nil {
+		log.Error("SyncService.applyTripImageDeleteHelper GetOneByIDQuery error: " + err.Error())
+		return failedSyncMutation(error_utils.ErrorCode.INTERNAL_SERVER_ERROR)
+	}
+	if image == nil {
+		return model.SyncMutationResult{Status: model.SyncMutationStatus.Applied}
+	}
+
+	if errCode := s.tripImageService.DeleteTripImage(ctx, userId, mutation.TripID, mutation.EntityID); errCode != "" {
+		return failedSyncMutation(errCode)
+	}
+	return model.SyncMutationResult{Status: model.SyncMutationStatus.Applied}
+}
+
+// decodeSyncPayload reads a mutation payload and checks it against the binding rules of the matching endpoint
+func decodeSyncPayload(payload json.RawMessage, dest interface{}) bool {
+	if len(payload) == 0 {
+		return false
+	}
+	if err := json.Unmarshal(payload, dest); err != nil {
+		return false
+	}
+	return binding.Validator.ValidateStruct(dest) == nil
+}
+
+func failedSyncMutation(errCode string) model.SyncMutationResult {
+	return model.SyncMutationResult{Status: model.SyncMutationStatus.Failed, ErrorCode: errCode}
+}
+
+func conflictingSyncMutation(serverUpdatedAt time.Time) model.SyncMutationResult {
+	return model.SyncMutationResult{Status: model.SyncMutationStatus.Conflict, ServerUpdatedAt: &serverUpdatedAt}
+}
+
+func (s *SyncService) PurgeTombstones(ctx *gin.Context) error {
+	// measured on the database clock, like the cursors checked against the same retention
+	now, err := s.syncTombstoneRepository.CurrentTimeQuery(ctx, nil)
+	if err != nil {
+		log.Error("SyncService.PurgeTombstones CurrentTimeQuery error: " + err.Error())
+		return err
+	}
+	err = s.syncTombstoneRepository.DeleteBeforeCommand(ctx, now.Add(-constants.SYNC_TOMBSTONE_RETENTION), nil)
+	if err != nil {
+		log.Error("SyncService.PurgeTombstones DeleteBeforeCommand error: " + err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package serviceimplement
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+type TripActivityService struct {
+	tripActivityRepository repository.TripActivityRepository
+	tripRepository         repository.TripRepository
+	tripMemberRepository   repository.TripMemberRepository
+}
+
+func NewTripActivityService(
+	tripActivityRepository repository.TripActivityRepository,
+	tripRepository repository.TripRepository,
+	tripMemberRepository repository.TripMemberRepository,
+) service.TripActivityService {
+	return &TripActivityService{
+		tripActivityRepository: tripActivityRepository,
+		tripRepository:         tripRepository,
+		tripMemberRepository:   tripMemberRepository,
+	}
+}
+
+func (service *TripActivityService) GetActivities(ctx *gin.Context, userId int64, tripId int64, cursor *int64, limit int) (*model.TripActivityPageResponse, string) {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripActivityService.GetActivities GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	isMember, err := service.tripMemberRepository.IsUserInTripQuery(ctx, tripId, userId, nil)
+	if err != nil {
+		log.Error("TripActivityService.GetActivities IsUserInTripQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if !isMember {
+		return nil, error_utils.ErrorCode.FORBIDDEN
+	}
+
+	// one extra row tells whether another page follows
+	activities, err := service.tripActivityRepository.GetPageByTripIDQuery(ctx, tripId, cursor, limit+1, nil)
+	if err != nil {
+		log.Error("TripActivityService.GetActivities GetPageByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	response := &model.TripActivityPageResponse{
+		Activities: make([]model.TripActivityResponse, 0, limit),
+	}
+	if len(activities) > limit {
+		activities = activities[:limit]
+		response.NextCursor = &activities[limit-1].ID
+	}
+	for _, activity := range activities {
+		response.Activities = append(response.Activities, toTripActivityResponse(activity))
+	}
+	return response, ""
+}
+
+func toTripActivityResponse(activity entity.TripActivityWithActor) model.TripActivityResponse {
+	response := model.TripActivityResponse{
+		ID:         activity.ID,
+		Action:     activity.Action,
+		EntityType: activity.EntityType,
+		EntityID:   activity.EntityID,
+		CreatedAt:  activity.CreatedAt,
+	}
+	if activity.ActorID != nil {
+		actor := model.UserInfo{ID: *activity.ActorID, PhotoURL: activity.ActorPhotoURL}
+		if activity.ActorName != nil {
+			actor.Name = *activity.ActorName
+		}
+		response.Actor = &actor
+	}
+	if activity.Before != nil {
+		response.Before = json.RawMessage(*activity.Before)
+	}
+	if activity.After != nil {
+		response.After = json.RawMessage(*activity.After)
+	}
+	return response
+}
+
+// tripActivityRecord is an audit entry whose before and after values are not serialised yet
+type tripActivityRecord struct {
+	activity entity.TripActivity
+	before   interface{}
+	after    interface{}
+}
+
+// recordTripActivity stores an audit entry, normally in the transaction of the change it describes.
+// A nil before or after stays NULL.
+func recordTripActivity(ctx context.Context, tripActivityRepository repository.TripActivityRepository, record tripActivityRecord, tx *sqlx.Tx) error {
+	activity := record.activity
+	var err error
+	if activity.Before, err = encodeActivityValue(record.before); err != nil {
+		return err
+	}
+	if activity.After, err = encodeActivityValue(record.after); err != nil {
+		return err
+	}
+	return tripActivityRepository.CreateCommand(ctx, &activity, tx)
+}
+
+func encodeActivityValue(value interface{}) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	text := string(encoded)
+	return &text, nil
+}
+
+// changedTripFields returns the values of the trip fields that differ, before and after an update
+func changedTripFields(before *entity.Trip, after *entity.Trip) (map[string]interface{}, map[string]interface{}, error) {
+	beforeFields, err := tripFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterFields, err := tripFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changedBefore := make(map[string]interface{})
+	changedAfter := make(map[string]interface{})
+	for _, fields := range []map[string]interface{}{beforeFields, afterFields} {
+		for key := range fields {
+			if key == "updatedAt" || key == "createdAt" {
+				continue
+			}
+			if !reflect.DeepEqual(beforeFields[key], afterFields[key]) {
+				changedBefore[key] = beforeFields[key]
+				changedAfter[key] = afterFields[key]
+			}
+		}
+	}
+	return changedBefore, changedAfter, nil
+}
+
+func tripFields(trip *entity.Trip) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(trip)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	err = json.Unmarshal(encoded, &fields)
+	return fields, err
+}
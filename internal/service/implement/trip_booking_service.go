@@ -0,0 +1,305 @@
+package serviceimplement
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	timezoneutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/timezone_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
+)
+
+type TripBookingService struct {
+	tripBookingRepository repository.TripBookingRepository
+	tripRepository        repository.TripRepository
+	tripMemberRepository  repository.TripMemberRepository
+	tripItemService       service.TripItemService
+}
+
+func NewTripBookingService(
+	tripBookingRepository repository.TripBookingRepository,
+	tripRepository repository.TripRepository,
+	tripMemberRepository repository.TripMemberRepository,
+	tripItemService service.TripItemService,
+) service.TripBookingService {
+	return &TripBookingService{
+		tripBookingRepository: tripBookingRepository,
+		tripRepository:        tripRepository,
+		tripMemberRepository:  tripMemberRepository,
+		tripItemService:       tripItemService,
+	}
+}
+
+func (service *TripBookingService) CreateBooking(ctx *gin.Context, userId int64, tripId int64, bookingRequest model.TripBookingRequest) (*model.TripBookingResponse, string) {
+	if _, errCode := service.getTripIfUserInTrip(ctx, userId, tripId); errCode != "" {
+		return nil, errCode
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanAddBookings, "TripBookingService.CreateBooking"); errCode != "" {
+		return nil, errCode
+	}
+	if bookingRequest.EndAt != nil && bookingRequest.EndAt.Before(bookingRequest.StartAt) {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	booking := &entity.TripBooking{
+		TripID:    tripId,
+		CreatedBy: userId,
+	}
+	applyBookingRequest(booking, bookingRequest)
+
+	bookingId, err := service.tripBookingRepository.CreateCommand(ctx, booking, nil)
+	if err != nil {
+		log.Error("TripBookingService.CreateBooking CreateCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	booking.ID = bookingId
+
+	response := toTripBookingResponse(*booking)
+	return &response, ""
+}
+
+func (service *TripBookingService) GetBookings(ctx *gin.Context, userId int64, tripId int64) ([]model.TripBookingResponse, string) {
+	if _, errCode := service.getTripIfUserInTrip(ctx, userId, tripId); errCode != "" {
+		return nil, errCode
+	}
+
+	bookings, err := service.tripBookingRepository.GetAllByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripBookingService.GetBookings GetAllByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	responses := make([]model.TripBookingResponse, 0, len(bookings))
+	for _, booking := range bookings {
+		responses = append(responses, toTripBookingResponse(booking))
+	}
+	return responses, ""
+}
+
+func (service *TripBookingService) UpdateBooking(ctx *gin.Context, userId int64, tripId int64, bookingId int64, bookingRequest model.TripBookingRequest) (*model.TripBookingResponse, string) {
+	booking, errCode := service.getBookingIfUserCanEdit(ctx, userId, tripId, bookingId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	if bookingRequest.EndAt != nil && bookingRequest.EndAt.Before(bookingRequest.StartAt) {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	applyBookingRequest(booking, bookingRequest)
+	err := service.tripBookingRepository.UpdateCommand(ctx, booking, nil)
+	if err != nil {
+		log.Error("TripBookingService.UpdateBooking UpdateCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	response := toTripBookingResponse(*booking)
+	return &response, ""
+}
+
+func (service *TripBookingService) DeleteBooking(ctx *gin.Context, userId int64, tripId int64, bookingId int64) string {
+	if _, errCode := service.getBookingIfUserCanEdit(ctx, userId, tripId, bookingId); errCode != "" {
+		return errCode
+	}
+
+	err := service.tripBookingRepository.DeleteByIDCommand(ctx, bookingId, nil)
+	if err != nil {
+		log.Error("TripBookingService.DeleteBooking DeleteByIDCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	return ""
+}
+
+func (service *TripBookingService) GetTripTimeline(ctx *gin.Context, userId int64, tripId int64) (*model.TripTimelineResponse, string) {
+	trip, errCode := service.getTripIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	tripItems, errCode := service.tripItemService.GetTripItemsByTripID(ctx, userId, tripId, "")
+	if errCode != "" {
+		return nil, errCode
+	}
+	bookings, err := service.tripBookingRepository.GetAllByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripBookingService.GetTripTimeline GetAllByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	entriesByDay := make(map[int64][]model.TimelineEntry)
+	for day := int64(1); day <= int64(trip.Days); day++ {
+		entriesByDay[day] = make([]model.TimelineEntry, 0)
+	}
+
+	// items go in first in day order so that stops sharing a slot keep their order_in_day after the stable sort
+	sort.SliceStable(tripItems, func(i, j int) bool {
+		if tripItems[i].TripDay != tripItems[j].TripDay {
+			return tripItems[i].TripDay < tripItems[j].TripDay
+		}
+		return tripItems[i].OrderInDay < tripItems[j].OrderInDay
+	})
+	for i := range tripItems {
+		item := tripItems[i]
+		at := timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, item.TripDay)
+		startMinutes := model.TimeInDateStartHour[item.TimeInDate] * 60
+		if item.StartTime != nil {
+			if minutes, err := parseClock(*item.StartTime); err == nil {
+				startMinutes = minutes
+			}
+		}
+		entriesByDay[item.TripDay] = append(entriesByDay[item.TripDay], model.TimelineEntry{
+			Event: model.TimelineEvent.Visit,
+			At:    at.Add(time.Duration(startMinutes) * time.Minute),
+			Item:  &item,
+		})
+	}
+
+	for _, booking := range bookings {
+		response := toTripBookingResponse(booking)
+		startEvent, endEvent := bookingEvents(booking.Type)
+
+		startDay := timezoneutils.TripDayOf(trip.StartDate, trip.TimeZone, booking.StartAt)
+		entriesByDay[startDay] = append(entriesByDay[startDay], model.TimelineEntry{
+			Event:   startEvent,
+			At:      booking.StartAt,
+			Booking: &response,
+		})
+		if booking.EndAt != nil {
+			endDay := timezoneutils.TripDayOf(trip.StartDate, trip.TimeZone, *booking.EndAt)
+			entriesByDay[endDay] = append(entriesByDay[endDay], model.TimelineEntry{
+				Event:   endEvent,
+				At:      *booking.EndAt,
+				Booking: &response,
+			})
+		}
+	}
+
+	location := timezoneutils.Location(trip.TimeZone)
+	days := make([]model.TimelineDay, 0, len(entriesByDay))
+	for day, entries := range entriesByDay {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].At.Before(entries[j].At)
+		})
+		for i := range entries {
+			entries[i].At = entries[i].At.In(location)
+		}
+		days = append(days, model.TimelineDay{
+			TripDay: day,
+			Date:    timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, day),
+			Entries: entries,
+		})
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].TripDay < days[j].TripDay
+	})
+
+	return &model.TripTimelineResponse{Days: days}, ""
+}
+
+func (service *TripBookingService) getTripIfUserInTrip(ctx *gin.Context, userId int64, tripId int64) (*entity.Trip, string) {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripBookingService.getTripIfUserInTrip GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	isMember, err := service.tripMemberRepository.IsUserInTripQuery(ctx, tripId, userId, nil)
+	if err != nil {
+		log.Error("TripBookingService.getTripIfUserInTrip IsUserInTripQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if !isMember {
+		return nil, error_utils.ErrorCode.FORBIDDEN
+	}
+	return trip, ""
+}
+
+func (service *TripBookingService) getBookingIfUserCanEdit(ctx *gin.Context, userId int64, tripId int64, bookingId int64) (*entity.TripBooking, string) {
+	if _, errCode := service.getTripIfUserInTrip(ctx, userId, tripId); errCode != "" {
+		return nil, errCode
+	}
+
+	booking, err := service.tripBookingRepository.GetOneByIDQuery(ctx, tripId, bookingId, nil)
+	if err != nil {
+		log.Error("TripBookingService.getBookingIfUserCanEdit GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if booking == nil {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	// whoever added the booking can change it, as can trip admins
+	canEdit := func(role string) bool { return trippermissionutils.CanEditBooking(role, booking.CreatedBy == userId) }
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, canEdit, "TripBookingService.getBookingIfUserCanEdit"); errCode != "" {
+		return nil, errCode
+	}
+	return booking, ""
+}
+
+func applyBookingRequest(booking *entity.TripBooking, bookingRequest model.TripBookingRequest) {
+	booking.Type = bookingRequest.Type
+	booking.Title = bookingRequest.Title
+	booking.Provider = bookingRequest.Provider
+	booking.ConfirmationNumber = bookingRequest.ConfirmationNumber
+	booking.Address = bookingRequest.Address
+	booking.Lat = nil
+	booking.Long = nil
+	if bookingRequest.Location != nil {
+		booking.Lat = &bookingRequest.Location.Lat
+		booking.Long = &bookingRequest.Location.Long
+	}
+	booking.FromLocation = bookingRequest.FromLocation
+	booking.ToLocation = bookingRequest.ToLocation
+	booking.StartAt = bookingRequest.StartAt
+	booking.EndAt = bookingRequest.EndAt
+	booking.Note = bookingRequest.Note
+	// the column is NOT NULL, so store an empty list rather than a JSON null
+	booking.Attachments = make([]string, 0, len(bookingRequest.Attachments))
+	booking.Attachments = append(booking.Attachments, bookingRequest.Attachments...)
+}
+
+func toTripBookingResponse(booking entity.TripBooking) model.TripBookingResponse {
+	response := model.TripBookingResponse{
+		ID:                 booking.ID,
+		TripID:             booking.TripID,
+		CreatedBy:          booking.CreatedBy,
+		Type:               booking.Type,
+		Title:              booking.Title,
+		Provider:           booking.Provider,
+		ConfirmationNumber: booking.ConfirmationNumber,
+		Address:            booking.Address,
+		FromLocation:       booking.FromLocation,
+		ToLocation:         booking.ToLocation,
+		StartAt:            booking.StartAt,
+		EndAt:              booking.EndAt,
+		Note:               booking.Note,
+		Attachments:        booking.Attachments,
+	}
+	if booking.Lat != nil && booking.Long != nil {
+		response.Location = &model.GeoPoint{Lat: *booking.Lat, Long: *booking.Long}
+	}
+	if response.Attachments == nil {
+		response.Attachments = make([]string, 0)
+	}
+	return response
+}
+
+// bookingEvents names the two ends of a booking on the timeline
+func bookingEvents(bookingType string) (string, string) {
+	switch bookingType {
+	case model.BookingType.Hotel:
+		return model.TimelineEvent.CheckIn, model.TimelineEvent.CheckOut
+	case model.BookingType.CarRental:
+		return model.TimelineEvent.PickUp, model.TimelineEvent.DropOff
+	default:
+		return model.TimelineEvent.Departure, model.TimelineEvent.Arrival
+	}
+}
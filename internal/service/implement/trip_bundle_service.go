@@ -0,0 +1,241 @@
+package serviceimplement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/bean"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	bundleutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/bundle_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/constants"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+)
+
+// bundleDownloadTTL only has to outlive the download the server does right after signing
+const bundleDownloadTTL = time.Minute
+
+type TripBundleService struct {
+	tripService            service.TripService
+	tripItemService        service.TripItemService
+	tripMemberService      service.TripMemberService
+	tripDocumentRepository repository.TripDocumentRepository
+	tripImageRepository    repository.TripImageRepository
+	s3Service              bean.S3Service
+	httpClient             *http.Client
+}
+
+func NewTripBundleService(
+	tripService service.TripService,
+	tripItemService service.TripItemService,
+	tripMemberService service.TripMemberService,
+	tripDocumentRepository repository.TripDocumentRepository,
+	tripImageRepository repository.TripImageRepository,
+	s3Service bean.S3Service,
+) service.TripBundleService {
+	return &TripBundleService{
+		tripService:            tripService,
+		tripItemService:        tripItemService,
+		tripMemberService:      tripMemberService,
+		tripDocumentRepository: tripDocumentRepository,
+		tripImageRepository:    tripImageRepository,
+		s3Service:              s3Service,
+		httpClient:             &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (service *TripBundleService) GetTripBundle(ctx *gin.Context, userId int64, tripId int64, language string, knownHashes []string) ([]byte, string) {
+	// GetTripByID also checks that the user is a member of the trip
+	trip, errCode := service.tripService.GetTripByID(ctx, tripId, userId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	tripItems, errCode := service.tripItemService.GetTripItemsByTripID(ctx, userId, tripId, "")
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	members, errCode := service.tripMemberService.GetTripMembersIfUserInTrip(ctx, tripId, userId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	documents, err := service.tripDocumentRepository.GetAllVisibleByTripIDQuery(ctx, tripId, userId, nil)
+	if err != nil {
+		log.Error("TripBundleService.GetTripBundle GetAllVisibleByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	images, err := service.tripImageRepository.GetAllQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripBundleService.GetTripBundle GetAllQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	manifest := model.TripBundleManifest{
+		TripID:           tripId,
+		Language:         language,
+		GeneratedAt:      time.Now(),
+		Files:            []model.TripBundleFile{},
+		UnresolvedItems:  []int64{},
+		UnavailableFiles: []string{},
+	}
+	for _, tripItem := range tripItems {
+		if tripItem.PlaceInfo == nil {
+			manifest.UnresolvedItems = append(manifest.UnresolvedItems, tripItem.ID)
+		}
+	}
+
+	// binary files are gathered first so the JSON listings can point at the paths that made it into the bundle
+	var binaryFiles []bundleutils.File
+
+	bundleDocuments := make([]model.TripBundleDocument, 0, len(documents))
+	for _, document := range documents {
+		bundleDocument := model.TripBundleDocument{TripDocumentResponse: toTripDocumentResponse(document)}
+		filePath := fmt.Sprintf("documents/%d-%s", document.ID, bundleutils.SafeFileName(document.FileName))
+
+		content, err := service.downloadFile(ctx, document.S3Key)
+		if err != nil {
+			log.Error("TripBundleService.GetTripBundle downloadFile error: " + err.Error())
+			manifest.UnavailableFiles = append(manifest.UnavailableFiles, filePath)
+		} else {
+			bundleDocument.Path = filePath
+			binaryFiles = append(binaryFiles, bundleutils.File{Path: filePath, Content: content})
+		}
+		bundleDocuments = append(bundleDocuments, bundleDocument)
+	}
+
+	bundlePhotos := make([]model.TripBundlePhoto, 0, len(images))
+	for _, image := range images {
+		bundlePhoto := model.TripBundlePhoto{
+			TripImageResponse: model.TripImageResponse{
+				ID:         image.ID,
+				TripID:     image.TripID,
+				TripItemID: image.TripItemID,
+				ImageURL:   image.ImageURL,
+				CreatedAt:  image.CreatedAt,
+			},
+		}
+		filePath := fmt.Sprintf("photos/%d.jpg", image.ID)
+
+		// photos hosted outside the bucket are not fetched, the client still has their URL from photos.json
+		s3Key, ok := service.s3Service.KeyFromURL(image.ImageURL)
+		if !ok {
+			manifest.UnavailableFiles = append(manifest.UnavailableFiles, filePath)
+			bundlePhotos = append(bundlePhotos, bundlePhoto)
+			continue
+		}
+		content, err := service.downloadFile(ctx, s3Key)
+		if err == nil {
+			resizedContent, resized, resizeErr := bundleutils.DownscalePhoto(content, constants.TRIP_BUNDLE_PHOTO_MAX_SIDE)
+			if resizeErr != nil {
+				// formats we cannot decode, such as HEIC from iOS, and oversized canvases are bundled as uploaded
+				log.Info("TripBundleService.GetTripBundle bundling photo " + filePath + " as uploaded: " + resizeErr.Error())
+			}
+			if resizeErr == nil && resized {
+				content = resizedContent
+			} else {
+				filePath = fmt.Sprintf("photos/%d%s", image.ID, strings.ToLower(path.Ext(image.ImageURL)))
+			}
+		}
+		if err != nil {
+			log.Error("TripBundleService.GetTripBundle photo error: " + err.Error())
+			manifest.UnavailableFiles = append(manifest.UnavailableFiles, filePath)
+		} else {
+			bundlePhoto.Path = filePath
+			binaryFiles = append(binaryFiles, bundleutils.File{Path: filePath, Content: content})
+		}
+		bundlePhotos = append(bundlePhotos, bundlePhoto)
+	}
+
+	var files []bundleutils.File
+	for _, listing := range []struct {
+		path  string
+		value any
+	}{
+		{"trip.json", trip},
+		{"items.json", tripItems},
+		{"members.json", members},
+		{"documents.json", bundleDocuments},
+		{"photos.json", bundlePhotos},
+	} {
+		content, err := json.Marshal(listing.value)
+		if err != nil {
+			log.Error("TripBundleService.GetTripBundle Marshal error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		files = append(files, bundleutils.File{Path: listing.path, Content: content})
+	}
+	files = append(files, binaryFiles...)
+
+	known := make(map[string]bool, len(knownHashes))
+	for _, hash := range knownHashes {
+		known[strings.ToLower(hash)] = true
+	}
+
+	// every file stays in the manifest, but the ones the client already has are left out of the archive
+	archiveFiles := make([]bundleutils.File, 0, len(files)+1)
+	for _, file := range files {
+		hash := bundleutils.Hash(file.Content)
+		manifest.Files = append(manifest.Files, model.TripBundleFile{Path: file.Path, SHA256: hash, Size: len(file.Content)})
+		if !known[hash] {
+			archiveFiles = append(archiveFiles, file)
+		}
+	}
+
+	manifestContent, err := json.Marshal(manifest)
+	if err != nil {
+		log.Error("TripBundleService.GetTripBundle Marshal error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	archiveFiles = append(archiveFiles, bundleutils.File{Path: "manifest.json", Content: manifestContent})
+
+	archive, err := bundleutils.WriteArchive(archiveFiles)
+	if err != nil {
+		log.Error("TripBundleService.GetTripBundle WriteArchive error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	return archive, ""
+}
+
+// downloadFile fetches an S3 object through a short-lived signed URL, the same way clients download documents
+func (service *TripBundleService) downloadFile(ctx context.Context, s3Key string) ([]byte, error) {
+	url, err := service.s3Service.GenerateSignedDownloadURL(ctx, s3Key, bundleDownloadTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := service.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+	}
+
+	// one byte past the limit tells an oversized file apart from one exactly at the limit
+	content, err := io.ReadAll(io.LimitReader(resp.Body, constants.TRIP_BUNDLE_MAX_FILE_SIZE+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > constants.TRIP_BUNDLE_MAX_FILE_SIZE {
+		return nil, fmt.Errorf("%s is larger than %d bytes", s3Key, constants.TRIP_BUNDLE_MAX_FILE_SIZE)
+	}
+	return content, nil
+}
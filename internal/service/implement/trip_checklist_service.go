@@ -0,0 +1,463 @@
+package serviceimplement
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	packinglistutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/packing_list_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
+)
+
+const checklistDueDateLayout = "2006-01-02"
+
+type TripChecklistService struct {
+	tripChecklistRepository repository.TripChecklistRepository
+	tripRepository          repository.TripRepository
+	tripMemberRepository    repository.TripMemberRepository
+	tripReminderRepository  repository.TripReminderRepository
+	unitOfWork              repository.UnitOfWork
+	notificationService     service.NotificationService
+}
+
+func NewTripChecklistService(
+	tripChecklistRepository repository.TripChecklistRepository,
+	tripRepository repository.TripRepository,
+	tripMemberRepository repository.TripMemberRepository,
+	unitOfWork repository.UnitOfWork,
+	notificationService service.NotificationService,
+	tripReminderRepository repository.TripReminderRepository,
+) service.TripChecklistService {
+	return &TripChecklistService{
+		tripChecklistRepository: tripChecklistRepository,
+		tripRepository:          tripRepository,
+		tripMemberRepository:    tripMemberRepository,
+		unitOfWork:              unitOfWork,
+		notificationService:     notificationService,
+		tripReminderRepository:  tripReminderRepository,
+	}
+}
+
+func (service *TripChecklistService) CreateChecklist(ctx *gin.Context, userId int64, tripId int64, checklistRequest model.TripChecklistRequest) (*model.TripChecklistResponse, string) {
+	trip, members, errCode := service.getTripAndMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanEditChecklists, "TripChecklistService.CreateChecklist"); errCode != "" {
+		return nil, errCode
+	}
+
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripChecklistService.CreateChecklist Begin error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	checklist := &entity.TripChecklist{
+		TripID:    tripId,
+		CreatedBy: userId,
+		Type:      checklistRequest.Type,
+		Title:     checklistRequest.Title,
+	}
+	checklistId, err := service.tripChecklistRepository.CreateCommand(ctx, checklist, tx)
+	if err != nil {
+		log.Error("TripChecklistService.CreateChecklist CreateCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	checklist.ID = checklistId
+
+	items := make([]entity.TripChecklistItem, 0)
+	if checklistRequest.SeedDefaults && checklistRequest.Type == model.ChecklistType.Packing {
+		lang := ctx.DefaultQuery("language", "vi")
+		titles := packinglistutils.DefaultPackingList(trip.Days, trip.EnSpecialRequirements, trip.EnMedicalConditions, lang)
+		for _, title := range titles {
+			item := entity.TripChecklistItem{
+				ChecklistID: checklistId,
+				TripID:      tripId,
+				CreatedBy:   userId,
+				Title:       title,
+			}
+			item.ID, err = service.tripChecklistRepository.CreateItemCommand(ctx, &item, tx)
+			if err != nil {
+				log.Error("TripChecklistService.CreateChecklist CreateItemCommand error: " + err.Error())
+				return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+			}
+			items = append(items, item)
+		}
+	}
+
+	err = service.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripChecklistService.CreateChecklist Commit error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	response := toTripChecklistResponse(*checklist, items, members)
+	return &response, ""
+}
+
+func (service *TripChecklistService) GetChecklists(ctx *gin.Context, userId int64, tripId int64) ([]model.TripChecklistResponse, string) {
+	_, members, errCode := service.getTripAndMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	checklists, err := service.tripChecklistRepository.GetAllByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripChecklistService.GetChecklists GetAllByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	items, err := service.tripChecklistRepository.GetAllItemsByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripChecklistService.GetChecklists GetAllItemsByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	itemsByChecklist := make(map[int64][]entity.TripChecklistItem)
+	for _, item := range items {
+		itemsByChecklist[item.ChecklistID] = append(itemsByChecklist[item.ChecklistID], item)
+	}
+
+	responses := make([]model.TripChecklistResponse, 0, len(checklists))
+	for _, checklist := range checklists {
+		responses = append(responses, toTripChecklistResponse(checklist, itemsByChecklist[checklist.ID], members))
+	}
+	return responses, ""
+}
+
+func (service *TripChecklistService) DeleteChecklist(ctx *gin.Context, userId int64, tripId int64, checklistId int64) string {
+	checklist, _, errCode := service.getChecklistIfUserInTrip(ctx, userId, tripId, checklistId)
+	if errCode != "" {
+		return errCode
+	}
+
+	if errCode := service.checkCanDelete(ctx, userId, tripId, checklist.CreatedBy); errCode != "" {
+		return errCode
+	}
+
+	err := service.tripChecklistRepository.DeleteByIDCommand(ctx, checklistId, nil)
+	if err != nil {
+		log.Error("TripChecklistService.DeleteChecklist DeleteByIDCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	return ""
+}
+
+func (service *TripChecklistService) CreateChecklistItem(ctx *gin.Context, userId int64, tripId int64, checklistId int64, itemRequest model.TripChecklistItemRequest) (*model.TripChecklistItemResponse, string) {
+	_, members, errCode := service.getChecklistIfUserInTrip(ctx, userId, tripId, checklistId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanEditChecklists, "TripChecklistService.CreateChecklistItem"); errCode != "" {
+		return nil, errCode
+	}
+
+	item := &entity.TripChecklistItem{
+		ChecklistID: checklistId,
+		TripID:      tripId,
+		CreatedBy:   userId,
+	}
+	if errCode := applyChecklistItemRequest(item, itemRequest, members); errCode != "" {
+		return nil, errCode
+	}
+
+	itemId, err := service.tripChecklistRepository.CreateItemCommand(ctx, item, nil)
+	if err != nil {
+		log.Error("TripChecklistService.CreateChecklistItem CreateItemCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	item.ID = itemId
+
+	response := toTripChecklistItemResponse(*item, members)
+	return &response, ""
+}
+
+func (service *TripChecklistService) UpdateChecklistItem(ctx *gin.Context, userId int64, tripId int64, checklistId int64, itemId int64, itemRequest model.TripChecklistItemRequest) (*model.TripChecklistItemResponse, string) {
+	item, members, errCode := service.getChecklistItemIfUserInTrip(ctx, userId, tripId, checklistId, itemId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanEditChecklists, "TripChecklistService.UpdateChecklistItem"); errCode != "" {
+		return nil, errCode
+	}
+
+	if errCode := applyChecklistItemRequest(item, itemRequest, members); errCode != "" {
+		return nil, errCode
+	}
+
+	err := service.tripChecklistRepository.UpdateItemCommand(ctx, item, nil)
+	if err != nil {
+		log.Error("TripChecklistService.UpdateChecklistItem UpdateItemCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	response := toTripChecklistItemResponse(*item, members)
+	return &response, ""
+}
+
+func (service *TripChecklistService) CheckChecklistItem(ctx *gin.Context, userId int64, tripId int64, checklistId int64, itemId int64, checkRequest model.TripChecklistItemCheckRequest) (*model.TripChecklistItemResponse, string) {
+	item, members, errCode := service.getChecklistItemIfUserInTrip(ctx, userId, tripId, checklistId, itemId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanEditChecklists, "TripChecklistService.CheckChecklistItem"); errCode != "" {
+		return nil, errCode
+	}
+
+	// checking an item again keeps whoever checked it first
+	if *checkRequest.IsDone && !item.IsDone {
+		now := time.Now()
+		item.IsDone = true
+		item.DoneBy = &userId
+		item.DoneAt = &now
+	} else if !*checkRequest.IsDone {
+		item.IsDone = false
+		item.DoneBy = nil
+		item.DoneAt = nil
+	}
+
+	err := service.tripChecklistRepository.UpdateItemCommand(ctx, item, nil)
+	if err != nil {
+		log.Error("TripChecklistService.CheckChecklistItem UpdateItemCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	response := toTripChecklistItemResponse(*item, members)
+	return &response, ""
+}
+
+func (service *TripChecklistService) DeleteChecklistItem(ctx *gin.Context, userId int64, tripId int64, checklistId int64, itemId int64) string {
+	item, _, errCode := service.getChecklistItemIfUserInTrip(ctx, userId, tripId, checklistId, itemId)
+	if errCode != "" {
+		return errCode
+	}
+
+	if errCode := service.checkCanDelete(ctx, userId, tripId, item.CreatedBy); errCode != "" {
+		return errCode
+	}
+
+	err := service.tripChecklistRepository.DeleteItemByIDCommand(ctx, itemId, nil)
+	if err != nil {
+		log.Error("TripChecklistService.DeleteChecklistItem DeleteItemByIDCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	return ""
+}
+
+// SendChecklistReminders runs next to SendTripStartReminders and nudges members about undone items.
+// Assigned items remind their assignee, unassigned ones remind the whole trip.
+func (service *TripChecklistService) SendChecklistReminders(ctx *gin.Context) error {
+	now := time.Now()
+	trips, err := service.tripRepository.GetAllStartingBetweenQuery(ctx, now.AddDate(0, 0, -2), now.AddDate(0, 0, 5), nil)
+	if err != nil {
+		log.Error("TripChecklistService.SendChecklistReminders - GetAllStartingBetweenQuery Error: " + err.Error())
+		return err
+	}
+
+	for _, trip := range trips {
+		if !isStartReminderDue(now, trip) {
+			continue
+		}
+		claimed, err := service.tripReminderRepository.ClaimCommand(ctx, trip.ID, entity.TripReminderType.ChecklistPending, trip.StartDate, nil)
+		if err != nil {
+			log.Error("TripChecklistService.SendChecklistReminders - ClaimCommand Error: " + err.Error())
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		// the claim is only kept once someone got the reminder, otherwise the next run tries again
+		items, err := service.tripChecklistRepository.GetAllItemsByTripIDQuery(ctx, trip.ID, nil)
+		if err != nil {
+			log.Error("TripChecklistService.SendChecklistReminders - GetAllItemsByTripIDQuery Error: " + err.Error())
+			service.releaseReminderHelper(ctx, trip)
+			continue
+		}
+		members, err := service.tripMemberRepository.GetTripMembersQuery(ctx, trip.ID, nil)
+		if err != nil {
+			log.Error("TripChecklistService.SendChecklistReminders - GetTripMembersQuery Error: " + err.Error())
+			service.releaseReminderHelper(ctx, trip)
+			continue
+		}
+		isMember := make(map[int64]bool)
+		for _, member := range members {
+			isMember[member.UserID] = true
+		}
+
+		receivers := make(map[int64]bool)
+		for _, item := range items {
+			if item.IsDone {
+				continue
+			}
+			if item.AssigneeID != nil && isMember[*item.AssigneeID] {
+				receivers[*item.AssigneeID] = true
+				continue
+			}
+			for userID := range isMember {
+				receivers[userID] = true
+			}
+		}
+
+		sent := false
+		for userID := range receivers {
+			errCode := service.notificationService.SaveAndSendNotification(ctx, model.SaveNotificationRequest{
+				ReceiverUserID:      userID,
+				TriggerEntityType:   entity.NotificationTriggerType.System,
+				ReferenceEntityType: entity.NotificationReferenceType.Trip,
+				ReferenceEntityID:   &trip.ID,
+				Type:                entity.NotificationType.ChecklistItemsPending,
+			})
+			if errCode != "" {
+				log.Error("TripChecklistService.SendChecklistReminders - SaveAndSendNotification Error: " + errCode)
+				continue
+			}
+			sent = true
+		}
+		if len(receivers) > 0 && !sent {
+			service.releaseReminderHelper(ctx, trip)
+		}
+	}
+	return nil
+}
+
+func (service *TripChecklistService) releaseReminderHelper(ctx *gin.Context, trip *entity.Trip) {
+	err := service.tripReminderRepository.ReleaseCommand(ctx, trip.ID, entity.TripReminderType.ChecklistPending, trip.StartDate, nil)
+	if err != nil {
+		log.Error("TripChecklistService.SendChecklistReminders - ReleaseCommand Error: " + err.Error())
+	}
+}
+
+func (service *TripChecklistService) getTripAndMembersIfUserInTrip(ctx *gin.Context, userId int64, tripId int64) (*entity.Trip, map[int64]string, string) {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripChecklistService.getTripAndMembersIfUserInTrip GetOneByIDQuery error: " + err.Error())
+		return nil, nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	tripMembers, err := service.tripMemberRepository.GetTripMembersQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripChecklistService.getTripAndMembersIfUserInTrip GetTripMembersQuery error: " + err.Error())
+		return nil, nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	members := make(map[int64]string)
+	for _, member := range tripMembers {
+		members[member.UserID] = member.Name
+	}
+	if _, ok := members[userId]; !ok {
+		return nil, nil, error_utils.ErrorCode.FORBIDDEN
+	}
+	return trip, members, ""
+}
+
+func (service *TripChecklistService) getChecklistIfUserInTrip(ctx *gin.Context, userId int64, tripId int64, checklistId int64) (*entity.TripChecklist, map[int64]string, string) {
+	_, members, errCode := service.getTripAndMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, nil, errCode
+	}
+
+	checklist, err := service.tripChecklistRepository.GetOneByIDQuery(ctx, tripId, checklistId, nil)
+	if err != nil {
+		log.Error("TripChecklistService.getChecklistIfUserInTrip GetOneByIDQuery error: " + err.Error())
+		return nil, nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if checklist == nil {
+		return nil, nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	return checklist, members, ""
+}
+
+func (service *TripChecklistService) getChecklistItemIfUserInTrip(ctx *gin.Context, userId int64, tripId int64, checklistId int64, itemId int64) (*entity.TripChecklistItem, map[int64]string, string) {
+	_, members, errCode := service.getChecklistIfUserInTrip(ctx, userId, tripId, checklistId)
+	if errCode != "" {
+		return nil, nil, errCode
+	}
+
+	item, err := service.tripChecklistRepository.GetOneItemByIDQuery(ctx, checklistId, itemId, nil)
+	if err != nil {
+		log.Error("TripChecklistService.getChecklistItemIfUserInTrip GetOneItemByIDQuery error: " + err.Error())
+		return nil, nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if item == nil {
+		return nil, nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	return item, members, ""
+}
+
+// checkCanDelete lets whoever created a checklist or item remove it, as well as trip admins
+func (service *TripChecklistService) checkCanDelete(ctx *gin.Context, userId int64, tripId int64, createdBy int64) string {
+	canDelete := func(role string) bool { return trippermissionutils.CanDeleteChecklistEntry(role, createdBy == userId) }
+	return checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, canDelete, "TripChecklistService.checkCanDelete")
+}
+
+func applyChecklistItemRequest(item *entity.TripChecklistItem, itemRequest model.TripChecklistItemRequest, members map[int64]string) string {
+	if itemRequest.AssigneeID != nil {
+		if _, ok := members[*itemRequest.AssigneeID]; !ok {
+			return error_utils.ErrorCode.BAD_REQUEST
+		}
+	}
+
+	item.DueDate = nil
+	if itemRequest.DueDate != nil {
+		dueDate, err := time.Parse(checklistDueDateLayout, *itemRequest.DueDate)
+		if err != nil {
+			return error_utils.ErrorCode.BAD_REQUEST
+		}
+		item.DueDate = &dueDate
+	}
+	item.Title = itemRequest.Title
+	item.AssigneeID = itemRequest.AssigneeID
+	return ""
+}
+
+func toTripChecklistResponse(checklist entity.TripChecklist, items []entity.TripChecklistItem, members map[int64]string) model.TripChecklistResponse {
+	response := model.TripChecklistResponse{
+		ID:        checklist.ID,
+		TripID:    checklist.TripID,
+		Type:      checklist.Type,
+		Title:     checklist.Title,
+		CreatedBy: checklist.CreatedBy,
+		Items:     make([]model.TripChecklistItemResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		response.Items = append(response.Items, toTripChecklistItemResponse(item, members))
+	}
+	return response
+}
+
+func toTripChecklistItemResponse(item entity.TripChecklistItem, members map[int64]string) model.TripChecklistItemResponse {
+	response := model.TripChecklistItemResponse{
+		ID:          item.ID,
+		ChecklistID: item.ChecklistID,
+		Title:       item.Title,
+		AssigneeID:  item.AssigneeID,
+		IsDone:      item.IsDone,
+		DoneBy:      item.DoneBy,
+		DoneAt:      item.DoneAt,
+		CreatedBy:   item.CreatedBy,
+	}
+	if item.AssigneeID != nil {
+		if name, ok := members[*item.AssigneeID]; ok {
+			response.AssigneeName = &name
+		}
+	}
+	if item.DoneBy != nil {
+		if name, ok := members[*item.DoneBy]; ok {
+			response.DoneByName = &name
+		}
+	}
+	if item.DueDate != nil {
+		dueDate := item.DueDate.Format(checklistDueDateLayout)
+		response.DueDate = &dueDate
+	}
+	return response
+}
@@ -0,0 +1,319 @@
+package serviceimplement
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	mentionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/mention_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
+)
+
+type TripCommentService struct {
+	tripCommentRepository repository.TripCommentRepository
+	tripRepository        repository.TripRepository
+	tripMemberRepository  repository.TripMemberRepository
+	tripItemRepository    repository.TripItemRepository
+	userRepository        repository.UserRepository
+	notificationService   service.NotificationService
+}
+
+func NewTripCommentService(
+	tripCommentRepository repository.TripCommentRepository,
+	tripRepository repository.TripRepository,
+	tripMemberRepository repository.TripMemberRepository,
+	tripItemRepository repository.TripItemRepository,
+	userRepository repository.UserRepository,
+	notificationService service.NotificationService,
+) service.TripCommentService {
+	return &TripCommentService{
+		tripCommentRepository: tripCommentRepository,
+		tripRepository:        tripRepository,
+		tripMemberRepository:  tripMemberRepository,
+		tripItemRepository:    tripItemRepository,
+		userRepository:        userRepository,
+		notificationService:   notificationService,
+	}
+}
+
+func (service *TripCommentService) CreateComment(ctx *gin.Context, userId int64, tripId int64, commentRequest model.TripCommentRequest) (*model.TripCommentResponse, string) {
+	members, errCode := service.getMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanComment, "TripCommentService.CreateComment"); errCode != "" {
+		return nil, errCode
+	}
+
+	if commentRequest.TripItemID != nil {
+		isTripItemExists, err := service.tripItemRepository.ExistsByTripIDAndTripItemIDCommand(ctx, tripId, *commentRequest.TripItemID, nil)
+		if err != nil {
+			log.Error("TripCommentService.CreateComment ExistsByTripIDAndTripItemIDCommand error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		if !isTripItemExists {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+	}
+
+	// a reply must stay in the thread of its parent, on the trip or on the same item
+	if commentRequest.ParentID != nil {
+		parent, err := service.tripCommentRepository.GetOneByIDQuery(ctx, tripId, *commentRequest.ParentID, nil)
+		if err != nil {
+			log.Error("TripCommentService.CreateComment GetOneByIDQuery error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		if parent == nil || !sameTripItem(parent.TripItemID, commentRequest.TripItemID) {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+	}
+
+	comment := &entity.TripComment{
+		TripID:     tripId,
+		TripItemID: commentRequest.TripItemID,
+		ParentID:   commentRequest.ParentID,
+		AuthorID:   userId,
+		Content:    commentRequest.Content,
+		CreatedAt:  time.Now(),
+	}
+	commentId, err := service.tripCommentRepository.CreateCommand(ctx, comment, nil)
+	if err != nil {
+		log.Error("TripCommentService.CreateComment CreateCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	comment.ID = commentId
+
+	service.notifyMentions(ctx, tripId, userId, mentionutils.Find(comment.Content, memberNames(members)), nil)
+
+	response := toTripCommentResponse(*comment, members)
+	return &response, ""
+}
+
+func (service *TripCommentService) GetComments(ctx *gin.Context, userId int64, tripId int64, tripItemId *int64) ([]model.TripCommentResponse, string) {
+	members, errCode := service.getMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	comments, err := service.tripCommentRepository.GetAllByTargetQuery(ctx, tripId, tripItemId, nil)
+	if err != nil {
+		log.Error("TripCommentService.GetComments GetAllByTargetQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	// authors who have since left the trip still need a name next to their comments
+	for _, comment := range comments {
+		if _, ok := members[comment.AuthorID]; ok {
+			continue
+		}
+		user, err := service.userRepository.GetOneByIDQuery(ctx, comment.AuthorID, nil)
+		if err != nil {
+			log.Error("TripCommentService.GetComments GetOneByIDQuery error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		if user != nil {
+			members[comment.AuthorID] = model.UserInfo{ID: user.Id, Name: user.Name, PhotoURL: user.PhotoURL}
+		}
+	}
+
+	return buildCommentThreads(comments, members), ""
+}
+
+func (service *TripCommentService) UpdateComment(ctx *gin.Context, userId int64, tripId int64, commentId int64, commentRequest model.TripCommentUpdateRequest) (*model.TripCommentResponse, string) {
+	members, errCode := service.getMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	comment, err := service.tripCommentRepository.GetOneByIDQuery(ctx, tripId, commentId, nil)
+	if err != nil {
+		log.Error("TripCommentService.UpdateComment GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if comment == nil {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	if comment.AuthorID != userId {
+		return nil, error_utils.ErrorCode.FORBIDDEN
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanComment, "TripCommentService.UpdateComment"); errCode != "" {
+		return nil, errCode
+	}
+
+	err = service.tripCommentRepository.UpdateContentCommand(ctx, commentId, commentRequest.Content, nil)
+	if err != nil {
+		log.Error("TripCommentService.UpdateComment UpdateContentCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	// only members who were not already mentioned hear about the edit
+	names := memberNames(members)
+	previous := mentionutils.Find(comment.Content, names)
+	service.notifyMentions(ctx, tripId, userId, mentionutils.Find(commentRequest.Content, names), previous)
+
+	now := time.Now()
+	comment.Content = commentRequest.Content
+	comment.EditedAt = &now
+	response := toTripCommentResponse(*comment, members)
+	return &response, ""
+}
+
+func (service *TripCommentService) DeleteComment(ctx *gin.Context, userId int64, tripId int64, commentId int64) string {
+	if _, errCode := service.getMembersIfUserInTrip(ctx, userId, tripId); errCode != "" {
+		return errCode
+	}
+
+	comment, err := service.tripCommentRepository.GetOneByIDQuery(ctx, tripId, commentId, nil)
+	if err != nil {
+		log.Error("TripCommentService.DeleteComment GetOneByIDQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if comment == nil {
+		return error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	// authors can remove their own comments, admins can remove anyone's
+	canDelete := func(role string) bool { return trippermissionutils.CanDeleteComment(role, comment.AuthorID == userId) }
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, canDelete, "TripCommentService.DeleteComment"); errCode != "" {
+		return errCode
+	}
+
+	err = service.tripCommentRepository.DeleteByIDCommand(ctx, commentId, nil)
+	if err != nil {
+		log.Error("TripCommentService.DeleteComment DeleteByIDCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	return ""
+}
+
+func (service *TripCommentService) getMembersIfUserInTrip(ctx *gin.Context, userId int64, tripId int64) (map[int64]model.UserInfo, string) {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripCommentService.getMembersIfUserInTrip GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	tripMembers, err := service.tripMemberRepository.GetTripMembersQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripCommentService.getMembersIfUserInTrip GetTripMembersQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	members := make(map[int64]model.UserInfo)
+	for _, member := range tripMembers {
+		members[member.UserID] = model.UserInfo{ID: member.UserID, Name: member.Name, PhotoURL: member.PhotoURL}
+	}
+	if _, ok := members[userId]; !ok {
+		return nil, error_utils.ErrorCode.FORBIDDEN
+	}
+	return members, ""
+}
+
+func (service *TripCommentService) notifyMentions(ctx *gin.Context, tripId int64, authorId int64, mentioned []int64, alreadyMentioned []int64) {
+	skip := map[int64]bool{authorId: true}
+	for _, userID := range alreadyMentioned {
+		skip[userID] = true
+	}
+
+	for _, userID := range mentioned {
+		if skip[userID] {
+			continue
+		}
+		errCode := service.notificationService.SaveAndSendNotification(ctx, model.SaveNotificationRequest{
+			ReceiverUserID:      userID,
+			TriggerEntityType:   entity.NotificationTriggerType.User,
+			TriggerEntityID:     &authorId,
+			ReferenceEntityType: entity.NotificationReferenceType.Trip,
+			ReferenceEntityID:   &tripId,
+			Type:                entity.NotificationType.TripCommentMention,
+		})
+		if errCode != "" {
+			log.Error("TripCommentService.notifyMentions SaveAndSendNotification error: " + errCode)
+		}
+	}
+}
+
+// buildCommentThreads nests replies under their parents. Deleted comments stay as placeholders
+// while they still have replies and are dropped otherwise.
+func buildCommentThreads(comments []entity.TripComment, members map[int64]model.UserInfo) []model.TripCommentResponse {
+	children := make(map[int64][]entity.TripComment)
+	roots := make([]entity.TripComment, 0)
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			roots = append(roots, comment)
+		} else {
+			children[*comment.ParentID] = append(children[*comment.ParentID], comment)
+		}
+	}
+
+	var build func(comment entity.TripComment) (model.TripCommentResponse, bool)
+	build = func(comment entity.TripComment) (model.TripCommentResponse, bool) {
+		response := toTripCommentResponse(comment, members)
+		for _, child := range children[comment.ID] {
+			if reply, ok := build(child); ok {
+				response.Replies = append(response.Replies, reply)
+			}
+		}
+		if comment.DeletedAt.Valid {
+			response.Content = ""
+			response.Mentions = make([]model.UserInfo, 0)
+			return response, len(response.Replies) > 0
+		}
+		return response, true
+	}
+
+	threads := make([]model.TripCommentResponse, 0, len(roots))
+	for _, root := range roots {
+		if thread, ok := build(root); ok {
+			threads = append(threads, thread)
+		}
+	}
+	return threads
+}
+
+func toTripCommentResponse(comment entity.TripComment, members map[int64]model.UserInfo) model.TripCommentResponse {
+	author, ok := members[comment.AuthorID]
+	if !ok {
+		author = model.UserInfo{ID: comment.AuthorID}
+	}
+
+	response := model.TripCommentResponse{
+		ID:         comment.ID,
+		TripID:     comment.TripID,
+		TripItemID: comment.TripItemID,
+		ParentID:   comment.ParentID,
+		Author:     author,
+		Content:    comment.Content,
+		Mentions:   make([]model.UserInfo, 0),
+		IsDeleted:  comment.DeletedAt.Valid,
+		EditedAt:   comment.EditedAt,
+		CreatedAt:  comment.CreatedAt,
+		Replies:    make([]model.TripCommentResponse, 0),
+	}
+	for _, userID := range mentionutils.Find(comment.Content, memberNames(members)) {
+		response.Mentions = append(response.Mentions, members[userID])
+	}
+	return response
+}
+
+func memberNames(members map[int64]model.UserInfo) map[int64]string {
+	names := make(map[int64]string, len(members))
+	for userID, member := range members {
+		names[userID] = member.Name
+	}
+	return names
+}
+
+func sameTripItem(a *int64, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
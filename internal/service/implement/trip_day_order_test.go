@@ -0,0 +1,129 @@
+package serviceimplement
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+// lineProblem places the stops on a line at the given positions, all movable and all in the morning
+func lineProblem(positions []float64) dayOrderProblem {
+	n := len(positions)
+	problem := dayOrderProblem{
+		distance:  make([][]float64, n),
+		fixedAt:   make([]int, n),
+		pinned:    make([]bool, n),
+		slots:     make([]string, n),
+		itemSlots: make([]string, n),
+		startIdx:  -1,
+		endIdx:    -1,
+	}
+	for i := range positions {
+		problem.distance[i] = make([]float64, n)
+		for j := range positions {
+			problem.distance[i][j] = math.Abs(positions[i] - positions[j])
+		}
+		problem.fixedAt[i] = -1
+		problem.slots[i] = model.TimeInDate.Morning
+		problem.itemSlots[i] = model.TimeInDate.Morning
+	}
+	return problem
+}
+
+func TestDayOrderProblemSolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		positions []float64
+		setup     func(p *dayOrderProblem)
+		want      []int
+		wantOK    bool
+	}{
+		{
+			name:      "empty day",
+			positions: nil,
+			want:      []int{},
+			wantOK:    true,
+		},
+		{
+			name:      "single stop",
+			positions: []float64{5},
+			want:      []int{0},
+			wantOK:    true,
+		},
+		{
+			name:      "zigzag is straightened",
+			positions: []float64{0, 3, 1, 2},
+			setup:     func(p *dayOrderProblem) { p.startIdx = 0 },
+			want:      []int{0, 2, 3, 1},
+			wantOK:    true,
+		},
+		{
+			name:      "stop without coordinates keeps its position",
+			positions: []float64{0, 3, 100, 1, 2},
+			setup: func(p *dayOrderProblem) {
+				p.fixedAt[2] = 2
+				p.startIdx = 0
+			},
+			want:   []int{0, 3, 2, 4, 1},
+			wantOK: true,
+		},
+		{
+			name:      "end stop goes last",
+			positions: []float64{0, 1, 2, 3},
+			setup: func(p *dayOrderProblem) {
+				p.startIdx = 0
+				p.endIdx = 1
+			},
+			want:   []int{0, 2, 3, 1},
+			wantOK: true,
+		},
+		{
+			name:      "pinned stop keeps its time of day",
+			positions: []float64{0, 1, 2},
+			setup: func(p *dayOrderProblem) {
+				p.slots = []string{model.TimeInDate.Morning, model.TimeInDate.Morning, model.TimeInDate.Evening}
+				p.itemSlots = []string{model.TimeInDate.Evening, model.TimeInDate.Morning, model.TimeInDate.Morning}
+				p.pinned[0] = true
+			},
+			want:   []int{2, 1, 0},
+			wantOK: true,
+		},
+		{
+			name:      "pinned slot that no position has",
+			positions: []float64{0, 1},
+			setup: func(p *dayOrderProblem) {
+				p.itemSlots[0] = model.TimeInDate.Night
+				p.pinned[0] = true
+			},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := lineProblem(tt.positions)
+			if tt.setup != nil {
+				tt.setup(&problem)
+			}
+			got, ok := problem.solve()
+			if ok != tt.wantOK {
+				t.Fatalf("solve() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("solve() = %v, want %v", got, tt.want)
+			}
+			if ok && !problem.feasible(got) {
+				t.Errorf("solve() = %v is not feasible", got)
+			}
+		})
+	}
+}
+
+func TestDayOrderProblemLengthSkipsUnroutedStops(t *testing.T) {
+	problem := lineProblem([]float64{0, 100, 4})
+	problem.fixedAt[1] = 1
+	if got := problem.length([]int{0, 1, 2}); got != 4 {
+		t.Errorf("length() = %v, want 4", got)
+	}
+}
@@ -0,0 +1,260 @@
+package serviceimplement
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/bean"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
+)
+
+// documentDownloadTTL keeps download links short-lived, clients ask for a fresh one on every open
+const documentDownloadTTL = 5 * time.Minute
+
+type TripDocumentService struct {
+	tripDocumentRepository repository.TripDocumentRepository
+	tripRepository         repository.TripRepository
+	tripMemberRepository   repository.TripMemberRepository
+	tripItemRepository     repository.TripItemRepository
+	tripBookingRepository  repository.TripBookingRepository
+	s3Service              bean.S3Service
+}
+
+func NewTripDocumentService(
+	tripDocumentRepository repository.TripDocumentRepository,
+	tripRepository repository.TripRepository,
+	tripMemberRepository repository.TripMemberRepository,
+	tripItemRepository repository.TripItemRepository,
+	tripBookingRepository repository.TripBookingRepository,
+	s3Service bean.S3Service,
+) service.TripDocumentService {
+	return &TripDocumentService{
+		tripDocumentRepository: tripDocumentRepository,
+		tripRepository:         tripRepository,
+		tripMemberRepository:   tripMemberRepository,
+		tripItemRepository:     tripItemRepository,
+		tripBookingRepository:  tripBookingRepository,
+		s3Service:              s3Service,
+	}
+}
+
+func (service *TripDocumentService) CreateDocument(ctx *gin.Context, userId int64, tripId int64, documentRequest model.TripDocumentRequest) (*model.TripDocumentUploadResponse, string) {
+	if errCode := service.checkUserInTrip(ctx, userId, tripId); errCode != "" {
+		return nil, errCode
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanUploadDocuments, "TripDocumentService.CreateDocument"); errCode != "" {
+		return nil, errCode
+	}
+
+	if documentRequest.TripItemID != nil {
+		isTripItemExists, err := service.tripItemRepository.ExistsByTripIDAndTripItemIDCommand(ctx, tripId, *documentRequest.TripItemID, nil)
+		if err != nil {
+			log.Error("TripDocumentService.CreateDocument ExistsByTripIDAndTripItemIDCommand error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		if !isTripItemExists {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+	}
+	if documentRequest.TripBookingID != nil {
+		booking, err := service.tripBookingRepository.GetOneByIDQuery(ctx, tripId, *documentRequest.TripBookingID, nil)
+		if err != nil {
+			log.Error("TripDocumentService.CreateDocument GetOneByIDQuery error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		if booking == nil {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+	}
+
+	// the key is chosen here so a client can only ever upload into the slot it was handed
+	uploadURL, s3Key, err := service.s3Service.GenerateSignedUploadURL(ctx, sanitizeDocumentFileName(documentRequest.FileName, documentRequest.ContentType), documentRequest.ContentType)
+	if err != nil {
+		log.Error("TripDocumentService.CreateDocument GenerateSignedUploadURL error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	document := &entity.TripDocument{
+		TripID:        tripId,
+		UploadedBy:    userId,
+		Title:         documentRequest.Title,
+		FileName:      documentRequest.FileName,
+		ContentType:   documentRequest.ContentType,
+		S3Key:         s3Key,
+		Visibility:    documentRequest.Visibility,
+		TripItemID:    documentRequest.TripItemID,
+		TripBookingID: documentRequest.TripBookingID,
+		CreatedAt:     time.Now(),
+	}
+	documentId, err := service.tripDocumentRepository.CreateCommand(ctx, document, nil)
+	if err != nil {
+		log.Error("TripDocumentService.CreateDocument CreateCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	document.ID = documentId
+
+	return &model.TripDocumentUploadResponse{
+		Document:  toTripDocumentResponse(*document),
+		UploadURL: uploadURL,
+	}, ""
+}
+
+func (service *TripDocumentService) GetDocuments(ctx *gin.Context, userId int64, tripId int64) ([]model.TripDocumentResponse, string) {
+	if errCode := service.checkUserInTrip(ctx, userId, tripId); errCode != "" {
+		return nil, errCode
+	}
+
+	documents, err := service.tripDocumentRepository.GetAllVisibleByTripIDQuery(ctx, tripId, userId, nil)
+	if err != nil {
+		log.Error("TripDocumentService.GetDocuments GetAllVisibleByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	responses := make([]model.TripDocumentResponse, 0, len(documents))
+	for _, document := range documents {
+		responses = append(responses, toTripDocumentResponse(document))
+	}
+	return responses, ""
+}
+
+func (service *TripDocumentService) GetDocumentDownloadURL(ctx *gin.Context, userId int64, tripId int64, documentId int64) (*model.TripDocumentDownloadResponse, string) {
+	document, errCode := service.getVisibleDocument(ctx, userId, tripId, documentId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	expiresAt := time.Now().Add(documentDownloadTTL)
+	url, err := service.s3Service.GenerateSignedDownloadURL(ctx, document.S3Key, documentDownloadTTL)
+	if err != nil {
+		log.Error("TripDocumentService.GetDocumentDownloadURL GenerateSignedDownloadURL error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	return &model.TripDocumentDownloadResponse{
+		URL:       url,
+		ExpiresAt: expiresAt,
+	}, ""
+}
+
+func (service *TripDocumentService) DeleteDocument(ctx *gin.Context, userId int64, tripId int64, documentId int64) string {
+	document, errCode := service.getVisibleDocument(ctx, userId, tripId, documentId)
+	if errCode != "" {
+		return errCode
+	}
+
+	// the uploader can remove the document, admins only those shared with the trip
+	canDelete := func(role string) bool {
+		return trippermissionutils.CanDeleteDocument(role, document.UploadedBy == userId)
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, canDelete, "TripDocumentService.DeleteDocument"); errCode != "" {
+		return errCode
+	}
+
+	err := service.tripDocumentRepository.DeleteByIDCommand(ctx, documentId, nil)
+	if err != nil {
+		log.Error("TripDocumentService.DeleteDocument DeleteByIDCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	// the record is already gone for clients, a leftover object only costs storage
+	err = service.s3Service.DeleteImage(ctx, document.S3Key)
+	if err != nil {
+		log.Error("TripDocumentService.DeleteDocument DeleteImage error: " + err.Error())
+	}
+
+	return ""
+}
+
+func (service *TripDocumentService) checkUserInTrip(ctx *gin.Context, userId int64, tripId int64) string {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripDocumentService.checkUserInTrip GetOneByIDQuery error: " + err.Error())
+		return error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	isMember, err := service.tripMemberRepository.IsUserInTripQuery(ctx, tripId, userId, nil)
+	if err != nil {
+		log.Error("TripDocumentService.checkUserInTrip IsUserInTripQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if !isMember {
+		return error_utils.ErrorCode.FORBIDDEN
+	}
+	return ""
+}
+
+func (service *TripDocumentService) getVisibleDocument(ctx *gin.Context, userId int64, tripId int64, documentId int64) (*entity.TripDocument, string) {
+	if errCode := service.checkUserInTrip(ctx, userId, tripId); errCode != "" {
+		return nil, errCode
+	}
+
+	document, err := service.tripDocumentRepository.GetOneByIDQuery(ctx, tripId, documentId, nil)
+	if err != nil {
+		log.Error("TripDocumentService.getVisibleDocument GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	// private documents of other members are reported as missing rather than forbidden
+	if document == nil || (document.Visibility == model.DocumentVisibility.Private && document.UploadedBy != userId) {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	return document, ""
+}
+
+func toTripDocumentResponse(document entity.TripDocument) model.TripDocumentResponse {
+	return model.TripDocumentResponse{
+		ID:            document.ID,
+		TripID:        document.TripID,
+		UploadedBy:    document.UploadedBy,
+		Title:         document.Title,
+		FileName:      document.FileName,
+		ContentType:   document.ContentType,
+		Visibility:    document.Visibility,
+		TripItemID:    document.TripItemID,
+		TripBookingID: document.TripBookingID,
+		CreatedAt:     document.CreatedAt,
+	}
+}
+
+// documentExtensions names the stored object after the declared content type rather than the client's file name
+var documentExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/heic":      ".heic",
+	"image/webp":      ".webp",
+}
+
+// sanitizeDocumentFileName keeps the object key to a plain ascii name, the original name stays on the record
+func sanitizeDocumentFileName(fileName string, contentType string) string {
+	base := strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+
+	var builder strings.Builder
+	for _, r := range base {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			builder.WriteRune(r)
+		default:
+			builder.WriteRune('_')
+		}
+	}
+	name := builder.String()
+	if len(name) > 100 {
+		name = name[:100]
+	}
+	if name == "" {
+		name = "document"
+	}
+	// the S3 service appends a timestamp between the base name and the extension
+	return "document-" + name + "-" + documentExtensions[contentType]
+}
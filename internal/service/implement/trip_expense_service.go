@@ -0,0 +1,753 @@
+package serviceimplement
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	currencyutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/currency_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	timezoneutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/timezone_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
+)
+
+type TripExpenseService struct {
+	tripExpenseRepository    repository.TripExpenseRepository
+	tripSettlementRepository repository.TripSettlementRepository
+	tripRepository           repository.TripRepository
+	tripMemberRepository     repository.TripMemberRepository
+	tripItemRepository       repository.TripItemRepository
+	userRepository           repository.UserRepository
+	exchangeRateRepository   repository.ExchangeRateRepository
+	unitOfWork               repository.UnitOfWork
+}
+
+func NewTripExpenseService(
+	tripExpenseRepository repository.TripExpenseRepository,
+	tripSettlementRepository repository.TripSettlementRepository,
+	tripRepository repository.TripRepository,
+	tripMemberRepository repository.TripMemberRepository,
+	tripItemRepository repository.TripItemRepository,
+	userRepository repository.UserRepository,
+	exchangeRateRepository repository.ExchangeRateRepository,
+	unitOfWork repository.UnitOfWork,
+) service.TripExpenseService {
+	return &TripExpenseService{
+		tripExpenseRepository:    tripExpenseRepository,
+		tripSettlementRepository: tripSettlementRepository,
+		tripRepository:           tripRepository,
+		tripMemberRepository:     tripMemberRepository,
+		tripItemRepository:       tripItemRepository,
+		userRepository:           userRepository,
+		exchangeRateRepository:   exchangeRateRepository,
+		unitOfWork:               unitOfWork,
+	}
+}
+
+func (service *TripExpenseService) CreateExpense(ctx *gin.Context, userId int64, tripId int64, expenseRequest model.TripExpenseRequest) (*model.TripExpenseResponse, string) {
+	trip, members, errCode := service.getTripAndMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanRecordExpenses, "TripExpenseService.CreateExpense"); errCode != "" {
+		return nil, errCode
+	}
+
+	payerID := userId
+	if expenseRequest.PayerID != nil {
+		payerID = *expenseRequest.PayerID
+	}
+	if _, ok := members[payerID]; !ok {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	// every participant has to be on the trip, and only once
+	seen := make(map[int64]bool)
+	for _, split := range expenseRequest.Splits {
+		if _, ok := members[split.UserID]; !ok || seen[split.UserID] {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+		seen[split.UserID] = true
+	}
+
+	totalCents := toCents(expenseRequest.Amount)
+	shares, splitCents, ok := splitExpense(totalCents, expenseRequest.SplitType, expenseRequest.Splits)
+	if !ok {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripExpenseService.CreateExpense Begin error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	tripDay := expenseRequest.TripDay
+	if expenseRequest.TripItemID != nil {
+		tripItem, err := service.tripItemRepository.GetOneByIDQuery(ctx, tripId, *expenseRequest.TripItemID, tx)
+		if err != nil {
+			log.Error("TripExpenseService.CreateExpense GetOneByIDQuery error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		if tripItem == nil {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+		if tripDay == nil {
+			tripDay = &tripItem.TripDay
+		}
+	}
+	if tripDay == nil {
+		today := timezoneutils.TripDayOf(trip.StartDate, trip.TimeZone, time.Now())
+		if today >= 1 && today <= int64(trip.Days) {
+			tripDay = &today
+		}
+	}
+
+	category := expenseRequest.Category
+	if category == "" {
+		category = model.ExpenseCategory.Other
+	}
+
+	// freeze the conversion now so later rate imports don't change what was spent
+	currency, ok := currencyutils.Normalise(expenseRequest.Currency)
+	if !ok {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	unitsPerUSD, err := service.getUnitsPerUSD(ctx, tx)
+	if err != nil {
+		log.Error("TripExpenseService.CreateExpense getUnitsPerUSD error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	rate, ok := currencyutils.Rate(currency, trip.BudgetCurrency, unitsPerUSD)
+	if !ok {
+		return nil, error_utils.ErrorCode.EXCHANGE_RATE_NOT_FOUND
+	}
+	baseAmount := fromCents(toCents(fromCents(totalCents) * rate))
+
+	expense := &entity.TripExpense{
+		TripID:       tripId,
+		TripItemID:   expenseRequest.TripItemID,
+		PayerID:      payerID,
+		CreatedBy:    userId,
+		Description:  expenseRequest.Description,
+		Amount:       fromCents(totalCents),
+		Currency:     currency,
+		SplitType:    expenseRequest.SplitType,
+		Category:     category,
+		TripDay:      tripDay,
+		BaseCurrency: &trip.BudgetCurrency,
+		ExchangeRate: &rate,
+		BaseAmount:   &baseAmount,
+	}
+	expense.ID, err = service.tripExpenseRepository.CreateCommand(ctx, expense, tx)
+	if err != nil {
+		log.Error("TripExpenseService.CreateExpense CreateCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	var splits []entity.TripExpenseSplit
+	for i, splitRequest := range expenseRequest.Splits {
+		split := entity.TripExpenseSplit{
+			ExpenseID: expense.ID,
+			UserID:    splitRequest.UserID,
+			Share:     shares[i],
+			Amount:    fromCents(splitCents[i]),
+		}
+		err = service.tripExpenseRepository.CreateSplitCommand(ctx, &split, tx)
+		if err != nil {
+			log.Error("TripExpenseService.CreateExpense CreateSplitCommand error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		splits = append(splits, split)
+	}
+
+	err = service.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripExpenseService.CreateExpense Commit error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	response := toTripExpenseResponse(*expense, splits, members)
+	return &response, ""
+}
+
+func (service *TripExpenseService) GetExpenses(ctx *gin.Context, userId int64, tripId int64) ([]model.TripExpenseResponse, string) {
+	_, members, errCode := service.getTripAndMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	expenses, splitsByExpense, errCode := service.getExpensesWithSplits(ctx, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	service.addFormerMembers(ctx, members, expenses, splitsByExpense, nil)
+
+	responses := make([]model.TripExpenseResponse, 0, len(expenses))
+	for _, expense := range expenses {
+		responses = append(responses, toTripExpenseResponse(expense, splitsByExpense[expense.ID], members))
+	}
+	return responses, ""
+}
+
+func (service *TripExpenseService) DeleteExpense(ctx *gin.Context, userId int64, tripId int64, expenseId int64) string {
+	if _, _, errCode := service.getTripAndMembersIfUserInTrip(ctx, userId, tripId); errCode != "" {
+		return errCode
+	}
+
+	expense, err := service.tripExpenseRepository.GetOneByIDQuery(ctx, tripId, expenseId, nil)
+	if err != nil {
+		log.Error("TripExpenseService.DeleteExpense GetOneByIDQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if expense == nil {
+		return error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	// whoever recorded or paid the expense can remove it, as can trip admins
+	canDelete := func(role string) bool {
+		return trippermissionutils.CanDeleteExpense(role, expense.CreatedBy == userId || expense.PayerID == userId)
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, canDelete, "TripExpenseService.DeleteExpense"); errCode != "" {
+		return errCode
+	}
+
+	err = service.tripExpenseRepository.DeleteByIDCommand(ctx, expenseId, nil)
+	if err != nil {
+		log.Error("TripExpenseService.DeleteExpense DeleteByIDCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	return ""
+}
+
+func (service *TripExpenseService) GetBalances(ctx *gin.Context, userId int64, tripId int64) (*model.TripBalancesResponse, string) {
+	_, members, errCode := service.getTripAndMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	expenses, splitsByExpense, errCode := service.getExpensesWithSplits(ctx, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	settlements, err := service.tripSettlementRepository.GetAllByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripExpenseService.GetBalances GetAllByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	service.addFormerMembers(ctx, members, expenses, splitsByExpense, settlements)
+
+	// amounts are kept in cents per currency so rounding never leaves a debt open
+	type ledgerEntry struct {
+		paid    int64
+		owed    int64
+		settled int64
+	}
+	ledgers := make(map[string]map[int64]*ledgerEntry)
+	entryFor := func(currency string, userID int64) *ledgerEntry {
+		if ledgers[currency] == nil {
+			ledgers[currency] = make(map[int64]*ledgerEntry)
+		}
+		if ledgers[currency][userID] == nil {
+			ledgers[currency][userID] = &ledgerEntry{}
+		}
+		return ledgers[currency][userID]
+	}
+
+	for _, expense := range expenses {
+		entryFor(expense.Currency, expense.PayerID).paid += toCents(expense.Amount)
+		for _, split := range splitsByExpense[expense.ID] {
+			entryFor(expense.Currency, split.UserID).owed += toCents(split.Amount)
+		}
+	}
+
+	response := &model.TripBalancesResponse{
+		Balances:    make([]model.CurrencyBalance, 0, len(ledgers)),
+		Settlements: make([]model.TripSettlementResponse, 0, len(settlements)),
+	}
+	for _, settlement := range settlements {
+		amount := toCents(settlement.Amount)
+		entryFor(settlement.Currency, settlement.FromUserID).settled += amount
+		entryFor(settlement.Currency, settlement.ToUserID).settled -= amount
+		response.Settlements = append(response.Settlements, model.TripSettlementResponse{
+			ID:         settlement.ID,
+			FromUserID: settlement.FromUserID,
+			FromName:   members[settlement.FromUserID],
+			ToUserID:   settlement.ToUserID,
+			ToName:     members[settlement.ToUserID],
+			CreatedBy:  settlement.CreatedBy,
+			Amount:     settlement.Amount,
+			Currency:   settlement.Currency,
+			CreatedAt:  settlement.CreatedAt,
+		})
+	}
+
+	currencies := make([]string, 0, len(ledgers))
+	for currency := range ledgers {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		balance := model.CurrencyBalance{
+			Currency:  currency,
+			Members:   make([]model.MemberBalance, 0),
+			Transfers: make([]model.SettlementTransfer, 0),
+		}
+		nets := make(map[int64]int64)
+		userIDs := make([]int64, 0, len(ledgers[currency]))
+		for userID := range ledgers[currency] {
+			userIDs = append(userIDs, userID)
+		}
+		sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+
+		for _, userID := range userIDs {
+			entry := ledgers[currency][userID]
+			nets[userID] = entry.paid - entry.owed + entry.settled
+			balance.Members = append(balance.Members, model.MemberBalance{
+				UserID:  userID,
+				Name:    members[userID],
+				Paid:    fromCents(entry.paid),
+				Owed:    fromCents(entry.owed),
+				Settled: fromCents(entry.settled),
+				Net:     fromCents(nets[userID]),
+			})
+		}
+		for _, transfer := range settleUp(nets) {
+			transfer.FromName = members[transfer.FromUserID]
+			transfer.ToName = members[transfer.ToUserID]
+			balance.Transfers = append(balance.Transfers, transfer)
+		}
+		response.Balances = append(response.Balances, balance)
+	}
+
+	return response, ""
+}
+
+func (service *TripExpenseService) GetBudgetSummary(ctx *gin.Context, userId int64, tripId int64) (*model.TripBudgetSummaryResponse, string) {
+	trip, _, errCode := service.getTripAndMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	expenses, err := service.tripExpenseRepository.GetAllByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripExpenseService.GetBudgetSummary GetAllByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	unitsPerUSD, err := service.getUnitsPerUSD(ctx, nil)
+	if err != nil {
+		log.Error("TripExpenseService.GetBudgetSummary getUnitsPerUSD error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	var spentCents int64
+	categoryCents := make(map[string]int64)
+	dayCents := make(map[int64]int64)
+	missingRates := make(map[string]bool)
+	for _, expense := range expenses {
+		amount, ok := baseAmountOf(expense, trip.BudgetCurrency, unitsPerUSD)
+		if !ok {
+			missingRates[expense.Currency] = true
+			continue
+		}
+		cents := toCents(amount)
+		spentCents += cents
+		categoryCents[expense.Category] += cents
+
+		// spending before or after the trip (or with no day at all) lands on day 0
+		var tripDay int64
+		if expense.TripDay != nil && *expense.TripDay >= 1 && *expense.TripDay <= int64(trip.Days) {
+			tripDay = *expense.TripDay
+		}
+		dayCents[tripDay] += cents
+	}
+
+	budgetCents := toCents(trip.Budget)
+	var allowanceCents int64
+	if trip.Days > 0 {
+		allowanceCents = budgetCents / int64(trip.Days)
+	}
+	response := &model.TripBudgetSummaryResponse{
+		Currency:       trip.BudgetCurrency,
+		Budget:         fromCents(budgetCents),
+		Spent:          fromCents(spentCents),
+		Remaining:      fromCents(budgetCents - spentCents),
+		DailyAllowance: fromCents(allowanceCents),
+		ByCategory:     make([]model.BudgetCategorySummary, 0, len(categoryCents)),
+		ByDay:          make([]model.BudgetDaySummary, 0, trip.Days+1),
+		MissingRates:   make([]string, 0, len(missingRates)),
+	}
+
+	for _, category := range model.ExpenseCategories {
+		cents, ok := categoryCents[category]
+		if !ok {
+			continue
+		}
+		var percent float64
+		if budgetCents > 0 {
+			percent = math.Round(float64(cents)*10000/float64(budgetCents)) / 100
+		}
+		response.ByCategory = append(response.ByCategory, model.BudgetCategorySummary{
+			Category: category,
+			Spent:    fromCents(cents),
+			Percent:  percent,
+		})
+	}
+
+	if cents, ok := dayCents[0]; ok {
+		response.ByDay = append(response.ByDay, model.BudgetDaySummary{
+			TripDay: 0,
+			Spent:   fromCents(cents),
+		})
+	}
+	for tripDay := int64(1); tripDay <= int64(trip.Days); tripDay++ {
+		date := timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, tripDay)
+		response.ByDay = append(response.ByDay, model.BudgetDaySummary{
+			TripDay:   tripDay,
+			Date:      &date,
+			Spent:     fromCents(dayCents[tripDay]),
+			Allowance: fromCents(allowanceCents),
+			Remaining: fromCents(allowanceCents - dayCents[tripDay]),
+		})
+	}
+
+	for currency := range missingRates {
+		response.MissingRates = append(response.MissingRates, currency)
+	}
+	sort.Strings(response.MissingRates)
+
+	return response, ""
+}
+
+func (service *TripExpenseService) GetExpenseReport(ctx *gin.Context, userId int64, tripId int64) (*model.TripExpenseReport, string) {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripExpenseService.GetExpenseReport GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	expenses, errCode := service.GetExpenses(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	balances, errCode := service.GetBalances(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	return &model.TripExpenseReport{
+		Title:          trip.Title,
+		TimeZone:       trip.TimeZone,
+		BudgetCurrency: trip.BudgetCurrency,
+		Expenses:       expenses,
+		Balances:       balances.Balances,
+		Settlements:    balances.Settlements,
+	}, ""
+}
+
+func (service *TripExpenseService) CreateSettlement(ctx *gin.Context, userId int64, tripId int64, settlementRequest model.TripSettlementRequest) string {
+	currency, ok := currencyutils.Normalise(settlementRequest.Currency)
+	if !ok {
+		return error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	trip, members, errCode := service.getTripAndMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return errCode
+	}
+	if _, ok := members[settlementRequest.FromUserID]; !ok {
+		return error_utils.ErrorCode.BAD_REQUEST
+	}
+	if _, ok := members[settlementRequest.ToUserID]; !ok {
+		return error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	// a payment is recorded by one of the two sides, or by an admin
+	canRecord := func(role string) bool {
+		return trippermissionutils.CanRecordSettlement(role, userId == settlementRequest.FromUserID || userId == settlementRequest.ToUserID)
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, canRecord, "TripExpenseService.CreateSettlement"); errCode != "" {
+		return errCode
+	}
+
+	// a currency without a rate could never be set against the expenses, it would open a ledger of its own
+	unitsPerUSD, err := service.getUnitsPerUSD(ctx, nil)
+	if err != nil {
+		log.Error("TripExpenseService.CreateSettlement getUnitsPerUSD error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if _, ok := currencyutils.Rate(currency, trip.BudgetCurrency, unitsPerUSD); !ok {
+		return error_utils.ErrorCode.EXCHANGE_RATE_NOT_FOUND
+	}
+
+	err = service.tripSettlementRepository.CreateCommand(ctx, &entity.TripSettlement{
+		TripID:     tripId,
+		FromUserID: settlementRequest.FromUserID,
+		ToUserID:   settlementRequest.ToUserID,
+		CreatedBy:  userId,
+		Amount:     fromCents(toCents(settlementRequest.Amount)),
+		Currency:   currency,
+	}, nil)
+	if err != nil {
+		log.Error("TripExpenseService.CreateSettlement CreateCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	return ""
+}
+
+// getTripAndMembersIfUserInTrip returns the trip and the names of its current members keyed by user id
+func (service *TripExpenseService) getTripAndMembersIfUserInTrip(ctx *gin.Context, userId int64, tripId int64) (*entity.Trip, map[int64]string, string) {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripExpenseService.getTripAndMembersIfUserInTrip GetOneByIDQuery error: " + err.Error())
+		return nil, nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	tripMembers, err := service.tripMemberRepository.GetTripMembersQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripExpenseService.getTripAndMembersIfUserInTrip GetTripMembersQuery error: " + err.Error())
+		return nil, nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	members := make(map[int64]string)
+	for _, member := range tripMembers {
+		members[member.UserID] = member.Name
+	}
+	if _, ok := members[userId]; !ok {
+		return nil, nil, error_utils.ErrorCode.FORBIDDEN
+	}
+	return trip, members, ""
+}
+
+func (service *TripExpenseService) getUnitsPerUSD(ctx *gin.Context, tx *sqlx.Tx) (map[string]float64, error) {
+	rates, err := service.exchangeRateRepository.GetAllQuery(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	unitsPerUSD := make(map[string]float64, len(rates))
+	for _, rate := range rates {
+		unitsPerUSD[rate.Currency] = rate.UnitsPerUSD
+	}
+	return unitsPerUSD, nil
+}
+
+// addFormerMembers looks up the names of people who left the trip but still appear in the ledger,
+// whether as payers, split participants or settlement parties, in one batched query
+func (service *TripExpenseService) addFormerMembers(ctx *gin.Context, members map[int64]string, expenses []entity.TripExpense, splitsByExpense map[int64][]entity.TripExpenseSplit, settlements []entity.TripSettlement) {
+	var missingIDs []int64
+	addMissing := func(userID int64) {
+		if _, ok := members[userID]; ok {
+			return
+		}
+		// the empty name is a placeholder until the lookup fills it in
+		members[userID] = ""
+		missingIDs = append(missingIDs, userID)
+	}
+	for _, expense := range expenses {
+		addMissing(expense.PayerID)
+		for _, split := range splitsByExpense[expense.ID] {
+			addMissing(split.UserID)
+		}
+	}
+	for _, settlement := range settlements {
+		addMissing(settlement.FromUserID)
+		addMissing(settlement.ToUserID)
+	}
+	if len(missingIDs) == 0 {
+		return
+	}
+
+	users, err := service.userRepository.GetAllByIDsQuery(ctx, missingIDs, nil)
+	if err != nil {
+		log.Error("TripExpenseService.addFormerMembers GetAllByIDsQuery error: " + err.Error())
+		return
+	}
+	for _, user := range users {
+		members[user.Id] = user.Name
+	}
+}
+
+func (service *TripExpenseService) getExpensesWithSplits(ctx *gin.Context, tripId int64) ([]entity.TripExpense, map[int64][]entity.TripExpenseSplit, string) {
+	expenses, err := service.tripExpenseRepository.GetAllByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripExpenseService.getExpensesWithSplits GetAllByTripIDQuery error: " + err.Error())
+		return nil, nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	splits, err := service.tripExpenseRepository.GetAllSplitsByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripExpenseService.getExpensesWithSplits GetAllSplitsByTripIDQuery error: " + err.Error())
+		return nil, nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	splitsByExpense := make(map[int64][]entity.TripExpenseSplit)
+	for _, split := range splits {
+		splitsByExpense[split.ExpenseID] = append(splitsByExpense[split.ExpenseID], split)
+	}
+	return expenses, splitsByExpense, ""
+}
+
+func toTripExpenseResponse(expense entity.TripExpense, splits []entity.TripExpenseSplit, members map[int64]string) model.TripExpenseResponse {
+	response := model.TripExpenseResponse{
+		ID:           expense.ID,
+		TripID:       expense.TripID,
+		TripItemID:   expense.TripItemID,
+		PayerID:      expense.PayerID,
+		PayerName:    members[expense.PayerID],
+		CreatedBy:    expense.CreatedBy,
+		Description:  expense.Description,
+		Amount:       expense.Amount,
+		Currency:     expense.Currency,
+		SplitType:    expense.SplitType,
+		Category:     expense.Category,
+		TripDay:      expense.TripDay,
+		BaseCurrency: expense.BaseCurrency,
+		ExchangeRate: expense.ExchangeRate,
+		BaseAmount:   expense.BaseAmount,
+		Splits:       make([]model.ExpenseSplitResponse, 0, len(splits)),
+		CreatedAt:    expense.CreatedAt,
+	}
+	for _, split := range splits {
+		response.Splits = append(response.Splits, model.ExpenseSplitResponse{
+			UserID: split.UserID,
+			Name:   members[split.UserID],
+			Share:  split.Share,
+			Amount: split.Amount,
+		})
+	}
+	return response
+}
+
+// splitExpense returns the share weight and the owed cents of every participant.
+// Equal and share based splits hand leftover cents to the largest remainders so the parts add up.
+func splitExpense(totalCents int64, splitType string, splits []model.ExpenseSplitRequest) ([]float64, []int64, bool) {
+	shares := make([]float64, len(splits))
+	cents := make([]int64, len(splits))
+
+	if splitType == model.ExpenseSplitType.Exact {
+		var sum int64
+		for i, split := range splits {
+			if split.Amount == nil {
+				return nil, nil, false
+			}
+			cents[i] = toCents(*split.Amount)
+			shares[i] = fromCents(cents[i])
+			sum += cents[i]
+		}
+		return shares, cents, sum == totalCents
+	}
+
+	totalShares := 0.0
+	for i, split := range splits {
+		shares[i] = 1
+		if splitType == model.ExpenseSplitType.Shares {
+			if split.Share == nil {
+				return nil, nil, false
+			}
+			shares[i] = *split.Share
+		}
+		totalShares += shares[i]
+	}
+
+	remainders := make([]float64, len(splits))
+	var allocated int64
+	for i := range splits {
+		exact := float64(totalCents) * shares[i] / totalShares
+		cents[i] = int64(math.Floor(exact))
+		remainders[i] = exact - float64(cents[i])
+		allocated += cents[i]
+	}
+	order := make([]int, len(splits))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return remainders[order[i]] > remainders[order[j]] })
+	for i := int64(0); i < totalCents-allocated; i++ {
+		cents[order[int(i)%len(order)]]++
+	}
+
+	return shares, cents, true
+}
+
+// settleUp pairs the largest debtor with the largest creditor until everyone is even,
+// which needs at most one transfer fewer than there are people with a balance
+func settleUp(nets map[int64]int64) []model.SettlementTransfer {
+	type party struct {
+		userID int64
+		amount int64
+	}
+	var debtors, creditors []party
+	for userID, net := range nets {
+		if net < 0 {
+			debtors = append(debtors, party{userID, -net})
+		} else if net > 0 {
+			creditors = append(creditors, party{userID, net})
+		}
+	}
+	byAmount := func(parties []party) func(i, j int) bool {
+		return func(i, j int) bool {
+			if parties[i].amount != parties[j].amount {
+				return parties[i].amount > parties[j].amount
+			}
+			return parties[i].userID < parties[j].userID
+		}
+	}
+
+	transfers := make([]model.SettlementTransfer, 0)
+	for len(debtors) > 0 && len(creditors) > 0 {
+		sort.Slice(debtors, byAmount(debtors))
+		sort.Slice(creditors, byAmount(creditors))
+
+		amount := min(debtors[0].amount, creditors[0].amount)
+		transfers = append(transfers, model.SettlementTransfer{
+			FromUserID: debtors[0].userID,
+			ToUserID:   creditors[0].userID,
+			Amount:     fromCents(amount),
+		})
+		debtors[0].amount -= amount
+		creditors[0].amount -= amount
+		if debtors[0].amount == 0 {
+			debtors = debtors[1:]
+		}
+		if creditors[0].amount == 0 {
+			creditors = creditors[1:]
+		}
+	}
+	return transfers
+}
+
+// baseAmountOf uses the conversion recorded with the expense, and today's rate only when the
+// budget currency has changed since or the expense predates recorded rates
+func baseAmountOf(expense entity.TripExpense, budgetCurrency string, unitsPerUSD map[string]float64) (float64, bool) {
+	if expense.BaseAmount != nil && expense.BaseCurrency != nil && *expense.BaseCurrency == budgetCurrency {
+		return *expense.BaseAmount, true
+	}
+	rate, ok := currencyutils.Rate(expense.Currency, budgetCurrency, unitsPerUSD)
+	if !ok {
+		return 0, false
+	}
+	return expense.Amount * rate, true
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
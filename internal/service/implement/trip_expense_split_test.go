@@ -0,0 +1,155 @@
+package serviceimplement
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+func TestSplitExpense(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+	participants := func(n int) []model.ExpenseSplitRequest {
+		splits := make([]model.ExpenseSplitRequest, n)
+		for i := range splits {
+			splits[i].UserID = int64(i + 1)
+		}
+		return splits
+	}
+
+	tests := []struct {
+		name       string
+		totalCents int64
+		splitType  string
+		splits     []model.ExpenseSplitRequest
+		wantCents  []int64
+		wantOK     bool
+	}{
+		{
+			name:       "equal split hands leftover cents out in order",
+			totalCents: 1000,
+			splitType:  model.ExpenseSplitType.Equal,
+			splits:     participants(3),
+			wantCents:  []int64{334, 333, 333},
+			wantOK:     true,
+		},
+		{
+			name:       "equal split of an even amount",
+			totalCents: 900,
+			splitType:  model.ExpenseSplitType.Equal,
+			splits:     participants(3),
+			wantCents:  []int64{300, 300, 300},
+			wantOK:     true,
+		},
+		{
+			name:       "shares give the leftover cent to the largest remainder",
+			totalCents: 100,
+			splitType:  model.ExpenseSplitType.Shares,
+			splits:     []model.ExpenseSplitRequest{{UserID: 1, Share: float(1)}, {UserID: 2, Share: float(2)}},
+			wantCents:  []int64{33, 67},
+			wantOK:     true,
+		},
+		{
+			name:       "shares without a weight",
+			totalCents: 100,
+			splitType:  model.ExpenseSplitType.Shares,
+			splits:     []model.ExpenseSplitRequest{{UserID: 1, Share: float(1)}, {UserID: 2}},
+			wantOK:     false,
+		},
+		{
+			name:       "exact amounts that add up",
+			totalCents: 1050,
+			splitType:  model.ExpenseSplitType.Exact,
+			splits:     []model.ExpenseSplitRequest{{UserID: 1, Amount: float(3.5)}, {UserID: 2, Amount: float(7)}},
+			wantCents:  []int64{350, 700},
+			wantOK:     true,
+		},
+		{
+			name:       "exact amounts that do not add up",
+			totalCents: 1000,
+			splitType:  model.ExpenseSplitType.Exact,
+			splits:     []model.ExpenseSplitRequest{{UserID: 1, Amount: float(3.5)}, {UserID: 2, Amount: float(7)}},
+			wantCents:  []int64{350, 700},
+			wantOK:     false,
+		},
+		{
+			name:       "exact split without an amount",
+			totalCents: 1000,
+			splitType:  model.ExpenseSplitType.Exact,
+			splits:     []model.ExpenseSplitRequest{{UserID: 1, Amount: float(10)}, {UserID: 2}},
+			wantOK:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, cents, ok := splitExpense(tt.totalCents, tt.splitType, tt.splits)
+			if ok != tt.wantOK {
+				t.Fatalf("splitExpense() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if tt.wantCents != nil && !reflect.DeepEqual(cents, tt.wantCents) {
+				t.Errorf("splitExpense() cents = %v, want %v", cents, tt.wantCents)
+			}
+			if ok {
+				var sum int64
+				for _, c := range cents {
+					sum += c
+				}
+				if sum != tt.totalCents {
+					t.Errorf("splitExpense() cents add up to %d, want %d", sum, tt.totalCents)
+				}
+			}
+		})
+	}
+}
+
+func TestSettleUp(t *testing.T) {
+	tests := []struct {
+		name string
+		nets map[int64]int64
+		want []model.SettlementTransfer
+	}{
+		{
+			name: "everyone is even",
+			nets: map[int64]int64{1: 0, 2: 0},
+			want: []model.SettlementTransfer{},
+		},
+		{
+			name: "one creditor",
+			nets: map[int64]int64{1: 3000, 2: -1000, 3: -2000},
+			want: []model.SettlementTransfer{
+				{FromUserID: 3, ToUserID: 1, Amount: 20},
+				{FromUserID: 2, ToUserID: 1, Amount: 10},
+			},
+		},
+		{
+			name: "a settled member is left out",
+			nets: map[int64]int64{1: -550, 2: 0, 3: 550},
+			want: []model.SettlementTransfer{
+				{FromUserID: 1, ToUserID: 3, Amount: 5.5},
+			},
+		},
+		{
+			name: "ties are broken by user id",
+			nets: map[int64]int64{1: -100, 2: -100, 3: 100, 4: 100},
+			want: []model.SettlementTransfer{
+				{FromUserID: 1, ToUserID: 3, Amount: 1},
+				{FromUserID: 2, ToUserID: 4, Amount: 1},
+			},
+		},
+		{
+			name: "a debt split across creditors",
+			nets: map[int64]int64{1: -1000, 2: 600, 3: 400},
+			want: []model.SettlementTransfer{
+				{FromUserID: 1, ToUserID: 2, Amount: 6},
+				{FromUserID: 1, ToUserID: 3, Amount: 4},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := settleUp(tt.nets); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("settleUp(%v) = %v, want %v", tt.nets, got, tt.want)
+			}
+		})
+	}
+}
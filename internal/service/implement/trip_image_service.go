@@ -1,6 +1,8 @@
 package serviceimplement
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
@@ -8,14 +10,17 @@ import (
 	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
 )
 
 type TripImageService struct {
-	tripImageRepository  repository.TripImageRepository
-	tripRepository       repository.TripRepository
-	tripMemberRepository repository.TripMemberRepository
-	tripItemRepository   repository.TripItemRepository
-	unitOfWork           repository.UnitOfWork
+	tripImageRepository     repository.TripImageRepository
+	tripRepository          repository.TripRepository
+	tripMemberRepository    repository.TripMemberRepository
+	tripItemRepository      repository.TripItemRepository
+	unitOfWork              repository.UnitOfWork
+	tripActivityRepository  repository.TripActivityRepository
+	syncTombstoneRepository repository.SyncTombstoneRepository
 }
 
 func NewTripImageService(
@@ -24,13 +29,17 @@ func NewTripImageService(
 	tripMemberRepository repository.TripMemberRepository,
 	tripItemRepository repository.TripItemRepository,
 	unitOfWork repository.UnitOfWork,
+	tripActivityRepository repository.TripActivityRepository,
+	syncTombstoneRepository repository.SyncTombstoneRepository,
 ) service.TripImageService {
 	return &TripImageService{
-		tripImageRepository:  tripImageRepository,
-		tripRepository:       tripRepository,
-		tripMemberRepository: tripMemberRepository,
-		tripItemRepository:   tripItemRepository,
-		unitOfWork:           unitOfWork,
+		tripImageRepository:     tripImageRepository,
+		tripRepository:          tripRepository,
+		tripMemberRepository:    tripMemberRepository,
+		tripItemRepository:      tripItemRepository,
+		unitOfWork:              unitOfWork,
+		tripActivityRepository:  tripActivityRepository,
+		syncTombstoneRepository: syncTombstoneRepository,
 	}
 }
 
@@ -53,13 +62,13 @@ func (service *TripImageService) CreateTripImage(ctx *gin.Context, userId int64,
 		return error_utils.ErrorCode.TRIP_NOT_FOUND
 	}
 
-	// check if user is member of the trip
-	isMember, err := service.tripMemberRepository.IsUserInTripQuery(ctx, tripId, userId, tx)
+	// check if user may upload photos to the trip
+	role, err := service.tripMemberRepository.GetMemberRoleQuery(ctx, tripId, userId, tx)
 	if err != nil {
-		log.Error("TripImageService.CreateTripImage IsUserInTripQuery error: " + err.Error())
+		log.Error("TripImageService.CreateTripImage GetMemberRoleQuery error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
-	if !isMember {
+	if !trippermissionutils.CanUploadPhotos(role) {
 		return error_utils.ErrorCode.FORBIDDEN
 	}
 
@@ -80,6 +89,7 @@ func (service *TripImageService) CreateTripImage(ctx *gin.Context, userId int64,
 		ImageURL:   tripImageRequest.ImageURL,
 		UserID:     userId,
 		TripItemID: tripImageRequest.TripItemID,
+		CreatedAt:  time.Now(),
 	}
 	err = service.tripImageRepository.CreateCommand(ctx, tripImage, tx)
 	if err != nil {
@@ -87,6 +97,21 @@ func (service *TripImageService) CreateTripImage(ctx *gin.Context, userId int64,
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 
+	err = recordTripActivity(ctx, service.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     tripId,
+			ActorID:    &userId,
+			Action:     model.TripActivityAction.ImageUploaded,
+			EntityType: model.TripActivityEntityType.Image,
+			EntityID:   &tripImage.ID,
+		},
+		after: tripImageActivityValue(*tripImage),
+	}, tx)
+	if err != nil {
+		log.Error("TripImageService.CreateTripImage recordTripActivity error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
 	// commit transaction
 	err = service.unitOfWork.Commit(tx)
 	if err != nil {
@@ -160,22 +185,55 @@ func (service *TripImageService) DeleteTripImage(ctx *gin.Context, userId int64,
 		return error_utils.ErrorCode.TRIP_NOT_FOUND
 	}
 
-	// check if user is admin of the trip
-	isAdmin, err := service.tripMemberRepository.IsUserTripAdminQuery(ctx, tripId, userId, tx)
+	// check if user may delete photos of the trip
+	role, err := service.tripMemberRepository.GetMemberRoleQuery(ctx, tripId, userId, tx)
 	if err != nil {
-		log.Error("TripImageService.DeleteTripImage IsUserTripAdminQuery error: " + err.Error())
+		log.Error("TripImageService.DeleteTripImage GetMemberRoleQuery error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
-	if !isAdmin {
+	if !trippermissionutils.CanDeletePhotos(role) {
 		return error_utils.ErrorCode.FORBIDDEN
 	}
 
+	tripImage, err := service.tripImageRepository.GetOneByIDQuery(ctx, tripId, imageId, tx)
+	if err != nil {
+		log.Error("TripImageService.DeleteTripImage GetOneByIDQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if tripImage == nil {
+		return error_utils.ErrorCode.BAD_REQUEST
+	}
+
 	// delete trip image
 	err = service.tripImageRepository.DeleteOneByIDCommand(ctx, imageId, tx)
 	if err != nil {
 		log.Error("TripImageService.DeleteTripImage DeleteOneByIDCommand error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
+	err = service.syncTombstoneRepository.CreateCommand(ctx, &entity.SyncTombstone{
+		EntityType: entity.SyncEntityType.TripImage,
+		EntityID:   imageId,
+		TripID:     &tripId,
+	}, tx)
+	if err != nil {
+		log.Error("TripImageService.DeleteTripImage CreateCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	err = recordTripActivity(ctx, service.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     tripId,
+			ActorID:    &userId,
+			Action:     model.TripActivityAction.ImageDeleted,
+			EntityType: model.TripActivityEntityType.Image,
+			EntityID:   &imageId,
+		},
+		before: tripImageActivityValue(*tripImage),
+	}, tx)
+	if err != nil {
+		log.Error("TripImageService.DeleteTripImage recordTripActivity error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
 
 	// commit transaction
 	err = service.unitOfWork.Commit(tx)
@@ -288,3 +346,13 @@ func (service *TripImageService) GetAllByTripIDAndTripItemID(ctx *gin.Context, u
 
 	return tripImageResponses, ""
 }
+
+func tripImageActivityValue(tripImage entity.TripImage) model.TripImageResponse {
+	return model.TripImageResponse{
+		ID:         tripImage.ID,
+		TripID:     tripImage.TripID,
+		TripItemID: tripImage.TripItemID,
+		ImageURL:   tripImage.ImageURL,
+		CreatedAt:  tripImage.CreatedAt,
+	}
+}
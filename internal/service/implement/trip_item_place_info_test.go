@@ -0,0 +1,98 @@
+package serviceimplement
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchPlaceInfos(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		placeID := strings.TrimPrefix(r.URL.Path, "/")
+		mu.Lock()
+		requests[placeID]++
+		mu.Unlock()
+		if placeID == "unknown" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"status": 200,
+			"data":   map[string]any{"id": placeID, "name": "Place " + placeID + " " + r.URL.Query().Get("language")},
+		})
+	}))
+	defer server.Close()
+	t.Setenv("PLACE_INFO_URL", server.URL)
+	t.Setenv("CORE_SECRET_KEY", "secret")
+
+	tests := []struct {
+		name     string
+		placeIDs []string
+		want     map[string]string
+	}{
+		{
+			name:     "no places",
+			placeIDs: nil,
+			want:     map[string]string{},
+		},
+		{
+			name:     "repeated places are looked up once",
+			placeIDs: []string{"a", "b", "a", "c", "d", "b"},
+			want:     map[string]string{"a": "Place a vi", "b": "Place b vi", "c": "Place c vi", "d": "Place d vi"},
+		},
+		{
+			name:     "places core cannot resolve are left out",
+			placeIDs: []string{"e", "unknown"},
+			want:     map[string]string{"e": "Place e vi"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			clear(requests)
+			mu.Unlock()
+
+			got := fetchPlaceInfos(tt.placeIDs, "vi")
+			if len(got) != len(tt.want) {
+				t.Fatalf("fetchPlaceInfos() returned %d places, want %d", len(got), len(tt.want))
+			}
+			for placeID, name := range tt.want {
+				if got[placeID] == nil || got[placeID].Name != name {
+					t.Errorf("fetchPlaceInfos()[%q] = %+v, want name %q", placeID, got[placeID], name)
+				}
+			}
+			mu.Lock()
+			for placeID, count := range requests {
+				if count != 1 {
+					t.Errorf("place %q was requested %d times, want once", placeID, count)
+				}
+			}
+			mu.Unlock()
+		})
+	}
+
+	if maxInFlight < 2 {
+		t.Errorf("at most %d lookups ran at once, want them to overlap", maxInFlight)
+	}
+	if maxInFlight > placeInfoWorkers {
+		t.Errorf("%d lookups ran at once, want at most %d", maxInFlight, placeInfoWorkers)
+	}
+}
@@ -0,0 +1,131 @@
+package serviceimplement
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+// placeTable mimics trip_items with its unique place_id
+type placeTable struct {
+	places map[int64]string
+	nextID int64
+}
+
+func (table *placeTable) write(id int64, placeID string) error {
+	for otherID, otherPlace := range table.places {
+		if otherID != id && otherPlace == placeID {
+			return fmt.Errorf("duplicate place %s", placeID)
+		}
+	}
+	if id == 0 {
+		table.nextID++
+		id = table.nextID
+	}
+	table.places[id] = placeID
+	return nil
+}
+
+// saveItems replays the writes of CreateTripItems in the same order
+func saveItems(previousItems []entity.TripItem, requests []model.TripItemRequest) (map[int64]string, error) {
+	table := &placeTable{places: make(map[int64]string), nextID: 100}
+	for _, item := range previousItems {
+		table.places[item.ID] = item.PlaceID
+	}
+
+	matchedIDs, ok := matchTripItems(previousItems, requests)
+	if !ok {
+		return nil, fmt.Errorf("requests do not match the items")
+	}
+	kept := make(map[int64]bool)
+	for _, id := range matchedIDs {
+		kept[id] = true
+	}
+	for _, item := range previousItems {
+		if !kept[item.ID] {
+			delete(table.places, item.ID)
+		}
+	}
+	for _, id := range movedPlaceItemIDs(previousItems, requests, matchedIDs) {
+		table.places[id] = fmt.Sprintf("released:%d", id)
+	}
+	for i, request := range requests {
+		if err := table.write(matchedIDs[i], request.PlaceID); err != nil {
+			return nil, err
+		}
+	}
+	return table.places, nil
+}
+
+func TestSaveTripItemsFreesPlacesFirst(t *testing.T) {
+	id := func(v int64) *int64 { return &v }
+	previousItems := []entity.TripItem{
+		{ID: 1, PlaceID: "p1", TripDay: 1, OrderInDay: 1},
+		{ID: 2, PlaceID: "p2", TripDay: 1, OrderInDay: 2},
+		{ID: 3, PlaceID: "p3", TripDay: 1, OrderInDay: 3},
+	}
+
+	tests := []struct {
+		name      string
+		requests  []model.TripItemRequest
+		want      map[int64]string
+		wantMoved []int64
+	}{
+		{
+			name: "kept items swap places",
+			requests: []model.TripItemRequest{
+				{ID: id(1), PlaceID: "p2", TripDay: 1, OrderInDay: 1},
+				{ID: id(2), PlaceID: "p1", TripDay: 1, OrderInDay: 2},
+				{ID: id(3), PlaceID: "p3", TripDay: 1, OrderInDay: 3},
+			},
+			want:      map[int64]string{1: "p2", 2: "p1", 3: "p3"},
+			wantMoved: []int64{1, 2},
+		},
+		{
+			name: "places rotate",
+			requests: []model.TripItemRequest{
+				{ID: id(1), PlaceID: "p2"},
+				{ID: id(2), PlaceID: "p3"},
+				{ID: id(3), PlaceID: "p1"},
+			},
+			want:      map[int64]string{1: "p2", 2: "p3", 3: "p1"},
+			wantMoved: []int64{1, 2, 3},
+		},
+		{
+			name: "new item takes the place a kept item moves off",
+			requests: []model.TripItemRequest{
+				{PlaceID: "p1", TripDay: 2, OrderInDay: 1},
+				{ID: id(1), PlaceID: "p4", TripDay: 1, OrderInDay: 1},
+			},
+			want:      map[int64]string{1: "p4", 101: "p1"},
+			wantMoved: []int64{1},
+		},
+		{
+			name: "unchanged places are left alone",
+			requests: []model.TripItemRequest{
+				{PlaceID: "p1", TripDay: 1, OrderInDay: 1},
+				{PlaceID: "p2", TripDay: 1, OrderInDay: 2},
+			},
+			want:      map[int64]string{1: "p1", 2: "p2"},
+			wantMoved: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matchedIDs, _ := matchTripItems(previousItems, tt.requests)
+			if moved := movedPlaceItemIDs(previousItems, tt.requests, matchedIDs); !reflect.DeepEqual(moved, tt.wantMoved) {
+				t.Errorf("movedPlaceItemIDs() = %v, want %v", moved, tt.wantMoved)
+			}
+			got, err := saveItems(previousItems, tt.requests)
+			if err != nil {
+				t.Fatalf("save error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("saved places = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
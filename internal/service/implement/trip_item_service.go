@@ -3,46 +3,73 @@ package serviceimplement
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/bean"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	currencyutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/currency_utils"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/env"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	timezoneutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/timezone_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
 )
 
 type TripItemService struct {
-	tripItemRepository   repository.TripItemRepository
-	tripRepository       repository.TripRepository
-	tripMemberRepository repository.TripMemberRepository
-	unitOfWork           repository.UnitOfWork
+	tripItemRepository      repository.TripItemRepository
+	tripRepository          repository.TripRepository
+	tripMemberRepository    repository.TripMemberRepository
+	exchangeRateRepository  repository.ExchangeRateRepository
+	unitOfWork              repository.UnitOfWork
+	routingProvider         bean.RoutingProvider
+	tripRealtimeService     service.TripRealtimeService
+	tripActivityRepository  repository.TripActivityRepository
+	syncTombstoneRepository repository.SyncTombstoneRepository
 }
 
 func NewTripItemService(
 	tripItemRepository repository.TripItemRepository,
 	tripRepository repository.TripRepository,
 	tripMemberRepository repository.TripMemberRepository,
+	exchangeRateRepository repository.ExchangeRateRepository,
 	unitOfWork repository.UnitOfWork,
+	routingProvider bean.RoutingProvider,
+	tripRealtimeService service.TripRealtimeService,
+	tripActivityRepository repository.TripActivityRepository,
+	syncTombstoneRepository repository.SyncTombstoneRepository,
 ) service.TripItemService {
 	return &TripItemService{
-		tripItemRepository:   tripItemRepository,
-		tripRepository:       tripRepository,
-		tripMemberRepository: tripMemberRepository,
-		unitOfWork:           unitOfWork,
+		tripItemRepository:      tripItemRepository,
+		tripRepository:          tripRepository,
+		tripMemberRepository:    tripMemberRepository,
+		exchangeRateRepository:  exchangeRateRepository,
+		unitOfWork:              unitOfWork,
+		routingProvider:         routingProvider,
+		tripRealtimeService:     tripRealtimeService,
+		tripActivityRepository:  tripActivityRepository,
+		syncTombstoneRepository: syncTombstoneRepository,
 	}
 }
 
-func (service *TripItemService) CreateTripItems(ctx *gin.Context, userId int64, tripId int64, tripItemRequests []model.TripItemRequest) string {
+func (service *TripItemService) CreateTripItems(ctx *gin.Context, userId int64, tripId int64, tripItemRequests []model.TripItemRequest) ([]model.TripValidationIssue, string) {
+	// places are looked up in the core before the trip row is locked
+	placeInfos := fetchMissingCostPlaceInfos(tripItemRequests)
+
 	// begin transaction
 	tx, err := service.unitOfWork.Begin(ctx)
 	if err != nil {
 		log.Error("TripItemService.CreateTripItems Begin error: " + err.Error())
-		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 	defer service.unitOfWork.Rollback(tx)
 
@@ -50,52 +77,130 @@ func (service *TripItemService) CreateTripItems(ctx *gin.Context, userId int64,
 	trip, err := service.tripRepository.SelectForUpdateById(ctx, tripId, tx)
 	if err != nil {
 		log.Error("TripItemService.CreateTripItems LockTripRowByIDCommand error: " + err.Error())
-		return error_utils.ErrorCode.DB_DOWN
+		return nil, error_utils.ErrorCode.DB_DOWN
 	}
 	if trip == nil {
-		return error_utils.ErrorCode.TRIP_NOT_FOUND
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
 	}
 
 	// check to see if trip exists
 	trip, err = service.tripRepository.GetOneByIDQuery(ctx, tripId, tx)
 	if err != nil {
 		log.Error("TripItemService.CreateTripItems GetOneByIDQuery error: " + err.Error())
-		return error_utils.ErrorCode.DB_DOWN
+		return nil, error_utils.ErrorCode.DB_DOWN
 	}
 	if trip == nil {
-		return error_utils.ErrorCode.TRIP_NOT_FOUND
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
 	}
 
-	// check if user is admin
-	isAdmin, err := service.tripMemberRepository.IsUserTripAdminQuery(ctx, tripId, userId, tx)
+	// check if user may edit the itinerary
+	role, err := service.tripMemberRepository.GetMemberRoleQuery(ctx, tripId, userId, tx)
 	if err != nil {
-		log.Error("TripItemService.CreateTripItems IsUserTripAdminQuery error: " + err.Error())
-		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		log.Error("TripItemService.CreateTripItems GetMemberRoleQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
-	if !isAdmin {
-		return error_utils.ErrorCode.FORBIDDEN
+	if !trippermissionutils.CanEditItems(role) {
+		return nil, error_utils.ErrorCode.FORBIDDEN
 	}
 
-	// delete existing trip items
-	err = service.tripItemRepository.DeleteByTripIDCommand(ctx, tripId, tx)
+	// reject schedules that cannot work before touching the existing items
+	scheduledItems := make([]scheduledItem, 0, len(tripItemRequests))
+	for _, tripItemRequest := range tripItemRequests {
+		item, ok := scheduledItemFromRequest(tripItemRequest)
+		if !ok {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+		scheduledItems = append(scheduledItems, item)
+	}
+	// the issues are returned with the error, the validation endpoint only checks what is already saved
+	issues := checkSchedule(scheduledItems, trip.Days)
+	for _, issue := range issues {
+		if issue.Severity == model.TripValidationSeverity.Error {
+			return issues, error_utils.ErrorCode.TRIP_ITEM_SCHEDULE_CONFLICT
+		}
+	}
+
+	service.prefillItemCosts(ctx, trip, tripItemRequests, placeInfos, tx)
+
+	// items keep their id across saves, so comments, photos, documents and poll targets stay attached to them
+	previousItems, err := service.tripItemRepository.GetTripItemsByTripIDCommand(ctx, tripId, userId, tx)
 	if err != nil {
-		log.Error("TripItemService.CreateTripItems DeleteByTripIDCommand error: " + err.Error())
-		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		log.Error("TripItemService.CreateTripItems GetTripItemsByTripIDCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	matchedIDs, ok := matchTripItems(previousItems, tripItemRequests)
+	if !ok {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
 	}
 
-	// insert new trip items
-	for _, tripItemRequest := range tripItemRequests {
+	// delete the items left out first, so their places are free for the ones written below,
+	// leaving tombstones for offline clients
+	kept := make(map[int64]bool, len(matchedIDs))
+	for _, id := range matchedIDs {
+		kept[id] = true
+	}
+	for _, previousItem := range previousItems {
+		if kept[previousItem.ID] {
+			continue
+		}
+		err = service.syncTombstoneRepository.CreateCommand(ctx, &entity.SyncTombstone{
+			EntityType: entity.SyncEntityType.TripItem,
+			EntityID:   previousItem.ID,
+			TripID:     &tripId,
+		}, tx)
+		if err != nil {
+			log.Error("TripItemService.CreateTripItems CreateCommand error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		err = service.tripItemRepository.DeleteByIDCommand(ctx, tripId, previousItem.ID, tx)
+		if err != nil {
+			log.Error("TripItemService.CreateTripItems DeleteByIDCommand error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+	}
+
+	// a place belongs to a single item, so kept items that move free their old place first,
+	// otherwise two items swapping places or a new item taking a vacated place hit the unique key
+	err = service.tripItemRepository.ReleasePlaceIDsCommand(ctx, tripId, movedPlaceItemIDs(previousItems, tripItemRequests, matchedIDs), tx)
+	if err != nil {
+		log.Error("TripItemService.CreateTripItems ReleasePlaceIDsCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	// update the kept items in place and insert the new ones
+	tripItems := make([]entity.TripItem, 0, len(tripItemRequests))
+	for i, tripItemRequest := range tripItemRequests {
 		tripItem := &entity.TripItem{
-			TripID:     tripId,
-			PlaceID:    tripItemRequest.PlaceID,
-			TripDay:    tripItemRequest.TripDay,
-			OrderInDay: tripItemRequest.OrderInDay,
-			TimeInDate: tripItemRequest.TimeInDate,
+			ID:            matchedIDs[i],
+			TripID:        tripId,
+			PlaceID:       tripItemRequest.PlaceID,
+			TripDay:       tripItemRequest.TripDay,
+			OrderInDay:    tripItemRequest.OrderInDay,
+			TimeInDate:    tripItemRequest.TimeInDate,
+			Note:          tripItemRequest.Note,
+			StartTime:     formatClock(scheduledItems[i].Start),
+			EndTime:       formatClock(scheduledItems[i].End),
+			EstimatedCost: tripItemRequest.EstimatedCost,
+			CostCategory:  tripItemRequest.CostCategory,
+		}
+		if tripItem.ID != 0 {
+			err = service.tripItemRepository.UpdateCommand(ctx, tripItem, tx)
+		} else {
+			err = service.tripItemRepository.CreateCommand(ctx, tripItem, tx)
 		}
-		err := service.tripItemRepository.CreateCommand(ctx, tripItem, tx)
 		if err != nil {
 			log.Error("TripItemService.CreateTripItems Error: " + err.Error())
-			return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		tripItems = append(tripItems, *tripItem)
+	}
+
+	diff := compareTripItems(previousItems, tripItems)
+	for _, record := range diff.activities(tripId, &userId) {
+		err = recordTripActivity(ctx, service.tripActivityRepository, record, tx)
+		if err != nil {
+			log.Error("TripItemService.CreateTripItems recordTripActivity error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 		}
 	}
 
@@ -103,13 +208,203 @@ func (service *TripItemService) CreateTripItems(ctx *gin.Context, userId int64,
 	err = service.unitOfWork.Commit(tx)
 	if err != nil {
 		log.Error("TripItemService.CreateTripItems Commit error: " + err.Error())
-		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 
-	return ""
+	if changes := diff.changes(); !changes.IsEmpty() {
+		service.tripRealtimeService.PublishEvent(ctx, tripId, &userId, model.TripEventType.ItemsChanged, changes)
+	}
+
+	return nil, ""
 }
 
-func (service *TripItemService) GetTripItemsByTripID(ctx *gin.Context, userId int64, tripId int64) ([]model.TripItemResponse, string) {
+// matchTripItems returns, for each request, the id of the existing item it rewrites, or 0 for a new item.
+// A request names its item by id; one without an id takes over the existing item of the same place, so clients
+// that send the whole list without ids, such as offline sync, still keep what is attached to their items.
+// It reports false when a request names an item that is not in the trip or that another request already took.
+func matchTripItems(previousItems []entity.TripItem, tripItemRequests []model.TripItemRequest) ([]int64, bool) {
+	matchedIDs := make([]int64, len(tripItemRequests))
+	previousByID := make(map[int64]entity.TripItem, len(previousItems))
+	for _, item := range previousItems {
+		previousByID[item.ID] = item
+	}
+
+	claimed := make(map[int64]bool, len(tripItemRequests))
+	for i, tripItemRequest := range tripItemRequests {
+		if tripItemRequest.ID == nil {
+			continue
+		}
+		if _, ok := previousByID[*tripItemRequest.ID]; !ok || claimed[*tripItemRequest.ID] {
+			return nil, false
+		}
+		claimed[*tripItemRequest.ID] = true
+		matchedIDs[i] = *tripItemRequest.ID
+	}
+
+	for i, tripItemRequest := range tripItemRequests {
+		if tripItemRequest.ID != nil {
+			continue
+		}
+		// prefer the item at the same position so duplicates of a place are not swapped
+		var match int64
+		for _, item := range previousItems {
+			if claimed[item.ID] || item.PlaceID != tripItemRequest.PlaceID {
+				continue
+			}
+			if match == 0 || (item.TripDay == tripItemRequest.TripDay && item.OrderInDay == tripItemRequest.OrderInDay) {
+				match = item.ID
+			}
+		}
+		if match != 0 {
+			claimed[match] = true
+			matchedIDs[i] = match
+		}
+	}
+	return matchedIDs, true
+}
+
+// movedPlaceItemIDs returns the kept items whose request names another place than the one they hold
+func movedPlaceItemIDs(previousItems []entity.TripItem, tripItemRequests []model.TripItemRequest, matchedIDs []int64) []int64 {
+	placeByID := make(map[int64]string, len(previousItems))
+	for _, item := range previousItems {
+		placeByID[item.ID] = item.PlaceID
+	}
+
+	var moved []int64
+	for i, tripItemRequest := range tripItemRequests {
+		if matchedIDs[i] != 0 && placeByID[matchedIDs[i]] != tripItemRequest.PlaceID {
+			moved = append(moved, matchedIDs[i])
+		}
+	}
+	return moved
+}
+
+// tripItemDiff pairs old and saved items by id.
+// A paired item that changed position is moved, one that only changed its details or place is edited.
+type tripItemDiff struct {
+	added   []entity.TripItem
+	removed []entity.TripItem
+	// moved and edited hold the previous and the saved version of each item
+	moved  [][2]entity.TripItem
+	edited [][2]entity.TripItem
+}
+
+func compareTripItems(previousItems []entity.TripItem, tripItems []entity.TripItem) tripItemDiff {
+	var diff tripItemDiff
+
+	previousByID := make(map[int64]entity.TripItem, len(previousItems))
+	for _, item := range previousItems {
+		previousByID[item.ID] = item
+	}
+
+	saved := make(map[int64]bool, len(tripItems))
+	for _, item := range tripItems {
+		saved[item.ID] = true
+		previous, ok := previousByID[item.ID]
+		if !ok {
+			diff.added = append(diff.added, item)
+			continue
+		}
+
+		switch {
+		case previous.TripDay != item.TripDay || previous.OrderInDay != item.OrderInDay:
+			diff.moved = append(diff.moved, [2]entity.TripItem{previous, item})
+		case previous.PlaceID != item.PlaceID ||
+			previous.TimeInDate != item.TimeInDate ||
+			!equalStringPtr(previous.Note, item.Note) ||
+			!equalStringPtr(trimClockSeconds(previous.StartTime), trimClockSeconds(item.StartTime)) ||
+			!equalStringPtr(trimClockSeconds(previous.EndTime), trimClockSeconds(item.EndTime)):
+			diff.edited = append(diff.edited, [2]entity.TripItem{previous, item})
+		}
+	}
+
+	for _, item := range previousItems {
+		if !saved[item.ID] {
+			diff.removed = append(diff.removed, item)
+		}
+	}
+	return diff
+}
+
+func (diff tripItemDiff) changes() model.TripItemsChangedData {
+	changes := model.TripItemsChangedData{
+		Added:   make([]model.TripItemChange, 0, len(diff.added)),
+		Removed: make([]model.TripItemChange, 0, len(diff.removed)),
+		Moved:   make([]model.TripItemChange, 0, len(diff.moved)),
+		Edited:  make([]model.TripItemChange, 0, len(diff.edited)),
+	}
+	for _, item := range diff.added {
+		changes.Added = append(changes.Added, toTripItemChange(item))
+	}
+	for _, item := range diff.removed {
+		changes.Removed = append(changes.Removed, toTripItemChange(item))
+	}
+	for _, pair := range diff.moved {
+		change := toTripItemChange(pair[1])
+		change.FromTripDay = &pair[0].TripDay
+		change.FromOrderInDay = &pair[0].OrderInDay
+		changes.Moved = append(changes.Moved, change)
+	}
+	for _, pair := range diff.edited {
+		changes.Edited = append(changes.Edited, toTripItemChange(pair[1]))
+	}
+	return changes
+}
+
+// activities records one audit entry per changed item
+func (diff tripItemDiff) activities(tripId int64, actorId *int64) []tripActivityRecord {
+	records := make([]tripActivityRecord, 0, len(diff.added)+len(diff.removed)+len(diff.moved)+len(diff.edited))
+	for _, item := range diff.added {
+		records = append(records, newTripItemActivity(tripId, actorId, model.TripActivityAction.ItemAdded, item.ID, nil, toTripItemChange(item)))
+	}
+	for _, item := range diff.removed {
+		records = append(records, newTripItemActivity(tripId, actorId, model.TripActivityAction.ItemRemoved, item.ID, toTripItemChange(item), nil))
+	}
+	for _, pair := range diff.moved {
+		records = append(records, newTripItemActivity(tripId, actorId, model.TripActivityAction.ItemMoved, pair[1].ID, toTripItemChange(pair[0]), toTripItemChange(pair[1])))
+	}
+	for _, pair := range diff.edited {
+		records = append(records, newTripItemActivity(tripId, actorId, model.TripActivityAction.ItemEdited, pair[1].ID, toTripItemChange(pair[0]), toTripItemChange(pair[1])))
+	}
+	return records
+}
+
+func newTripItemActivity(tripId int64, actorId *int64, action string, tripItemId int64, before interface{}, after interface{}) tripActivityRecord {
+	return tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     tripId,
+			ActorID:    actorId,
+			Action:     action,
+			EntityType: model.TripActivityEntityType.TripItem,
+			EntityID:   &tripItemId,
+		},
+		before: before,
+		after:  after,
+	}
+}
+
+func toTripItemChange(item entity.TripItem) model.TripItemChange {
+	return model.TripItemChange{
+		PlaceID:    item.PlaceID,
+		TripDay:    item.TripDay,
+		OrderInDay: item.OrderInDay,
+		TimeInDate: item.TimeInDate,
+		Note:       item.Note,
+		StartTime:  trimClockSeconds(item.StartTime),
+		EndTime:    trimClockSeconds(item.EndTime),
+	}
+}
+
+func equalStringPtr(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// GetTripItemsByTripID returns the items with their place info, and the travel estimates between
+// consecutive stops when a travel mode is given
+func (service *TripItemService) GetTripItemsByTripID(ctx *gin.Context, userId int64, tripId int64, travelMode string) ([]model.TripItemResponse, string) {
 	lang := ctx.DefaultQuery("language", "vi")
 
 	// Get trip items with membership check
@@ -122,30 +417,636 @@ func (service *TripItemService) GetTripItemsByTripID(ctx *gin.Context, userId in
 		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 
+	placeIDs := make([]string, len(tripItems))
+	for i, item := range tripItems {
+		placeIDs[i] = item.PlaceID
+	}
+	placeInfos := fetchPlaceInfos(placeIDs, lang)
+
 	// Convert to response model
 	var tripItemResponses []model.TripItemResponse
 	for _, item := range tripItems {
 		tripItemResponse := model.TripItemResponse{
-			ID:         item.ID,
-			TripID:     item.TripID,
+			ID:            item.ID,
+			TripID:        item.TripID,
+			PlaceID:       item.PlaceID,
+			TripDay:       item.TripDay,
+			OrderInDay:    item.OrderInDay,
+			TimeInDate:    item.TimeInDate,
+			Note:          item.Note,
+			StartTime:     trimClockSeconds(item.StartTime),
+			EndTime:       trimClockSeconds(item.EndTime),
+			EstimatedCost: item.EstimatedCost,
+			CostCategory:  item.CostCategory,
+		}
+
+		tripItemResponse.PlaceInfo = placeInfos[item.PlaceID]
+
+		tripItemResponses = append(tripItemResponses, tripItemResponse)
+	}
+
+	if travelMode != "" {
+		service.estimateTravel(ctx, tripItemResponses, travelMode)
+	}
+
+	return tripItemResponses, ""
+}
+
+func (service *TripItemService) GetTripTravelSummary(ctx *gin.Context, userId int64, tripId int64, mode string) (*model.TripTravelSummaryResponse, string) {
+	tripItems, errCode := service.GetTripItemsByTripID(ctx, userId, tripId, "")
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	days := service.estimateTravel(ctx, tripItems, mode)
+
+	response := &model.TripTravelSummaryResponse{
+		Mode: mode,
+		Days: days,
+	}
+	for _, day := range days {
+		response.TotalDistanceMeters += day.TotalDistanceMeters
+		response.TotalDurationSeconds += day.TotalDurationSeconds
+	}
+
+	return response, ""
+}
+
+// estimateTravel sets TravelFromPrevious on every item that follows another stop of the same day
+// and returns the per-day totals. Items without coordinates break the chain and are reported.
+func (service *TripItemService) estimateTravel(ctx *gin.Context, tripItems []model.TripItemResponse, mode string) []model.TripDayTravelSummary {
+	indexesByDay := make(map[int64][]int)
+	var tripDays []int64
+	for i, item := range tripItems {
+		if _, ok := indexesByDay[item.TripDay]; !ok {
+			tripDays = append(tripDays, item.TripDay)
+		}
+		indexesByDay[item.TripDay] = append(indexesByDay[item.TripDay], i)
+	}
+	sort.Slice(tripDays, func(i, j int) bool { return tripDays[i] < tripDays[j] })
+
+	days := make([]model.TripDayTravelSummary, 0, len(tripDays))
+	for _, tripDay := range tripDays {
+		indexes := indexesByDay[tripDay]
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return tripItems[indexes[i]].OrderInDay < tripItems[indexes[j]].OrderInDay
+		})
+
+		summary := model.TripDayTravelSummary{
+			TripDay:           tripDay,
+			Legs:              make([]model.TravelLeg, 0),
+			UnresolvedItemIDs: make([]int64, 0),
+		}
+
+		var previous *model.TripItemResponse
+		for _, index := range indexes {
+			current := &tripItems[index]
+			if !hasLocation(current.PlaceInfo) {
+				summary.UnresolvedItemIDs = append(summary.UnresolvedItemIDs, current.ID)
+				previous = nil
+				continue
+			}
+
+			if previous != nil {
+				from := bean.LatLng{Lat: previous.PlaceInfo.Location.Lat, Long: previous.PlaceInfo.Location.Long}
+				to := bean.LatLng{Lat: current.PlaceInfo.Location.Lat, Long: current.PlaceInfo.Location.Long}
+				distance, duration, err := service.routingProvider.Estimate(ctx, from, to, mode)
+				if err != nil {
+					log.Error("TripItemService.estimateTravel Estimate error: " + err.Error())
+				} else {
+					leg := model.TravelLeg{
+						FromTripItemID:  previous.ID,
+						ToTripItemID:    current.ID,
+						Mode:            mode,
+						DistanceMeters:  math.Round(distance),
+						DurationSeconds: int64(duration.Seconds()),
+					}
+					current.TravelFromPrevious = &leg
+					summary.Legs = append(summary.Legs, leg)
+					summary.TotalDistanceMeters += leg.DistanceMeters
+					summary.TotalDurationSeconds += leg.DurationSeconds
+				}
+			}
+			previous = current
+		}
+
+		days = append(days, summary)
+	}
+
+	return days
+}
+
+func hasLocation(placeInfo *model.PlaceInfo) bool {
+	return placeInfo != nil && (placeInfo.Location.Lat != 0 || placeInfo.Location.Long != 0)
+}
+
+func (service *TripItemService) GetTripRoute(ctx *gin.Context, userId int64, tripId int64) (*model.TripRoute, string) {
+	// Get trip items with membership check and resolved place info
+	tripItems, errCode := service.GetTripItemsByTripID(ctx, userId, tripId, "")
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripItemService.GetTripRoute GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	sort.SliceStable(tripItems, func(i, j int) bool {
+		if tripItems[i].TripDay != tripItems[j].TripDay {
+			return tripItems[i].TripDay < tripItems[j].TripDay
+		}
+		return tripItems[i].OrderInDay < tripItems[j].OrderInDay
+	})
+
+	route := &model.TripRoute{
+		TripID:          trip.ID,
+		Title:           trip.Title,
+		City:            trip.City,
+		Days:            make([]model.TripRouteDay, 0),
+		UnresolvedItems: make([]model.TripRouteUnresolvedItem, 0),
+	}
+
+	// time_in_date slots are wall clock hours in the trip's zone
+	startDate := timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, 1)
+	for _, item := range tripItems {
+		// keep track of items we cannot place on a map instead of dropping them
+		if !hasLocation(item.PlaceInfo) {
+			route.UnresolvedItems = append(route.UnresolvedItems, model.TripRouteUnresolvedItem{
+				TripItemID: item.ID,
+				PlaceID:    item.PlaceID,
+				TripDay:    item.TripDay,
+				OrderInDay: item.OrderInDay,
+			})
+			continue
+		}
+
+		if len(route.Days) == 0 || route.Days[len(route.Days)-1].TripDay != item.TripDay {
+			route.Days = append(route.Days, model.TripRouteDay{
+				TripDay:   item.TripDay,
+				Date:      startDate.AddDate(0, 0, int(item.TripDay-1)),
+				Waypoints: make([]model.TripRouteWaypoint, 0),
+			})
+		}
+		day := &route.Days[len(route.Days)-1]
+
+		day.Waypoints = append(day.Waypoints, model.TripRouteWaypoint{
+			TripItemID: item.ID,
 			PlaceID:    item.PlaceID,
-			TripDay:    item.TripDay,
+			Name:       item.PlaceInfo.Name,
+			Address:    item.PlaceInfo.Address,
 			OrderInDay: item.OrderInDay,
 			TimeInDate: item.TimeInDate,
+			Time:       day.Date.Add(time.Duration(model.TimeInDateStartHour[item.TimeInDate]) * time.Hour),
+			Lat:        item.PlaceInfo.Location.Lat,
+			Long:       item.PlaceInfo.Location.Long,
+		})
+	}
+
+	return route, ""
+}
+
+func (service *TripItemService) OptimizeTripDay(ctx *gin.Context, userId int64, tripId int64, tripDay int64, request model.OptimizeTripDayRequest) (*model.OptimizeTripDayResponse, string) {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripItemService.OptimizeTripDay GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+	if tripDay < 1 || tripDay > int64(trip.Days) {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	// only those who can reorder the itinerary get a preview
+	role, err := service.tripMemberRepository.GetMemberRoleQuery(ctx, tripId, userId, nil)
+	if err != nil {
+		log.Error("TripItemService.OptimizeTripDay GetMemberRoleQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if !trippermissionutils.CanEditItems(role) {
+		return nil, error_utils.ErrorCode.FORBIDDEN
+	}
+
+	tripItems, errCode := service.GetTripItemsByTripID(ctx, userId, tripId, "")
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	var dayItems []model.TripItemResponse
+	for _, item := range tripItems {
+		if item.TripDay == tripDay {
+			dayItems = append(dayItems, item)
 		}
+	}
+	sort.SliceStable(dayItems, func(i, j int) bool { return dayItems[i].OrderInDay < dayItems[j].OrderInDay })
 
-		// Fetch place info from external API
-		placeInfo, err := fetchPlaceInfo(item.PlaceID, lang)
-		if err == nil {
-			tripItemResponse.PlaceInfo = placeInfo
-		} else {
-			tripItemResponse.PlaceInfo = nil
+	// slots are handed out by position, so the day keeps the same set of time_in_date values
+	slots := make([]string, len(dayItems))
+	itemSlots := make([]string, len(dayItems))
+	indexByID := make(map[int64]int)
+	for i, item := range dayItems {
+		slots[i] = item.TimeInDate
+		itemSlots[i] = item.TimeInDate
+		indexByID[item.ID] = i
+	}
+	sort.SliceStable(slots, func(i, j int) bool { return model.TimeInDateStartHour[slots[i]] < model.TimeInDateStartHour[slots[j]] })
+
+	problem := dayOrderProblem{
+		fixedAt:   make([]int, len(dayItems)),
+		pinned:    make([]bool, len(dayItems)),
+		slots:     slots,
+		itemSlots: itemSlots,
+		startIdx:  -1,
+		endIdx:    -1,
+	}
+	for i := range problem.fixedAt {
+		problem.fixedAt[i] = -1
+	}
+
+	response := &model.OptimizeTripDayResponse{
+		TripDay:           tripDay,
+		TravelMode:        request.TravelMode,
+		Items:             make([]model.OptimizedTripItem, 0),
+		UnresolvedItemIDs: make([]int64, 0),
+	}
+
+	// items without coordinates cannot be routed, they stay where they are
+	for i, item := range dayItems {
+		if !hasLocation(item.PlaceInfo) {
+			problem.fixedAt[i] = i
+			response.UnresolvedItemIDs = append(response.UnresolvedItemIDs, item.ID)
 		}
+	}
+	for _, pinnedID := range request.PinnedItemIDs {
+		index, ok := indexByID[pinnedID]
+		if !ok {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+		problem.pinned[index] = true
+	}
+	if request.StartItemID != nil {
+		index, ok := indexByID[*request.StartItemID]
+		if !ok || problem.fixedAt[index] != -1 {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+		problem.startIdx = index
+	}
+	if request.EndItemID != nil {
+		index, ok := indexByID[*request.EndItemID]
+		if !ok || problem.fixedAt[index] != -1 || index == problem.startIdx {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+		problem.endIdx = index
+	}
 
-		tripItemResponses = append(tripItemResponses, tripItemResponse)
+	// distances between every pair of routable stops
+	problem.distance = make([][]float64, len(dayItems))
+	for i := range dayItems {
+		problem.distance[i] = make([]float64, len(dayItems))
+		if problem.fixedAt[i] != -1 {
+			continue
+		}
+		for j := range dayItems {
+			if i == j || problem.fixedAt[j] != -1 {
+				continue
+			}
+			from := bean.LatLng{Lat: dayItems[i].PlaceInfo.Location.Lat, Long: dayItems[i].PlaceInfo.Location.Long}
+			to := bean.LatLng{Lat: dayItems[j].PlaceInfo.Location.Lat, Long: dayItems[j].PlaceInfo.Location.Long}
+			distance, _, err := service.routingProvider.Estimate(ctx, from, to, request.TravelMode)
+			if err != nil {
+				log.Error("TripItemService.OptimizeTripDay Estimate error: " + err.Error())
+				return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+			}
+			problem.distance[i][j] = distance
+		}
 	}
 
-	return tripItemResponses, ""
+	currentOrder := make([]int, len(dayItems))
+	for i := range currentOrder {
+		currentOrder[i] = i
+	}
+	currentDistance := problem.length(currentOrder)
+
+	order, ok := problem.solve()
+	if !ok {
+		return nil, error_utils.ErrorCode.TRIP_DAY_OPTIMIZATION_INFEASIBLE
+	}
+	optimizedDistance := problem.length(order)
+	// the search is bounded on long days, never suggest something worse than what is already there
+	if problem.feasible(currentOrder) && currentDistance <= optimizedDistance {
+		order = currentOrder
+		optimizedDistance = currentDistance
+	}
+
+	date := timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, tripDay)
+	for position, index := range order {
+		item := dayItems[index]
+		name := ""
+		if item.PlaceInfo != nil {
+			name = item.PlaceInfo.Name
+		}
+		response.Items = append(response.Items, model.OptimizedTripItem{
+			TripItemID:    item.ID,
+			PlaceID:       item.PlaceID,
+			Name:          name,
+			OldOrderInDay: item.OrderInDay,
+			NewOrderInDay: int64(position + 1),
+			OldTimeInDate: item.TimeInDate,
+			NewTimeInDate: slots[position],
+			Time:          date.Add(time.Duration(model.TimeInDateStartHour[slots[position]]) * time.Hour),
+		})
+	}
+	response.CurrentDistanceMeters = math.Round(currentDistance)
+	response.OptimizedDistanceMeters = math.Round(optimizedDistance)
+	response.DistanceSavedMeters = response.CurrentDistanceMeters - response.OptimizedDistanceMeters
+
+	return response, ""
+}
+
+// dayOrderSearchLimit bounds the branch and bound search so long days still answer quickly
+const dayOrderSearchLimit = 200000
+
+// dayOrderProblem describes the stops of one day, indexed by their current position
+type dayOrderProblem struct {
+	distance [][]float64
+	// fixedAt is the position an item cannot leave, -1 when it can move
+	fixedAt []int
+	pinned  []bool
+	// slots is the time_in_date handed to each position, itemSlots the one each item has today
+	slots     []string
+	itemSlots []string
+	startIdx  int
+	endIdx    int
+}
+
+// length sums the distance between consecutive routable stops, skipping the ones without coordinates
+func (p *dayOrderProblem) length(order []int) float64 {
+	total := 0.0
+	previous := -1
+	for _, index := range order {
+		if p.fixedAt[index] != -1 {
+			continue
+		}
+		if previous != -1 {
+			total += p.distance[previous][index]
+		}
+		previous = index
+	}
+	return total
+}
+
+func (p *dayOrderProblem) allowed(index int, position int, firstFree int, lastFree int) bool {
+	if p.fixedAt[index] != -1 {
+		return p.fixedAt[index] == position
+	}
+	if p.pinned[index] && p.slots[position] != p.itemSlots[index] {
+		return false
+	}
+	if index == p.startIdx && position != firstFree {
+		return false
+	}
+	if index == p.endIdx && position != lastFree {
+		return false
+	}
+	if position == firstFree && p.startIdx != -1 && index != p.startIdx {
+		return false
+	}
+	if position == lastFree && p.endIdx != -1 && index != p.endIdx {
+		return false
+	}
+	return true
+}
+
+func (p *dayOrderProblem) freeBounds() (int, int) {
+	firstFree, lastFree := -1, -1
+	for position := range p.fixedAt {
+		if p.isFixedPosition(position) {
+			continue
+		}
+		if firstFree == -1 {
+			firstFree = position
+		}
+		lastFree = position
+	}
+	return firstFree, lastFree
+}
+
+func (p *dayOrderProblem) isFixedPosition(position int) bool {
+	for _, fixed := range p.fixedAt {
+		if fixed == position {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *dayOrderProblem) feasible(order []int) bool {
+	firstFree, lastFree := p.freeBounds()
+	for position, index := range order {
+		if !p.allowed(index, position, firstFree, lastFree) {
+			return false
+		}
+	}
+	return true
+}
+
+// solve runs a depth first branch and bound search, trying the nearest stops first
+// so the first complete order found is the greedy nearest neighbour one
+func (p *dayOrderProblem) solve() ([]int, bool) {
+	n := len(p.fixedAt)
+	firstFree, lastFree := p.freeBounds()
+	order := make([]int, 0, n)
+	used := make([]bool, n)
+	// an empty day has an empty best order, so finding one is tracked on its own
+	best := make([]int, 0, n)
+	found := false
+	bestLength := math.Inf(1)
+	visited := 0
+
+	var search func(position int, previous int, length float64)
+	search = func(position int, previous int, length float64) {
+		if visited >= dayOrderSearchLimit || length >= bestLength {
+			return
+		}
+		visited++
+		if position == n {
+			best = append(best[:0], order...)
+			bestLength = length
+			found = true
+			return
+		}
+
+		var candidates []int
+		for index := 0; index < n; index++ {
+			if !used[index] && p.allowed(index, position, firstFree, lastFree) {
+				candidates = append(candidates, index)
+			}
+		}
+		if previous != -1 {
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return p.distance[previous][candidates[i]] < p.distance[previous][candidates[j]]
+			})
+		}
+
+		for _, index := range candidates {
+			step, next := 0.0, previous
+			if p.fixedAt[index] == -1 {
+				if previous != -1 {
+					step = p.distance[previous][index]
+				}
+				next = index
+			}
+			used[index] = true
+			order = append(order, index)
+			search(position+1, next, length+step)
+			order = order[:len(order)-1]
+			used[index] = false
+		}
+	}
+	search(0, -1, 0)
+
+	return best, found
+}
+
+// placeInfoWorkers bounds the concurrent place lookups against core
+const placeInfoWorkers = 8
+
+// fetchPlaceInfos looks up every distinct place concurrently, keyed by place ID.
+// Places core cannot resolve are left out.
+func fetchPlaceInfos(placeIDs []string, lang string) map[string]*model.PlaceInfo {
+	var distinct []string
+	seen := make(map[string]bool, len(placeIDs))
+	for _, placeID := range placeIDs {
+		if !seen[placeID] {
+			seen[placeID] = true
+			distinct = append(distinct, placeID)
+		}
+	}
+
+	results := make([]*model.PlaceInfo, len(distinct))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, placeInfoWorkers)
+	for i, placeID := range distinct {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, placeID string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			placeInfo, err := fetchPlaceInfo(placeID, lang)
+			if err == nil {
+				results[i] = placeInfo
+			}
+		}(i, placeID)
+	}
+	wg.Wait()
+
+	placeInfos := make(map[string]*model.PlaceInfo, len(distinct))
+	for i, placeID := range distinct {
+		if results[i] != nil {
+			placeInfos[placeID] = results[i]
+		}
+	}
+	return placeInfos
+}
+
+// fetchMissingCostPlaceInfos looks up the places of the items that were sent without a cost or category,
+// keyed by the index of the request. Places core cannot resolve are left out.
+func fetchMissingCostPlaceInfos(tripItemRequests []model.TripItemRequest) map[int]*model.PlaceInfo {
+	var missing []int
+	var placeIDs []string
+	for i, request := range tripItemRequests {
+		if request.EstimatedCost == nil || request.CostCategory == nil {
+			missing = append(missing, i)
+			placeIDs = append(placeIDs, request.PlaceID)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// English place types are what costCategoryForPlaceType understands
+	placeInfosByID := fetchPlaceInfos(placeIDs, "en")
+	placeInfos := make(map[int]*model.PlaceInfo, len(missing))
+	for _, i := range missing {
+		if placeInfo, ok := placeInfosByID[tripItemRequests[i].PlaceID]; ok {
+			placeInfos[i] = placeInfo
+		}
+	}
+	return placeInfos
+}
+
+// prefillItemCosts fills in the cost and category of items that were sent without them from the core
+// place data. Places core knows nothing about, or priced in a currency without a rate, stay blank.
+func (service *TripItemService) prefillItemCosts(ctx *gin.Context, trip *entity.Trip, tripItemRequests []model.TripItemRequest, placeInfos map[int]*model.PlaceInfo, tx *sqlx.Tx) {
+	if len(placeInfos) == 0 {
+		return
+	}
+
+	rates, err := service.exchangeRateRepository.GetAllQuery(ctx, tx)
+	if err != nil {
+		log.Error("TripItemService.prefillItemCosts GetAllQuery error: " + err.Error())
+		return
+	}
+	unitsPerUSD := make(map[string]float64, len(rates))
+	for _, rate := range rates {
+		unitsPerUSD[rate.Currency] = rate.UnitsPerUSD
+	}
+
+	for i, placeInfo := range placeInfos {
+		request := &tripItemRequests[i]
+		if request.CostCategory == nil {
+			if category, ok := costCategoryForPlaceType(placeInfo.Type); ok {
+				request.CostCategory = &category
+			}
+		}
+		if request.EstimatedCost == nil && placeInfo.Price != nil {
+			priceCurrency := placeInfo.PriceCurrency
+			if priceCurrency == "" {
+				priceCurrency = trip.BudgetCurrency
+			}
+			priceCurrency, ok := currencyutils.Normalise(priceCurrency)
+			if !ok {
+				continue
+			}
+			if rate, ok := currencyutils.Rate(priceCurrency, trip.BudgetCurrency, unitsPerUSD); ok {
+				cost := math.Round(*placeInfo.Price*rate*100) / 100
+				request.EstimatedCost = &cost
+			}
+		}
+	}
+}
+
+// placeTypeCategories maps keywords of core place types to cost categories, checked in order
+var placeTypeCategories = []struct {
+	keywords []string
+	category string
+}{
+	{[]string{"hotel", "hostel", "resort", "homestay", "motel", "lodging", "accommodation"}, model.ExpenseCategory.Lodging},
+	{[]string{"restaurant", "cafe", "coffee", "food", "bar", "bakery", "eatery", "market"}, model.ExpenseCategory.Food},
+	{[]string{"airport", "station", "terminal", "pier", "transport"}, model.ExpenseCategory.Transport},
+	{[]string{"museum", "park", "attraction", "zoo", "theater", "theatre", "gallery", "tour", "entertainment", "amusement", "temple", "pagoda", "landmark"}, model.ExpenseCategory.Tickets},
+	{[]string{"mall", "shop", "store", "shopping"}, model.ExpenseCategory.Shopping},
+}
+
+func costCategoryForPlaceType(placeType string) (string, bool) {
+	placeType = strings.ToLower(placeType)
+	if placeType == "" {
+		return "", false
+	}
+	for _, entry := range placeTypeCategories {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(placeType, keyword) {
+				return entry.category, true
+			}
+		}
+	}
+	return "", false
 }
 
 // fetchPlaceInfo calls the external API and returns *model.PlaceInfo or error
@@ -190,3 +1091,266 @@ func fetchPlaceInfo(placeID string, lang string) (*model.PlaceInfo, error) {
 
 	return &apiResp.Data, nil
 }
+
+func (service *TripItemService) ValidateTripSchedule(ctx *gin.Context, userId int64, tripId int64, mode string) (*model.TripValidationResponse, string) {
+	isMember, err := service.tripMemberRepository.IsUserInTripQuery(ctx, tripId, userId, nil)
+	if err != nil {
+		log.Error("TripItemService.ValidateTripSchedule IsUserInTripQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if !isMember {
+		return nil, error_utils.ErrorCode.FORBIDDEN
+	}
+
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripItemService.ValidateTripSchedule GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	tripItems, errCode := service.GetTripItemsByTripID(ctx, userId, tripId, "")
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	scheduledItems := make([]scheduledItem, 0, len(tripItems))
+	itemsByID := make(map[int64]scheduledItem)
+	for _, tripItem := range tripItems {
+		item := scheduledItem{
+			ID:         tripItem.ID,
+			PlaceID:    tripItem.PlaceID,
+			TripDay:    tripItem.TripDay,
+			OrderInDay: tripItem.OrderInDay,
+			TimeInDate: tripItem.TimeInDate,
+			Start:      -1,
+			End:        -1,
+		}
+		if tripItem.StartTime != nil {
+			item.Start, _ = parseClock(*tripItem.StartTime)
+		}
+		if tripItem.EndTime != nil {
+			item.End, _ = parseClock(*tripItem.EndTime)
+		}
+		scheduledItems = append(scheduledItems, item)
+		itemsByID[item.ID] = item
+	}
+
+	response := &model.TripValidationResponse{
+		Issues: checkSchedule(scheduledItems, trip.Days),
+	}
+
+	// travel time only matters between stops we can place on a map
+	for _, day := range service.estimateTravel(ctx, tripItems, mode) {
+		for _, leg := range day.Legs {
+			from, to := itemsByID[leg.FromTripItemID], itemsByID[leg.ToTripItemID]
+			travelMinutes := int(leg.DurationSeconds / 60)
+
+			leaveAt := from.End
+			if leaveAt == -1 {
+				leaveAt = from.Start
+			}
+			if leaveAt != -1 && to.Start != -1 && to.Start-leaveAt < travelMinutes {
+				response.Issues = append(response.Issues, newTripValidationIssue(
+					model.TripValidationIssueType.UnrealisticTravel, model.TripValidationSeverity.Warning, from, to,
+					fmt.Sprintf("Getting from stop %d to stop %d takes about %d minutes but only %d are planned", from.OrderInDay, to.OrderInDay, travelMinutes, to.Start-leaveAt),
+				))
+			} else if travelMinutes > maxReasonableTravelMinutes {
+				response.Issues = append(response.Issues, newTripValidationIssue(
+					model.TripValidationIssueType.UnrealisticTravel, model.TripValidationSeverity.Warning, from, to,
+					fmt.Sprintf("Getting from stop %d to stop %d takes about %d minutes", from.OrderInDay, to.OrderInDay, travelMinutes),
+				))
+			}
+		}
+	}
+
+	response.Valid = true
+	for _, issue := range response.Issues {
+		if issue.Severity == model.TripValidationSeverity.Error {
+			response.Valid = false
+		}
+	}
+
+	return response, ""
+}
+
+// maxReasonableTravelMinutes flags legs that eat most of a time slot even when no clock times are set
+const maxReasonableTravelMinutes = 180
+
+// scheduledItem is the part of a trip item the schedule checks need, times are minutes since midnight or -1
+type scheduledItem struct {
+	ID         int64
+	PlaceID    string
+	TripDay    int64
+	OrderInDay int64
+	TimeInDate string
+	Start      int
+	End        int
+}
+
+// scheduledItemFromRequest resolves the start and end minutes of a request, deriving the end from the duration
+func scheduledItemFromRequest(request model.TripItemRequest) (scheduledItem, bool) {
+	item := scheduledItem{
+		PlaceID:    request.PlaceID,
+		TripDay:    request.TripDay,
+		OrderInDay: request.OrderInDay,
+		TimeInDate: request.TimeInDate,
+		Start:      -1,
+		End:        -1,
+	}
+
+	var err error
+	if request.StartTime != nil {
+		if item.Start, err = parseClock(*request.StartTime); err != nil {
+			return item, false
+		}
+	}
+	if request.EndTime != nil {
+		if item.End, err = parseClock(*request.EndTime); err != nil {
+			return item, false
+		}
+	}
+	if request.DurationMinutes != nil {
+		// a duration needs a start, and must agree with the end when both are sent
+		if item.Start == -1 {
+			return item, false
+		}
+		end := item.Start + int(*request.DurationMinutes)
+		if end >= 24*60 || (item.End != -1 && item.End != end) {
+			return item, false
+		}
+		item.End = end
+	}
+	if item.End != -1 && item.Start == -1 {
+		return item, false
+	}
+
+	return item, true
+}
+
+// checkSchedule reports problems found within each day and across the trip
+func checkSchedule(items []scheduledItem, tripDays int) []model.TripValidationIssue {
+	issues := make([]model.TripValidationIssue, 0)
+
+	itemsByDay := make(map[int64][]scheduledItem)
+	var days []int64
+	for _, item := range items {
+		if _, ok := model.TimeInDateStartHour[item.TimeInDate]; !ok {
+			issues = append(issues, newTripValidationIssue(model.TripValidationIssueType.InvalidTime, model.TripValidationSeverity.Error, item, item,
+				fmt.Sprintf("Stop %d has an unknown time of day %q", item.OrderInDay, item.TimeInDate)))
+		}
+		if item.TripDay > int64(tripDays) {
+			issues = append(issues, newTripValidationIssue(model.TripValidationIssueType.BeyondTripLength, model.TripValidationSeverity.Error, item, item,
+				fmt.Sprintf("Stop %d is planned on day %d but the trip only lasts %d days", item.OrderInDay, item.TripDay, tripDays)))
+		}
+		if item.Start != -1 && item.End != -1 && item.End <= item.Start {
+			issues = append(issues, newTripValidationIssue(model.TripValidationIssueType.InvalidTime, model.TripValidationSeverity.Error, item, item,
+				fmt.Sprintf("Stop %d ends before it starts", item.OrderInDay)))
+		}
+		if _, ok := itemsByDay[item.TripDay]; !ok {
+			days = append(days, item.TripDay)
+		}
+		itemsByDay[item.TripDay] = append(itemsByDay[item.TripDay], item)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+
+	for _, day := range days {
+		dayItems := itemsByDay[day]
+		sort.SliceStable(dayItems, func(i, j int) bool { return dayItems[i].OrderInDay < dayItems[j].OrderInDay })
+
+		var lastTimed *scheduledItem
+		for i := range dayItems {
+			current := dayItems[i]
+			if i > 0 {
+				previous := dayItems[i-1]
+				if previous.OrderInDay == current.OrderInDay {
+					issues = append(issues, newTripValidationIssue(model.TripValidationIssueType.DuplicateOrder, model.TripValidationSeverity.Error, previous, current,
+						fmt.Sprintf("Two stops share position %d", current.OrderInDay)))
+				}
+				if model.TimeInDateStartHour[current.TimeInDate] < model.TimeInDateStartHour[previous.TimeInDate] {
+					issues = append(issues, newTripValidationIssue(model.TripValidationIssueType.OutOfOrder, model.TripValidationSeverity.Error, previous, current,
+						fmt.Sprintf("Stop %d (%s) comes after stop %d (%s)", current.OrderInDay, current.TimeInDate, previous.OrderInDay, previous.TimeInDate)))
+				}
+			}
+
+			if current.Start == -1 {
+				continue
+			}
+			if lastTimed != nil {
+				if current.Start < lastTimed.Start {
+					issues = append(issues, newTripValidationIssue(model.TripValidationIssueType.OutOfOrder, model.TripValidationSeverity.Error, *lastTimed, current,
+						fmt.Sprintf("Stop %d starts before stop %d", current.OrderInDay, lastTimed.OrderInDay)))
+				} else if lastTimed.End != -1 && current.Start < lastTimed.End {
+					issues = append(issues, newTripValidationIssue(model.TripValidationIssueType.Overlap, model.TripValidationSeverity.Error, *lastTimed, current,
+						fmt.Sprintf("Stop %d starts before stop %d ends", current.OrderInDay, lastTimed.OrderInDay)))
+				}
+			}
+			lastTimed = &dayItems[i]
+		}
+	}
+
+	// visiting the same place twice is allowed, but usually a mistake
+	firstVisit := make(map[string]scheduledItem)
+	for _, day := range days {
+		for _, item := range itemsByDay[day] {
+			if first, ok := firstVisit[item.PlaceID]; ok {
+				issues = append(issues, newTripValidationIssue(model.TripValidationIssueType.DuplicatePlace, model.TripValidationSeverity.Warning, first, item,
+					fmt.Sprintf("Place %s is visited on day %d and again on day %d", item.PlaceID, first.TripDay, item.TripDay)))
+				continue
+			}
+			firstVisit[item.PlaceID] = item
+		}
+	}
+
+	return issues
+}
+
+func newTripValidationIssue(issueType string, severity string, first scheduledItem, second scheduledItem, message string) model.TripValidationIssue {
+	issue := model.TripValidationIssue{
+		Type:        issueType,
+		Severity:    severity,
+		TripDay:     second.TripDay,
+		TripItemIDs: []int64{first.ID},
+		OrderInDay:  []int64{first.OrderInDay},
+		Message:     message,
+	}
+	if first != second {
+		issue.TripItemIDs = append(issue.TripItemIDs, second.ID)
+		issue.OrderInDay = append(issue.OrderInDay, second.OrderInDay)
+	}
+	return issue
+}
+
+// parseClock accepts "HH:MM" from requests and "HH:MM:SS" from the TIME column
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		clock, err = time.Parse("15:04:05", value)
+		if err != nil {
+			return -1, err
+		}
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+func formatClock(minutes int) *string {
+	if minutes == -1 {
+		return nil
+	}
+	value := fmt.Sprintf("%02d:%02d:00", minutes/60, minutes%60)
+	return &value
+}
+
+func trimClockSeconds(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	minutes, err := parseClock(*value)
+	if err != nil {
+		return value
+	}
+	trimmed := fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+	return &trimmed
+}
@@ -3,20 +3,44 @@ package serviceimplement
 import (
 	"context"
 
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
 )
 
 type TripMemberService struct {
-	tripMemberRepo repository.TripMemberRepository
+	tripMemberRepo          repository.TripMemberRepository
+	unitOfWork              repository.UnitOfWork
+	notificationService     service.NotificationService
+	tripRealtimeService     service.TripRealtimeService
+	tripActivityRepository  repository.TripActivityRepository
+	syncTombstoneRepository repository.SyncTombstoneRepository
+	tripRepository          repository.TripRepository
 }
 
-func NewTripMemberService(tripMemberRepo repository.TripMemberRepository) service.TripMemberService {
+func NewTripMemberService(
+	tripMemberRepo repository.TripMemberRepository,
+	unitOfWork repository.UnitOfWork,
+	notificationService service.NotificationService,
+	tripRealtimeService service.TripRealtimeService,
+	tripActivityRepository repository.TripActivityRepository,
+	syncTombstoneRepository repository.SyncTombstoneRepository,
+	tripRepository repository.TripRepository,
+) service.TripMemberService {
 	return &TripMemberService{
-		tripMemberRepo: tripMemberRepo,
+		tripMemberRepo:          tripMemberRepo,
+		unitOfWork:              unitOfWork,
+		notificationService:     notificationService,
+		tripRealtimeService:     tripRealtimeService,
+		tripActivityRepository:  tripActivityRepository,
+		syncTombstoneRepository: syncTombstoneRepository,
+		tripRepository:          tripRepository,
 	}
 }
 
@@ -56,30 +80,348 @@ func (s *TripMemberService) DeleteMemberFromTrip(ctx context.Context, tripID int
 	if deleterID == memberID {
 		return error_utils.ErrorCode.FORBIDDEN
 	}
-	// Check deleter is admin
-	isAdmin, err := s.tripMemberRepo.IsUserTripAdminQuery(ctx, tripID, deleterID, nil)
+	deleterRole, err := s.tripMemberRepo.GetMemberRoleQuery(ctx, tripID, deleterID, nil)
 	if err != nil {
-		log.Error("TripMemberService.DeleteMemberFromTrip IsUserTripAdminQuery error: " + err.Error())
+		log.Error("TripMemberService.DeleteMemberFromTrip GetMemberRoleQuery error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
-	if !isAdmin {
+	memberRole, err := s.tripMemberRepo.GetMemberRoleQuery(ctx, tripID, memberID, nil)
+	if err != nil {
+		log.Error("TripMemberService.DeleteMemberFromTrip GetMemberRoleQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	// admins can only remove members ranked below them
+	if !trippermissionutils.CanRemoveMember(deleterRole, memberRole) {
 		return error_utils.ErrorCode.FORBIDDEN
 	}
 
-	// Check member is in trip
-	isMember, err := s.tripMemberRepo.IsUserInTripQuery(ctx, tripID, memberID, nil)
+	member := tripMemberInfo(ctx, s.tripMemberRepo, tripID, memberID)
+	tx, err := s.unitOfWork.Begin(ctx)
 	if err != nil {
-		log.Error("TripMemberService.DeleteMemberFromTrip IsUserInTripQuery error: " + err.Error())
+		log.Error("TripMemberService.DeleteMemberFromTrip Begin transaction error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
-	if !isMember {
+	defer s.unitOfWork.Rollback(tx)
+
+	err = s.syncTombstoneRepository.CreateForTripMemberCommand(ctx, tripID, memberID, tx)
+	if err != nil {
+		log.Error("TripMemberService.DeleteMemberFromTrip CreateForTripMemberCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	err = s.tripMemberRepo.DeleteMemberCommand(ctx, tripID, memberID, tx)
+	if err != nil {
+		log.Error("TripMemberService.DeleteMemberFromTrip DeleteMemberCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	err = s.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripMemberService.DeleteMemberFromTrip Commit error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	err = recordTripActivity(ctx, s.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     tripID,
+			ActorID:    &deleterID,
+			Action:     model.TripActivityAction.MemberRemoved,
+			EntityType: model.TripActivityEntityType.Member,
+			EntityID:   &memberID,
+		},
+		before: member,
+	}, nil)
+	if err != nil {
+		log.Error("TripMemberService.DeleteMemberFromTrip recordTripActivity error: " + err.Error())
+	}
+
+	s.tripRealtimeService.PublishEvent(ctx, tripID, &deleterID, model.TripEventType.MemberLeft, member)
+	return ""
+}
+
+func (s *TripMemberService) ChangeMemberRole(ctx context.Context, tripID int64, memberID int64, changerID int64, role string) string {
+	if changerID == memberID {
 		return error_utils.ErrorCode.FORBIDDEN
 	}
 
-	err = s.tripMemberRepo.DeleteMemberCommand(ctx, tripID, memberID, nil)
+	changerRole, err := s.tripMemberRepo.GetMemberRoleQuery(ctx, tripID, changerID, nil)
 	if err != nil {
-		log.Error("TripMemberService.DeleteMemberFromTrip DeleteMemberCommand error: " + err.Error())
+		log.Error("TripMemberService.ChangeMemberRole GetMemberRoleQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if !trippermissionutils.IsMember(changerRole) {
+		return error_utils.ErrorCode.FORBIDDEN
+	}
+
+	memberRole, err := s.tripMemberRepo.GetMemberRoleQuery(ctx, tripID, memberID, nil)
+	if err != nil {
+		log.Error("TripMemberService.ChangeMemberRole GetMemberRoleQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if !trippermissionutils.CanChangeRole(changerRole, memberRole, role) {
+		return error_utils.ErrorCode.FORBIDDEN
+	}
+	if memberRole == role {
+		return ""
+	}
+
+	err = s.tripMemberRepo.UpdateRoleCommand(ctx, tripID, memberID, role, nil)
+	if err != nil {
+		log.Error("TripMemberService.ChangeMemberRole UpdateRoleCommand error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
+
+	err = recordTripActivity(ctx, s.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     tripID,
+			ActorID:    &changerID,
+			Action:     model.TripActivityAction.MemberRoleChanged,
+			EntityType: model.TripActivityEntityType.Member,
+			EntityID:   &memberID,
+		},
+		before: map[string]interface{}{"role": memberRole},
+		after:  map[string]interface{}{"role": role},
+	}, nil)
+	if err != nil {
+		log.Error("TripMemberService.ChangeMemberRole recordTripActivity error: " + err.Error())
+	}
+
+	s.tripRealtimeService.PublishEvent(ctx, tripID, &changerID, model.TripEventType.MemberRoleChanged, model.TripMemberRoleChangedData{
+		User: tripMemberInfo(ctx, s.tripMemberRepo, tripID, memberID),
+		Role: role,
+	})
+	return ""
+}
+
+func (s *TripMemberService) LeaveTrip(ctx *gin.Context, tripID int64, userID int64) string {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripMemberService.LeaveTrip Begin transaction error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	defer s.unitOfWork.Rollback(tx)
+
+	// lock the trip so concurrent leaves and transfers see each other's ownership changes
+	trip, err := s.tripRepository.SelectForUpdateById(ctx, tripID, tx)
+	if err != nil {
+		log.Error("TripMemberService.LeaveTrip SelectForUpdateById error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if trip == nil {
+		return error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	// members come ordered from the longest-standing one
+	members, err := s.tripMemberRepo.GetTripMembersQuery(ctx, tripID, tx)
+	if err != nil {
+		log.Error("TripMemberService.LeaveTrip GetTripMembersQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	var leaver *entity.TripMemberWithUser
+	remaining := make([]entity.TripMemberWithUser, 0, len(members))
+	for i := range members {
+		if members[i].UserID == userID {
+			leaver = &members[i]
+		} else {
+			remaining = append(remaining, members[i])
+		}
+	}
+	if leaver == nil {
+		return error_utils.ErrorCode.FORBIDDEN
+	}
+	// nobody would be left to own the trip
+	if len(remaining) == 0 {
+		return error_utils.ErrorCode.TRIP_SOLE_MEMBER
+	}
+
+	// an owner who leaves hands the trip over to the longest-standing admin, or else the longest-standing member
+	var successor *entity.TripMemberWithUser
+	if leaver.Role == entity.TripRole.Owner {
+		successor = &remaining[0]
+		for i := range remaining {
+			if trippermissionutils.IsAdmin(remaining[i].Role) {
+				successor = &remaining[i]
+				break
+			}
+		}
+
+		err = s.tripMemberRepo.UpdateRoleCommand(ctx, tripID, successor.UserID, entity.TripRole.Owner, tx)
+		if err != nil {
+			log.Error("TripMemberService.LeaveTrip UpdateRoleCommand error: " + err.Error())
+			return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+	}
+
+	err = s.syncTombstoneRepository.CreateForTripMemberCommand(ctx, tripID, userID, tx)
+	if err != nil {
+		log.Error("TripMemberService.LeaveTrip CreateForTripMemberCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	err = s.tripMemberRepo.DeleteMemberCommand(ctx, tripID, userID, tx)
+	if err != nil {
+		log.Error("TripMemberService.LeaveTrip DeleteMemberCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	leaverInfo := model.UserInfo{ID: leaver.UserID, Name: leaver.Name, PhotoURL: leaver.PhotoURL}
+	err = recordTripActivity(ctx, s.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     tripID,
+			ActorID:    &userID,
+			Action:     model.TripActivityAction.MemberLeft,
+			EntityType: model.TripActivityEntityType.Member,
+			EntityID:   &userID,
+		},
+		before: leaverInfo,
+	}, tx)
+	if err != nil {
+		log.Error("TripMemberService.LeaveTrip recordTripActivity error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if successor != nil {
+		err = recordOwnershipTransfer(ctx, s.tripActivityRepository, tripID, userID, successor, tx)
+		if err != nil {
+			log.Error("TripMemberService.LeaveTrip recordOwnershipTransfer error: " + err.Error())
+			return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+	}
+
+	err = s.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripMemberService.LeaveTrip Commit error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	s.tripRealtimeService.PublishEvent(ctx, tripID, &userID, model.TripEventType.MemberLeft, leaverInfo)
+	for _, member := range remaining {
+		s.notifyMember(ctx, member.UserID, tripID, userID, entity.NotificationType.TripMemberLeft)
+	}
+	if successor != nil {
+		s.publishRoleChanged(ctx, tripID, userID, *successor, entity.TripRole.Owner)
+		s.notifyMember(ctx, successor.UserID, tripID, userID, entity.NotificationType.TripOwnershipTransferred)
+	}
+	return ""
+}
+
+func (s *TripMemberService) TransferOwnership(ctx *gin.Context, tripID int64, ownerID int64, newOwnerID int64) string {
+	if ownerID == newOwnerID {
+		return error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripMemberService.TransferOwnership Begin transaction error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	defer s.unitOfWork.Rollback(tx)
+
+	// lock the trip so concurrent leaves and transfers see each other's ownership changes
+	trip, err := s.tripRepository.SelectForUpdateById(ctx, tripID, tx)
+	if err != nil {
+		log.Error("TripMemberService.TransferOwnership SelectForUpdateById error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if trip == nil {
+		return error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	members, err := s.tripMemberRepo.GetTripMembersQuery(ctx, tripID, tx)
+	if err != nil {
+		log.Error("TripMemberService.TransferOwnership GetTripMembersQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	var owner, newOwner *entity.TripMemberWithUser
+	for i := range members {
+		switch members[i].UserID {
+		case ownerID:
+			owner = &members[i]
+		case newOwnerID:
+			newOwner = &members[i]
+		}
+	}
+	if owner == nil || owner.Role != entity.TripRole.Owner || newOwner == nil {
+		return error_utils.ErrorCode.FORBIDDEN
+	}
+
+	// the previous owner stays on as an administrator
+	err = s.tripMemberRepo.UpdateRoleCommand(ctx, tripID, ownerID, entity.TripRole.Administrator, tx)
+	if err != nil {
+		log.Error("TripMemberService.TransferOwnership UpdateRoleCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	err = s.tripMemberRepo.UpdateRoleCommand(ctx, tripID, newOwnerID, entity.TripRole.Owner, tx)
+	if err != nil {
+		log.Error("TripMemberService.TransferOwnership UpdateRoleCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	err = recordOwnershipTransfer(ctx, s.tripActivityRepository, tripID, ownerID, newOwner, tx)
+	if err != nil {
+		log.Error("TripMemberService.TransferOwnership recordOwnershipTransfer error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	err = s.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripMemberService.TransferOwnership Commit error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	s.publishRoleChanged(ctx, tripID, ownerID, *owner, entity.TripRole.Administrator)
+	s.publishRoleChanged(ctx, tripID, ownerID, *newOwner, entity.TripRole.Owner)
+	for _, member := range members {
+		if member.UserID != ownerID {
+			s.notifyMember(ctx, member.UserID, tripID, ownerID, entity.NotificationType.TripOwnershipTransferred)
+		}
+	}
+	return ""
+}
+
+func (s *TripMemberService) publishRoleChanged(ctx context.Context, tripID int64, actorID int64, member entity.TripMemberWithUser, role string) {
+	s.tripRealtimeService.PublishEvent(ctx, tripID, &actorID, model.TripEventType.MemberRoleChanged, model.TripMemberRoleChangedData{
+		User: model.UserInfo{ID: member.UserID, Name: member.Name, PhotoURL: member.PhotoURL},
+		Role: role,
+	})
+}
+
+func (s *TripMemberService) notifyMember(ctx *gin.Context, receiverID int64, tripID int64, triggerUserID int64, notificationType string) {
+	errCode := s.notificationService.SaveAndSendNotification(ctx, model.SaveNotificationRequest{
+		ReceiverUserID:      receiverID,
+		TriggerEntityType:   entity.NotificationTriggerType.User,
+		TriggerEntityID:     &triggerUserID,
+		ReferenceEntityType: entity.NotificationReferenceType.Trip,
+		ReferenceEntityID:   &tripID,
+		Type:                notificationType,
+	})
+	if errCode != "" {
+		log.Error("TripMemberService.notifyMember SaveAndSendNotification error: " + errCode)
+	}
+}
+
+func recordOwnershipTransfer(ctx context.Context, tripActivityRepository repository.TripActivityRepository, tripID int64, previousOwnerID int64, newOwner *entity.TripMemberWithUser, tx *sqlx.Tx) error {
+	return recordTripActivity(ctx, tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     tripID,
+			ActorID:    &previousOwnerID,
+			Action:     model.TripActivityAction.OwnershipTransferred,
+			EntityType: model.TripActivityEntityType.Member,
+			EntityID:   &newOwner.UserID,
+		},
+		before: map[string]interface{}{"ownerID": previousOwnerID},
+		after:  map[string]interface{}{"ownerID": newOwner.UserID},
+	}, tx)
+}
+
+// checkTripPermission checks the role the user holds in the trip against a trippermissionutils policy.
+// Users outside the trip have no role, which every policy turns down.
+func checkTripPermission(ctx context.Context, tripMemberRepository repository.TripMemberRepository, tripId int64, userId int64, allowed func(role string) bool, caller string) string {
+	role, err := tripMemberRepository.GetMemberRoleQuery(ctx, tripId, userId, nil)
+	if err != nil {
+		log.Error(caller + " GetMemberRoleQuery error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if !allowed(role) {
+		return error_utils.ErrorCode.FORBIDDEN
+	}
 	return ""
 }
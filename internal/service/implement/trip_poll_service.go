@@ -0,0 +1,603 @@
+package serviceimplement
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
+)
+
+type TripPollService struct {
+	tripPollRepository     repository.TripPollRepository
+	tripRepository         repository.TripRepository
+	tripMemberRepository   repository.TripMemberRepository
+	tripItemRepository     repository.TripItemRepository
+	unitOfWork             repository.UnitOfWork
+	notificationService    service.NotificationService
+	tripRealtimeService    service.TripRealtimeService
+	tripActivityRepository repository.TripActivityRepository
+}
+
+func NewTripPollService(
+	tripPollRepository repository.TripPollRepository,
+	tripRepository repository.TripRepository,
+	tripMemberRepository repository.TripMemberRepository,
+	tripItemRepository repository.TripItemRepository,
+	unitOfWork repository.UnitOfWork,
+	notificationService service.NotificationService,
+	tripRealtimeService service.TripRealtimeService,
+	tripActivityRepository repository.TripActivityRepository,
+) service.TripPollService {
+	return &TripPollService{
+		tripPollRepository:     tripPollRepository,
+		tripRepository:         tripRepository,
+		tripMemberRepository:   tripMemberRepository,
+		tripItemRepository:     tripItemRepository,
+		unitOfWork:             unitOfWork,
+		notificationService:    notificationService,
+		tripRealtimeService:    tripRealtimeService,
+		tripActivityRepository: tripActivityRepository,
+	}
+}
+
+func (service *TripPollService) CreatePoll(ctx *gin.Context, userId int64, tripId int64, pollRequest model.TripPollRequest) (*model.TripPollResponse, string) {
+	members, errCode := service.getMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanCreatePolls, "TripPollService.CreatePoll"); errCode != "" {
+		return nil, errCode
+	}
+
+	if pollRequest.Deadline != nil && !pollRequest.Deadline.After(time.Now()) {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	if pollRequest.OptionType == model.PollOptionType.Place {
+		for _, option := range pollRequest.Options {
+			if option.PlaceID == nil || *option.PlaceID == "" {
+				return nil, error_utils.ErrorCode.BAD_REQUEST
+			}
+		}
+	}
+
+	// applying the winner rewrites the itinerary, so only those who may edit it can point a poll at an item
+	if pollRequest.ApplyToTripItemID != nil {
+		if pollRequest.OptionType != model.PollOptionType.Place {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+		if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanEditItems, "TripPollService.CreatePoll"); errCode != "" {
+			return nil, errCode
+		}
+		isTripItemExists, err := service.tripItemRepository.ExistsByTripIDAndTripItemIDCommand(ctx, tripId, *pollRequest.ApplyToTripItemID, nil)
+		if err != nil {
+			log.Error("TripPollService.CreatePoll ExistsByTripIDAndTripItemIDCommand error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		if !isTripItemExists {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+	}
+
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripPollService.CreatePoll Begin error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	now := time.Now()
+	poll := &entity.TripPoll{
+		TripID:            tripId,
+		CreatedBy:         userId,
+		Question:          pollRequest.Question,
+		OptionType:        pollRequest.OptionType,
+		AllowMultiple:     pollRequest.AllowMultiple,
+		IsAnonymous:       pollRequest.IsAnonymous,
+		Deadline:          pollRequest.Deadline,
+		Status:            model.PollStatus.Open,
+		ApplyToTripItemID: pollRequest.ApplyToTripItemID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	pollId, err := service.tripPollRepository.CreateCommand(ctx, poll, tx)
+	if err != nil {
+		log.Error("TripPollService.CreatePoll CreateCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	poll.ID = pollId
+
+	options := make([]entity.TripPollOption, 0, len(pollRequest.Options))
+	for i, optionRequest := range pollRequest.Options {
+		option := entity.TripPollOption{
+			PollID:      pollId,
+			Label:       optionRequest.Label,
+			OptionOrder: i + 1,
+		}
+		if pollRequest.OptionType == model.PollOptionType.Place {
+			option.PlaceID = optionRequest.PlaceID
+		}
+		optionId, err := service.tripPollRepository.CreateOptionCommand(ctx, &option, tx)
+		if err != nil {
+			log.Error("TripPollService.CreatePoll CreateOptionCommand error: " + err.Error())
+			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+		option.ID = optionId
+		options = append(options, option)
+	}
+
+	if err = service.unitOfWork.Commit(tx); err != nil {
+		log.Error("TripPollService.CreatePoll Commit error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	for memberId := range members {
+		if memberId == userId {
+			continue
+		}
+		service.notifyMember(ctx, memberId, tripId, &userId, entity.NotificationType.TripPollOpened)
+	}
+
+	response := toTripPollResponse(*poll, options, nil, members, userId)
+	return &response, ""
+}
+
+func (service *TripPollService) GetPolls(ctx *gin.Context, userId int64, tripId int64) ([]model.TripPollResponse, string) {
+	members, errCode := service.getMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	polls, err := service.tripPollRepository.GetAllByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripPollService.GetPolls GetAllByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	options, err := service.tripPollRepository.GetOptionsByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripPollService.GetPolls GetOptionsByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	votes, err := service.tripPollRepository.GetVotesByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripPollService.GetPolls GetVotesByTripIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	optionsByPoll := make(map[int64][]entity.TripPollOption)
+	for _, option := range options {
+		optionsByPoll[option.PollID] = append(optionsByPoll[option.PollID], option)
+	}
+	votesByPoll := make(map[int64][]entity.TripPollVote)
+	for _, vote := range votes {
+		votesByPoll[vote.PollID] = append(votesByPoll[vote.PollID], vote)
+	}
+
+	responses := make([]model.TripPollResponse, 0, len(polls))
+	for _, poll := range polls {
+		responses = append(responses, toTripPollResponse(poll, optionsByPoll[poll.ID], votesByPoll[poll.ID], members, userId))
+	}
+	return responses, ""
+}
+
+func (service *TripPollService) GetPoll(ctx *gin.Context, userId int64, tripId int64, pollId int64) (*model.TripPollResponse, string) {
+	members, errCode := service.getMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	poll, err := service.tripPollRepository.GetOneByIDQuery(ctx, tripId, pollId, nil)
+	if err != nil {
+		log.Error("TripPollService.GetPoll GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if poll == nil {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	return service.buildPollResponse(ctx, *poll, members, userId, "TripPollService.GetPoll")
+}
+
+func (service *TripPollService) Vote(ctx *gin.Context, userId int64, tripId int64, pollId int64, voteRequest model.TripPollVoteRequest) (*model.TripPollResponse, string) {
+	members, errCode := service.getMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, trippermissionutils.CanVote, "TripPollService.Vote"); errCode != "" {
+		return nil, errCode
+	}
+
+	poll, err := service.tripPollRepository.GetOneByIDQuery(ctx, tripId, pollId, nil)
+	if err != nil {
+		log.Error("TripPollService.Vote GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if poll == nil {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	// the deadline is enforced here too, since the cron job only closes polls every few minutes
+	if poll.Status != model.PollStatus.Open || (poll.Deadline != nil && !poll.Deadline.After(time.Now())) {
+		return nil, error_utils.ErrorCode.TRIP_POLL_CLOSED
+	}
+
+	options, err := service.tripPollRepository.GetOptionsByPollIDQuery(ctx, pollId, nil)
+	if err != nil {
+		log.Error("TripPollService.Vote GetOptionsByPollIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	isPollOption := make(map[int64]bool, len(options))
+	for _, option := range options {
+		isPollOption[option.ID] = true
+	}
+
+	optionIds := make([]int64, 0, len(voteRequest.OptionIDs))
+	seen := make(map[int64]bool)
+	for _, optionId := range voteRequest.OptionIDs {
+		if !isPollOption[optionId] {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+		if seen[optionId] {
+			continue
+		}
+		seen[optionId] = true
+		optionIds = append(optionIds, optionId)
+	}
+	if !poll.AllowMultiple && len(optionIds) != 1 {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripPollService.Vote Begin error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	err = service.tripPollRepository.ReplaceVotesCommand(ctx, pollId, userId, optionIds, tx)
+	if err != nil {
+		log.Error("TripPollService.Vote ReplaceVotesCommand error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if err = service.unitOfWork.Commit(tx); err != nil {
+		log.Error("TripPollService.Vote Commit error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	return service.buildPollResponse(ctx, *poll, members, userId, "TripPollService.Vote")
+}
+
+func (service *TripPollService) ClosePoll(ctx *gin.Context, userId int64, tripId int64, pollId int64) (*model.TripPollResponse, string) {
+	members, errCode := service.getMembersIfUserInTrip(ctx, userId, tripId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	poll, errCode := service.getPollIfCreatorOrAdmin(ctx, userId, tripId, pollId)
+	if errCode != "" {
+		return nil, errCode
+	}
+	if poll.Status != model.PollStatus.Open {
+		return nil, error_utils.ErrorCode.TRIP_POLL_CLOSED
+	}
+
+	if err := service.closePoll(ctx, poll, &userId); err != nil {
+		log.Error("TripPollService.ClosePoll closePoll error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	poll, err := service.tripPollRepository.GetOneByIDQuery(ctx, tripId, pollId, nil)
+	if err != nil {
+		log.Error("TripPollService.ClosePoll GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if poll == nil {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	return service.buildPollResponse(ctx, *poll, members, userId, "TripPollService.ClosePoll")
+}
+
+func (service *TripPollService) DeletePoll(ctx *gin.Context, userId int64, tripId int64, pollId int64) string {
+	if _, errCode := service.getMembersIfUserInTrip(ctx, userId, tripId); errCode != "" {
+		return errCode
+	}
+
+	if _, errCode := service.getPollIfCreatorOrAdmin(ctx, userId, tripId, pollId); errCode != "" {
+		return errCode
+	}
+
+	err := service.tripPollRepository.DeleteByIDCommand(ctx, pollId, nil)
+	if err != nil {
+		log.Error("TripPollService.DeletePoll DeleteByIDCommand error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	return ""
+}
+
+func (service *TripPollService) CloseExpiredPolls(ctx *gin.Context) error {
+	polls, err := service.tripPollRepository.GetAllOpenPastDeadlineQuery(ctx, time.Now(), nil)
+	if err != nil {
+		log.Error("TripPollService.CloseExpiredPolls - GetAllOpenPastDeadlineQuery Error: " + err.Error())
+		return err
+	}
+
+	for i := range polls {
+		if err := service.closePoll(ctx, &polls[i], nil); err != nil {
+			log.Error("TripPollService.CloseExpiredPolls - closePoll Error: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// closePoll records the winner, applies a winning place to the linked item and tells the members.
+// A poll that was closed concurrently is left untouched. actorId is nil when the deadline closed it.
+func (service *TripPollService) closePoll(ctx *gin.Context, poll *entity.TripPoll, actorId *int64) error {
+	options, err := service.tripPollRepository.GetOptionsByPollIDQuery(ctx, poll.ID, nil)
+	if err != nil {
+		return err
+	}
+	votes, err := service.tripPollRepository.GetVotesByPollIDQuery(ctx, poll.ID, nil)
+	if err != nil {
+		return err
+	}
+	winner := pollWinner(options, votes)
+
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	var winningOptionId *int64
+	if winner != nil {
+		winningOptionId = &winner.ID
+	}
+	closed, err := service.tripPollRepository.CloseCommand(ctx, poll.ID, winningOptionId, tx)
+	if err != nil {
+		return err
+	}
+	if !closed {
+		return nil
+	}
+
+	applied := false
+	if winner != nil && winner.PlaceID != nil && poll.ApplyToTripItemID != nil {
+		if applied, err = service.applyWinningPlace(ctx, poll, *winner.PlaceID, actorId, tx); err != nil {
+			return err
+		}
+	}
+
+	if err = service.unitOfWork.Commit(tx); err != nil {
+		return err
+	}
+
+	if applied {
+		service.publishAppliedItem(ctx, poll.TripID, *poll.ApplyToTripItemID)
+	}
+
+	members, err := service.tripMemberRepository.GetTripMembersQuery(ctx, poll.TripID, nil)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		service.notifyMember(ctx, member.UserID, poll.TripID, nil, entity.NotificationType.TripPollClosed)
+	}
+	return nil
+}
+
+func (service *TripPollService) applyWinningPlace(ctx *gin.Context, poll *entity.TripPoll, placeId string, actorId *int64, tx *sqlx.Tx) (bool, error) {
+	tripItem, err := service.tripItemRepository.GetOneByIDQuery(ctx, poll.TripID, *poll.ApplyToTripItemID, tx)
+	if err != nil {
+		return false, err
+	}
+	// items keep their id across itinerary saves, so the item is only gone when it was removed from the itinerary
+	if tripItem == nil || tripItem.PlaceID == placeId {
+		return false, nil
+	}
+
+	// a place can only be used by one item, so a winner already in an itinerary is not applied,
+	// and the poll still closes instead of failing on every retry
+	isPlaceUsed, err := service.tripItemRepository.ExistsByPlaceIDQuery(ctx, placeId, tx)
+	if err != nil {
+		return false, err
+	}
+	if isPlaceUsed {
+		log.Info(fmt.Sprintf("TripPollService.applyWinningPlace place %s of poll %d is already used by a trip item, not applied", placeId, poll.ID))
+		return false, nil
+	}
+
+	err = service.tripItemRepository.UpdatePlaceIDCommand(ctx, tripItem.ID, placeId, tx)
+	if err != nil {
+		return false, err
+	}
+
+	updated := *tripItem
+	updated.PlaceID = placeId
+	err = recordTripActivity(ctx, service.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     poll.TripID,
+			ActorID:    actorId,
+			Action:     model.TripActivityAction.ItemEdited,
+			EntityType: model.TripActivityEntityType.TripItem,
+			EntityID:   &tripItem.ID,
+		},
+		before: toTripItemChange(*tripItem),
+		after:  toTripItemChange(updated),
+	}, tx)
+	return err == nil, err
+}
+
+func (service *TripPollService) publishAppliedItem(ctx *gin.Context, tripId int64, tripItemId int64) {
+	tripItem, err := service.tripItemRepository.GetOneByIDQuery(ctx, tripId, tripItemId, nil)
+	if err != nil {
+		log.Error("TripPollService.publishAppliedItem GetOneByIDQuery error: " + err.Error())
+		return
+	}
+	if tripItem == nil {
+		return
+	}
+
+	changes := model.TripItemsChangedData{
+		Added:   make([]model.TripItemChange, 0),
+		Removed: make([]model.TripItemChange, 0),
+		Moved:   make([]model.TripItemChange, 0),
+		Edited:  []model.TripItemChange{toTripItemChange(*tripItem)},
+	}
+	service.tripRealtimeService.PublishEvent(ctx, tripId, nil, model.TripEventType.ItemsChanged, changes)
+}
+
+func (service *TripPollService) buildPollResponse(ctx *gin.Context, poll entity.TripPoll, members map[int64]model.UserInfo, userId int64, caller string) (*model.TripPollResponse, string) {
+	options, err := service.tripPollRepository.GetOptionsByPollIDQuery(ctx, poll.ID, nil)
+	if err != nil {
+		log.Error(caller + " GetOptionsByPollIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	votes, err := service.tripPollRepository.GetVotesByPollIDQuery(ctx, poll.ID, nil)
+	if err != nil {
+		log.Error(caller + " GetVotesByPollIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	response := toTripPollResponse(poll, options, votes, members, userId)
+	return &response, ""
+}
+
+func (service *TripPollService) getPollIfCreatorOrAdmin(ctx *gin.Context, userId int64, tripId int64, pollId int64) (*entity.TripPoll, string) {
+	poll, err := service.tripPollRepository.GetOneByIDQuery(ctx, tripId, pollId, nil)
+	if err != nil {
+		log.Error("TripPollService.getPollIfCreatorOrAdmin GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if poll == nil {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	canManage := func(role string) bool { return trippermissionutils.CanManagePoll(role, poll.CreatedBy == userId) }
+	if errCode := checkTripPermission(ctx, service.tripMemberRepository, tripId, userId, canManage, "TripPollService.getPollIfCreatorOrAdmin"); errCode != "" {
+		return nil, errCode
+	}
+	return poll, ""
+}
+
+func (service *TripPollService) getMembersIfUserInTrip(ctx *gin.Context, userId int64, tripId int64) (map[int64]model.UserInfo, string) {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripPollService.getMembersIfUserInTrip GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	tripMembers, err := service.tripMemberRepository.GetTripMembersQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripPollService.getMembersIfUserInTrip GetTripMembersQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	members := make(map[int64]model.UserInfo)
+	for _, member := range tripMembers {
+		members[member.UserID] = model.UserInfo{ID: member.UserID, Name: member.Name, PhotoURL: member.PhotoURL}
+	}
+	if _, ok := members[userId]; !ok {
+		return nil, error_utils.ErrorCode.FORBIDDEN
+	}
+	return members, ""
+}
+
+func (service *TripPollService) notifyMember(ctx *gin.Context, receiverId int64, tripId int64, triggerUserId *int64, notificationType string) {
+	request := model.SaveNotificationRequest{
+		ReceiverUserID:      receiverId,
+		TriggerEntityType:   entity.NotificationTriggerType.System,
+		ReferenceEntityType: entity.NotificationReferenceType.Trip,
+		ReferenceEntityID:   &tripId,
+		Type:                notificationType,
+	}
+	if triggerUserId != nil {
+		request.TriggerEntityType = entity.NotificationTriggerType.User
+		request.TriggerEntityID = triggerUserId
+	}
+
+	if errCode := service.notificationService.SaveAndSendNotification(ctx, request); errCode != "" {
+		log.Error("TripPollService.notifyMember SaveAndSendNotification error: " + errCode)
+	}
+}
+
+// pollWinner returns the option with strictly the most votes; a tie or an empty poll has no winner
+func pollWinner(options []entity.TripPollOption, votes []entity.TripPollVote) *entity.TripPollOption {
+	counts := make(map[int64]int)
+	for _, vote := range votes {
+		counts[vote.OptionID]++
+	}
+
+	var winner *entity.TripPollOption
+	best, tied := 0, false
+	for i := range options {
+		count := counts[options[i].ID]
+		switch {
+		case count > best:
+			winner, best, tied = &options[i], count, false
+		case count == best && count > 0:
+			tied = true
+		}
+	}
+	if tied {
+		return nil
+	}
+	return winner
+}
+
+func toTripPollResponse(poll entity.TripPoll, options []entity.TripPollOption, votes []entity.TripPollVote, members map[int64]model.UserInfo, userId int64) model.TripPollResponse {
+	response := model.TripPollResponse{
+		ID:                poll.ID,
+		TripID:            poll.TripID,
+		CreatedBy:         poll.CreatedBy,
+		Question:          poll.Question,
+		OptionType:        poll.OptionType,
+		AllowMultiple:     poll.AllowMultiple,
+		IsAnonymous:       poll.IsAnonymous,
+		Deadline:          poll.Deadline,
+		Status:            poll.Status,
+		ClosedAt:          poll.ClosedAt,
+		ApplyToTripItemID: poll.ApplyToTripItemID,
+		WinningOptionID:   poll.WinningOptionID,
+		MyOptionIDs:       make([]int64, 0),
+		Options:           make([]model.TripPollOptionResponse, 0, len(options)),
+	}
+
+	votesByOption := make(map[int64][]entity.TripPollVote)
+	voters := make(map[int64]bool)
+	for _, vote := range votes {
+		votesByOption[vote.OptionID] = append(votesByOption[vote.OptionID], vote)
+		voters[vote.UserID] = true
+		if vote.UserID == userId {
+			response.MyOptionIDs = append(response.MyOptionIDs, vote.OptionID)
+		}
+	}
+	response.TotalVoters = len(voters)
+
+	for _, option := range options {
+		optionResponse := model.TripPollOptionResponse{
+			ID:        option.ID,
+			Label:     option.Label,
+			PlaceID:   option.PlaceID,
+			VoteCount: len(votesByOption[option.ID]),
+			Voters:    make([]model.UserInfo, 0),
+		}
+		if !poll.IsAnonymous {
+			for _, vote := range votesByOption[option.ID] {
+				voter, ok := members[vote.UserID]
+				if !ok {
+					voter = model.UserInfo{ID: vote.UserID}
+				}
+				optionResponse.Voters = append(optionResponse.Voters, voter)
+			}
+		}
+		response.Options = append(response.Options, optionResponse)
+	}
+	return response
+}
@@ -0,0 +1,46 @@
+package serviceimplement
+
+import (
+	"testing"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+func TestPollWinner(t *testing.T) {
+	options := []entity.TripPollOption{{ID: 1, Label: "Beach"}, {ID: 2, Label: "Mountains"}, {ID: 3, Label: "City"}}
+	votesFor := func(optionIDs ...int64) []entity.TripPollVote {
+		votes := make([]entity.TripPollVote, 0, len(optionIDs))
+		for i, optionID := range optionIDs {
+			votes = append(votes, entity.TripPollVote{OptionID: optionID, UserID: int64(i + 1)})
+		}
+		return votes
+	}
+
+	tests := []struct {
+		name    string
+		options []entity.TripPollOption
+		votes   []entity.TripPollVote
+		wantID  int64
+	}{
+		{"no votes", options, nil, 0},
+		{"no options", nil, votesFor(1), 0},
+		{"single vote", options, votesFor(2), 2},
+		{"clear majority", options, votesFor(1, 3, 3), 3},
+		{"tie for first", options, votesFor(1, 2), 0},
+		{"tie below the leader", options, votesFor(1, 1, 1, 2, 3), 1},
+		{"later option overtakes a tie", options, votesFor(1, 2, 3, 3), 3},
+		{"votes for a removed option are ignored", options, votesFor(9, 9, 2), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			winner := pollWinner(tt.options, tt.votes)
+			var gotID int64
+			if winner != nil {
+				gotID = winner.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("pollWinner() = option %d, want option %d", gotID, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package serviceimplement
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/bean"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/constants"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/redis_helper"
+)
+
+// events buffered per socket before a slow client is dropped and has to reconnect and refetch
+const tripSessionBufferSize = 64
+
+type tripSession struct {
+	events   chan model.TripEvent
+	presence model.TripPresence
+}
+
+// TripRealtimeService publishes trip events on a Redis channel shared by all replicas, and every replica
+// delivers what it receives to the sockets connected to it. Presence lives in a Redis hash per trip.
+type TripRealtimeService struct {
+	tripRepository       repository.TripRepository
+	tripMemberRepository repository.TripMemberRepository
+	redisClient          bean.RedisClient
+	subscribeOnce        sync.Once
+	mu                   sync.Mutex
+	sessions             map[int64]map[string]*tripSession
+}
+
+func NewTripRealtimeService(
+	tripRepository repository.TripRepository,
+	tripMemberRepository repository.TripMemberRepository,
+	redisClient bean.RedisClient,
+) service.TripRealtimeService {
+	return &TripRealtimeService{
+		tripRepository:       tripRepository,
+		tripMemberRepository: tripMemberRepository,
+		redisClient:          redisClient,
+		sessions:             make(map[int64]map[string]*tripSession),
+	}
+}
+
+func (service *TripRealtimeService) PublishEvent(ctx context.Context, tripId int64, actorId *int64, eventType string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Error("TripRealtimeService.PublishEvent Marshal data error: " + err.Error())
+		return
+	}
+	event := model.TripEvent{
+		Type:      eventType,
+		TripID:    tripId,
+		ActorID:   actorId,
+		Data:      payload,
+		CreatedAt: time.Now(),
+	}
+	message, err := json.Marshal(event)
+	if err != nil {
+		log.Error("TripRealtimeService.PublishEvent Marshal event error: " + err.Error())
+		return
+	}
+
+	err = service.redisClient.Publish(context.Background(), constants.TRIP_EVENTS_CHANNEL, message)
+	if err != nil {
+		// the sockets on this replica still hear about it
+		log.Error("TripRealtimeService.PublishEvent Publish error: " + err.Error())
+		service.dispatch(event)
+	}
+}
+
+func (service *TripRealtimeService) Connect(ctx *gin.Context, userId int64, tripId int64) (*model.TripRealtimeSession, string) {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripRealtimeService.Connect GetOneByIDQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.DB_DOWN
+	}
+	if trip == nil {
+		return nil, error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	members, err := service.tripMemberRepository.GetTripMembersQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripRealtimeService.Connect GetTripMembersQuery error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	var user *model.UserInfo
+	for _, member := range members {
+		if member.UserID == userId {
+			user = &model.UserInfo{ID: member.UserID, Name: member.Name, PhotoURL: member.PhotoURL}
+			break
+		}
+	}
+	if user == nil {
+		return nil, error_utils.ErrorCode.FORBIDDEN
+	}
+
+	sessionId, err := newTripSessionID()
+	if err != nil {
+		log.Error("TripRealtimeService.Connect newTripSessionID error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	service.subscribeOnce.Do(func() {
+		go service.listen(context.Background())
+	})
+
+	presence, err := service.getPresence(ctx, tripId)
+	if err != nil {
+		log.Error("TripRealtimeService.Connect getPresence error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	local := &tripSession{
+		events: make(chan model.TripEvent, tripSessionBufferSize),
+		presence: model.TripPresence{
+			SessionID: sessionId,
+			User:      *user,
+			UpdatedAt: time.Now(),
+		},
+	}
+	service.mu.Lock()
+	if service.sessions[tripId] == nil {
+		service.sessions[tripId] = make(map[string]*tripSession)
+	}
+	service.sessions[tripId][sessionId] = local
+	service.mu.Unlock()
+
+	session := &model.TripRealtimeSession{
+		ID:       sessionId,
+		TripID:   tripId,
+		User:     *user,
+		Events:   local.events,
+		Presence: presence,
+	}
+	service.savePresence(ctx, tripId, local.presence)
+	service.PublishEvent(ctx, tripId, &userId, model.TripEventType.PresenceUpdated, local.presence)
+	return session, ""
+}
+
+func (service *TripRealtimeService) UpdatePresence(ctx context.Context, session *model.TripRealtimeSession, presenceRequest model.TripPresenceRequest) {
+	service.mu.Lock()
+	local, ok := service.sessions[session.TripID][session.ID]
+	if !ok {
+		service.mu.Unlock()
+		return
+	}
+	local.presence.TripDay = presenceRequest.TripDay
+	local.presence.View = presenceRequest.View
+	local.presence.UpdatedAt = time.Now()
+	presence := local.presence
+	service.mu.Unlock()
+
+	service.savePresence(ctx, session.TripID, presence)
+	service.PublishEvent(ctx, session.TripID, &session.User.ID, model.TripEventType.PresenceUpdated, presence)
+}
+
+func (service *TripRealtimeService) RefreshPresence(ctx context.Context, session *model.TripRealtimeSession) {
+	service.mu.Lock()
+	local, ok := service.sessions[session.TripID][session.ID]
+	if !ok {
+		service.mu.Unlock()
+		return
+	}
+	local.presence.UpdatedAt = time.Now()
+	presence := local.presence
+	service.mu.Unlock()
+
+	service.savePresence(ctx, session.TripID, presence)
+}
+
+func (service *TripRealtimeService) Disconnect(ctx context.Context, session *model.TripRealtimeSession) {
+	service.mu.Lock()
+	service.removeSession(session.TripID, session.ID)
+	service.mu.Unlock()
+
+	err := service.redisClient.HDel(ctx, redis_helper.Concat(constants.TRIP_PRESENCE_KEY, session.TripID), session.ID)
+	if err != nil {
+		log.Error("TripRealtimeService.Disconnect HDel error: " + err.Error())
+	}
+	service.PublishEvent(ctx, session.TripID, &session.User.ID, model.TripEventType.PresenceLeft, model.TripPresence{
+		SessionID: session.ID,
+		User:      session.User,
+		UpdatedAt: time.Now(),
+	})
+}
+
+func (service *TripRealtimeService) listen(ctx context.Context) {
+	for message := range service.redisClient.Subscribe(ctx, constants.TRIP_EVENTS_CHANNEL) {
+		var event model.TripEvent
+		if err := json.Unmarshal([]byte(message), &event); err != nil {
+			log.Error("TripRealtimeService.listen Unmarshal error: " + err.Error())
+			continue
+		}
+		service.dispatch(event)
+	}
+}
+
+// dispatch hands an event to the local sockets of its trip. A socket that cannot keep up is closed
+// rather than silently missing events, and a member who left loses their sockets.
+func (service *TripRealtimeService) dispatch(event model.TripEvent) {
+	var leftUserId int64
+	if event.Type == model.TripEventType.MemberLeft {
+		var member model.UserInfo
+		if err := json.Unmarshal(event.Data, &member); err == nil {
+			leftUserId = member.ID
+		}
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	for sessionId, local := range service.sessions[event.TripID] {
+		if leftUserId != 0 && local.presence.User.ID == leftUserId {
+			service.removeSession(event.TripID, sessionId)
+			continue
+		}
+		select {
+		case local.events <- event:
+		default:
+			service.removeSession(event.TripID, sessionId)
+		}
+	}
+}
+
+// removeSession must be called with mu held
+func (service *TripRealtimeService) removeSession(tripId int64, sessionId string) {
+	local, ok := service.sessions[tripId][sessionId]
+	if !ok {
+		return
+	}
+	delete(service.sessions[tripId], sessionId)
+	if len(service.sessions[tripId]) == 0 {
+		delete(service.sessions, tripId)
+	}
+	close(local.events)
+}
+
+func (service *TripRealtimeService) savePresence(ctx context.Context, tripId int64, presence model.TripPresence) {
+	value, err := json.Marshal(presence)
+	if err != nil {
+		log.Error("TripRealtimeService.savePresence Marshal error: " + err.Error())
+		return
+	}
+	// the hash outlives a single entry, stale entries of crashed replicas are filtered out on read
+	err = service.redisClient.HSet(ctx, redis_helper.Concat(constants.TRIP_PRESENCE_KEY, tripId), presence.SessionID, value, constants.TRIP_PRESENCE_EXP_TIME)
+	if err != nil {
+		log.Error("TripRealtimeService.savePresence HSet error: " + err.Error())
+	}
+}
+
+func (service *TripRealtimeService) getPresence(ctx context.Context, tripId int64) ([]model.TripPresence, error) {
+	entries, err := service.redisClient.HGetAll(ctx, redis_helper.Concat(constants.TRIP_PRESENCE_KEY, tripId))
+	if err != nil {
+		return nil, err
+	}
+
+	staleBefore := time.Now().Add(-constants.TRIP_PRESENCE_EXP_TIME)
+	presence := make([]model.TripPresence, 0, len(entries))
+	for _, value := range entries {
+		var entry model.TripPresence
+		if err := json.Unmarshal([]byte(value), &entry); err != nil {
+			continue
+		}
+		if entry.UpdatedAt.Before(staleBefore) {
+			continue
+		}
+		presence = append(presence, entry)
+	}
+	sort.Slice(presence, func(i, j int) bool {
+		return presence[i].UpdatedAt.Before(presence[j].UpdatedAt)
+	})
+	return presence, nil
+}
+
+// tripMemberInfo names a member for member events, falling back to the bare id when the lookup fails
+func tripMemberInfo(ctx context.Context, tripMemberRepository repository.TripMemberRepository, tripId int64, userId int64) model.UserInfo {
+	members, err := tripMemberRepository.GetTripMembersQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("tripMemberInfo GetTripMembersQuery error: " + err.Error())
+		return model.UserInfo{ID: userId}
+	}
+	for _, member := range members {
+		if member.UserID == userId {
+			return model.UserInfo{ID: member.UserID, Name: member.Name, PhotoURL: member.PhotoURL}
+		}
+	}
+	return model.UserInfo{ID: userId}
+}
+
+func newTripSessionID() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(time.Now().Unix(), 36) + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,29 @@
+package serviceimplement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+func TestIsStartReminderDue(t *testing.T) {
+	trip := &entity.Trip{StartDate: time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC), TimeZone: "America/New_York"}
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"before the window", time.Date(2025, 8, 7, 3, 59, 0, 0, time.UTC), false},
+		{"midnight 3 days before in the trip zone", time.Date(2025, 8, 7, 4, 0, 0, 0, time.UTC), true},
+		{"a later hourly run", time.Date(2025, 8, 8, 17, 0, 0, 0, time.UTC), true},
+		{"once the trip started", time.Date(2025, 8, 10, 4, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isStartReminderDue(tt.now, trip); got != tt.want {
+				t.Errorf("isStartReminderDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
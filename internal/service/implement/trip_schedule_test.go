@@ -0,0 +1,194 @@
+package serviceimplement
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"00:00", 0, false},
+		{"08:30", 510, false},
+		{"23:59", 1439, false},
+		{"14:05:00", 845, false},
+		{"24:00", -1, true},
+		{"8h30", -1, true},
+		{"", -1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseClock(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseClock(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseClock(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSchedule(t *testing.T) {
+	item := func(day int64, order int64, timeInDate string, start int, end int, placeID string) scheduledItem {
+		return scheduledItem{PlaceID: placeID, TripDay: day, OrderInDay: order, TimeInDate: timeInDate, Start: start, End: end}
+	}
+	type found struct {
+		Type     string
+		Severity string
+	}
+
+	tests := []struct {
+		name     string
+		items    []scheduledItem
+		tripDays int
+		want     []found
+	}{
+		{
+			name: "valid day",
+			items: []scheduledItem{
+				item(1, 1, "morning", 8*60, 10*60, "a"),
+				item(1, 2, "afternoon", 13*60, -1, "b"),
+				item(1, 3, "evening", -1, -1, "c"),
+			},
+			tripDays: 1,
+		},
+		{
+			name:     "empty itinerary",
+			tripDays: 3,
+		},
+		{
+			name:     "beyond trip length",
+			items:    []scheduledItem{item(3, 1, "morning", -1, -1, "a")},
+			tripDays: 2,
+			want:     []found{{model.TripValidationIssueType.BeyondTripLength, model.TripValidationSeverity.Error}},
+		},
+		{
+			name:     "ends before it starts",
+			items:    []scheduledItem{item(1, 1, "morning", 10*60, 9*60, "a")},
+			tripDays: 1,
+			want:     []found{{model.TripValidationIssueType.InvalidTime, model.TripValidationSeverity.Error}},
+		},
+		{
+			name:     "unknown time of day",
+			items:    []scheduledItem{item(1, 1, "noon", -1, -1, "a")},
+			tripDays: 1,
+			want:     []found{{model.TripValidationIssueType.InvalidTime, model.TripValidationSeverity.Error}},
+		},
+		{
+			name:     "shared position",
+			items:    []scheduledItem{item(1, 1, "morning", -1, -1, "a"), item(1, 1, "morning", -1, -1, "b")},
+			tripDays: 1,
+			want:     []found{{model.TripValidationIssueType.DuplicateOrder, model.TripValidationSeverity.Error}},
+		},
+		{
+			name:     "time of day goes backwards",
+			items:    []scheduledItem{item(1, 1, "evening", -1, -1, "a"), item(1, 2, "morning", -1, -1, "b")},
+			tripDays: 1,
+			want:     []found{{model.TripValidationIssueType.OutOfOrder, model.TripValidationSeverity.Error}},
+		},
+		{
+			name:     "starts before the previous stop",
+			items:    []scheduledItem{item(1, 1, "morning", 10*60, -1, "a"), item(1, 2, "morning", 9*60, -1, "b")},
+			tripDays: 1,
+			want:     []found{{model.TripValidationIssueType.OutOfOrder, model.TripValidationSeverity.Error}},
+		},
+		{
+			name:     "overlapping slots",
+			items:    []scheduledItem{item(1, 1, "morning", 8*60, 10*60, "a"), item(1, 2, "morning", 9*60+30, -1, "b")},
+			tripDays: 1,
+			want:     []found{{model.TripValidationIssueType.Overlap, model.TripValidationSeverity.Error}},
+		},
+		{
+			name:     "untimed stop between timed ones",
+			items:    []scheduledItem{item(1, 1, "morning", 8*60, 9*60, "a"), item(1, 2, "morning", -1, -1, "b"), item(1, 3, "morning", 8*60+30, -1, "c")},
+			tripDays: 1,
+			want:     []found{{model.TripValidationIssueType.Overlap, model.TripValidationSeverity.Error}},
+		},
+		{
+			name:     "same place twice",
+			items:    []scheduledItem{item(1, 1, "morning", -1, -1, "a"), item(2, 1, "morning", -1, -1, "a")},
+			tripDays: 2,
+			want:     []found{{model.TripValidationIssueType.DuplicatePlace, model.TripValidationSeverity.Warning}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []found
+			for _, issue := range checkSchedule(tt.items, tt.tripDays) {
+				got = append(got, found{issue.Type, issue.Severity})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkSchedule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduledItemFromRequest(t *testing.T) {
+	clock := func(value string) *string { return &value }
+	minutes := func(value int64) *int64 { return &value }
+
+	tests := []struct {
+		name      string
+		request   model.TripItemRequest
+		wantStart int
+		wantEnd   int
+		wantOK    bool
+	}{
+		{"untimed", model.TripItemRequest{}, -1, -1, true},
+		{"start and end", model.TripItemRequest{StartTime: clock("09:00"), EndTime: clock("10:30")}, 540, 630, true},
+		{"duration", model.TripItemRequest{StartTime: clock("09:00"), DurationMinutes: minutes(45)}, 540, 585, true},
+		{"duration agrees with end", model.TripItemRequest{StartTime: clock("09:00"), EndTime: clock("09:45"), DurationMinutes: minutes(45)}, 540, 585, true},
+		{"duration disagrees with end", model.TripItemRequest{StartTime: clock("09:00"), EndTime: clock("10:00"), DurationMinutes: minutes(45)}, 0, 0, false},
+		{"duration without start", model.TripItemRequest{DurationMinutes: minutes(45)}, 0, 0, false},
+		{"duration past midnight", model.TripItemRequest{StartTime: clock("23:30"), DurationMinutes: minutes(45)}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, ok := scheduledItemFromRequest(tt.request)
+			if ok != tt.wantOK {
+				t.Fatalf("scheduledItemFromRequest() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (item.Start != tt.wantStart || item.End != tt.wantEnd) {
+				t.Errorf("scheduledItemFromRequest() = %d-%d, want %d-%d", item.Start, item.End, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestNormaliseGeneratedTripItems(t *testing.T) {
+	items := []model.TripItemFromAIResponse{
+		{TripDay: 1, OrderInDay: 2, TimeInDay: "morning", PlaceID: "b"},
+		{TripDay: 1, OrderInDay: 1, TimeInDay: "afternoon", PlaceID: "a"},
+		{TripDay: 1, OrderInDay: 2, TimeInDay: "evening", PlaceID: "c"},
+		{TripDay: 2, OrderInDay: 5, TimeInDay: "noon", PlaceID: "d"},
+		{TripDay: 3, OrderInDay: 1, TimeInDay: "morning", PlaceID: "e"},
+	}
+	want := []model.TripItemFromAIResponse{
+		{TripDay: 1, OrderInDay: 1, TimeInDay: "afternoon", PlaceID: "a"},
+		{TripDay: 1, OrderInDay: 2, TimeInDay: "afternoon", PlaceID: "b"},
+		{TripDay: 1, OrderInDay: 3, TimeInDay: "evening", PlaceID: "c"},
+		{TripDay: 2, OrderInDay: 1, TimeInDay: "morning", PlaceID: "d"},
+	}
+
+	got := normaliseGeneratedTripItems(items, 2)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("normaliseGeneratedTripItems() = %+v, want %+v", got, want)
+	}
+
+	scheduledItems := make([]scheduledItem, 0, len(got))
+	for _, item := range got {
+		scheduledItems = append(scheduledItems, scheduledItem{PlaceID: item.PlaceID, TripDay: item.TripDay, OrderInDay: item.OrderInDay, TimeInDate: item.TimeInDay, Start: -1, End: -1})
+	}
+	for _, issue := range checkSchedule(scheduledItems, 2) {
+		if issue.Severity == model.TripValidationSeverity.Error {
+			t.Errorf("normalised items still fail the schedule check: %+v", issue)
+		}
+	}
+}
@@ -2,46 +2,97 @@ package serviceimplement
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"io"
+	"math"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/bean"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/repository"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/service"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/constants"
+	currencyutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/currency_utils"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/env"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/error_utils"
+	timezoneutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/timezone_utils"
+	trippermissionutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_permission_utils"
+	tripstatusutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/trip_status_utils"
 )
 
 type TripService struct {
-	tripRepository       repository.TripRepository
-	unitOfWork           repository.UnitOfWork
-	tripMemberRepository repository.TripMemberRepository
-	tripItemService      service.TripItemService
-	notificationService  service.NotificationService
+	tripRepository          repository.TripRepository
+	unitOfWork              repository.UnitOfWork
+	tripMemberRepository    repository.TripMemberRepository
+	tripSegmentRepository   repository.TripSegmentRepository
+	tripItemRepository      repository.TripItemRepository
+	tripItemService         service.TripItemService
+	notificationService     service.NotificationService
+	tripBookingRepository   repository.TripBookingRepository
+	tripRealtimeService     service.TripRealtimeService
+	tripActivityRepository  repository.TripActivityRepository
+	tripImageRepository     repository.TripImageRepository
+	tripDocumentRepository  repository.TripDocumentRepository
+	s3Service               bean.S3Service
+	syncTombstoneRepository repository.SyncTombstoneRepository
+	tripReminderRepository  repository.TripReminderRepository
+	exchangeRateRepository  repository.ExchangeRateRepository
 }
 
 func NewTripService(
 	tripRepository repository.TripRepository,
 	unitOfWork repository.UnitOfWork,
 	tripMemberRepository repository.TripMemberRepository,
+	tripSegmentRepository repository.TripSegmentRepository,
+	tripItemRepository repository.TripItemRepository,
 	tripItemService service.TripItemService,
 	notificationService service.NotificationService,
+	tripBookingRepository repository.TripBookingRepository,
+	tripRealtimeService service.TripRealtimeService,
+	tripActivityRepository repository.TripActivityRepository,
+	tripImageRepository repository.TripImageRepository,
+	tripDocumentRepository repository.TripDocumentRepository,
+	s3Service bean.S3Service,
+	syncTombstoneRepository repository.SyncTombstoneRepository,
+	tripReminderRepository repository.TripReminderRepository,
+	exchangeRateRepository repository.ExchangeRateRepository,
 ) service.TripService {
 	return &TripService{
-		tripRepository:       tripRepository,
-		unitOfWork:           unitOfWork,
-		tripMemberRepository: tripMemberRepository,
-		tripItemService:      tripItemService,
-		notificationService:  notificationService,
+		tripRepository:          tripRepository,
+		unitOfWork:              unitOfWork,
+		tripMemberRepository:    tripMemberRepository,
+		tripSegmentRepository:   tripSegmentRepository,
+		tripItemRepository:      tripItemRepository,
+		tripItemService:         tripItemService,
+		notificationService:     notificationService,
+		tripBookingRepository:   tripBookingRepository,
+		tripRealtimeService:     tripRealtimeService,
+		tripActivityRepository:  tripActivityRepository,
+		tripImageRepository:     tripImageRepository,
+		tripDocumentRepository:  tripDocumentRepository,
+		s3Service:               s3Service,
+		syncTombstoneRepository: syncTombstoneRepository,
+		tripReminderRepository:  tripReminderRepository,
+		exchangeRateRepository:  exchangeRateRepository,
 	}
 }
 
+// maxCoreDays is the longest stretch the core planner generates in one call
+const maxCoreDays = 7
+
+// hotelCheckInHour and hotelCheckOutHour place the chosen hotel on the timeline
+const (
+	hotelCheckInHour  = 14
+	hotelCheckOutHour = 12
+)
+
 func (service *TripService) CreateTrip(ctx *gin.Context, tripRequest model.CreateTripManuallyRequest, userId int64) (int64, string) {
 	tx, err := service.unitOfWork.Begin(ctx)
 	if err != nil {
@@ -52,12 +103,40 @@ func (service *TripService) CreateTrip(ctx *gin.Context, tripRequest model.Creat
 
 	if tripRequest.Status == "" {
 		tripRequest.Status = model.TripStatus.NotStarted
+		if tripRequest.Draft {
+			tripRequest.Status = model.TripStatus.Draft
+		}
+	}
+	if !tripstatusutils.IsInitial(tripRequest.Status) {
+		return 0, error_utils.ErrorCode.BAD_REQUEST
+	}
+	if tripRequest.City == "" && len(tripRequest.Segments) > 0 {
+		tripRequest.City = tripRequest.Segments[0].City
+	}
+
+	// the zone decides when the trip starts and ends, default it from the city
+	if tripRequest.TimeZone == "" {
+		tripRequest.TimeZone = timezoneutils.ForCity(tripRequest.City)
+	} else if !timezoneutils.IsValid(tripRequest.TimeZone) {
+		return 0, error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	if tripRequest.BudgetCurrency == "" {
+		tripRequest.BudgetCurrency = currencyutils.Default()
+	}
+	budgetCurrency, ok := currencyutils.Normalise(tripRequest.BudgetCurrency)
+	if !ok {
+		return 0, error_utils.ErrorCode.BAD_REQUEST
 	}
+
 	trip := &entity.Trip{
 		Title:                 tripRequest.Title,
 		City:                  tripRequest.City,
-		StartDate:             tripRequest.StartDate,
+		StartDate:             timezoneutils.TripStartDate(tripRequest.StartDate, timezoneutils.Location(tripRequest.TimeZone)),
+		TimeZone:              tripRequest.TimeZone,
 		Days:                  tripRequest.Days,
+		Budget:                tripRequest.Budget,
+		BudgetCurrency:        budgetCurrency,
 		ViLocationAttributes:  tripRequest.ViLocationAttributes,
 		ViFoodAttributes:      tripRequest.ViFoodAttributes,
 		ViSpecialRequirements: tripRequest.ViSpecialRequirements,
@@ -76,12 +155,19 @@ func (service *TripService) CreateTrip(ctx *gin.Context, tripRequest model.Creat
 		log.Error("TripService.CreateTrip Error: " + err.Error())
 		return 0, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
+	trip.ID = tripID
+
+	// create the city segments, a single one covering the whole trip if none are given
+	errCode := service.replaceTripSegmentsHelper(ctx, trip, tripRequest.Segments, nil, tx)
+	if errCode != "" {
+		return 0, errCode
+	}
 
-	// create trip member as administrator
+	// the creator owns the trip
 	member := &entity.TripMember{
 		TripID: tripID,
 		UserID: userId,
-		Role:   model.TripMemberRole.Administrator,
+		Role:   model.TripMemberRole.Owner,
 	}
 	err = service.tripMemberRepository.CreateCommand(ctx, member, tx)
 	if err != nil {
@@ -99,28 +185,111 @@ func (service *TripService) CreateTrip(ctx *gin.Context, tripRequest model.Creat
 	return tripID, ""
 }
 
-func (service *TripService) GetAllTripsByUserID(ctx *gin.Context, userId int64) ([]*model.TripResponse, string) {
-	trips, err := service.tripRepository.GetAllWithUserRoleByUserIdQuery(ctx, userId, nil)
+// tripSortColumns maps the sorts a client may ask for to the trip columns behind them
+var tripSortColumns = map[string]string{
+	model.TripSort.StartDate: "start_date",
+	model.TripSort.CreatedAt: "created_at",
+	model.TripSort.UpdatedAt: "updated_at",
+}
+
+// tripListCursor is the position after the last trip of a page, opaque to clients
+type tripListCursor struct {
+	Sort       string    `json:"sort"`
+	Descending bool      `json:"desc"`
+	Value      time.Time `json:"value"`
+	ID         int64     `json:"id"`
+}
+
+func (service *TripService) GetAllTripsByUserID(ctx *gin.Context, userId int64, request model.TripListRequest) (*model.TripPageResponse, string) {
+	if request.Sort == "" {
+		request.Sort = model.TripSort.StartDate
+	}
+	sortColumn, ok := tripSortColumns[request.Sort]
+	if !ok {
+		return nil, error_utils.ErrorCode.BAD_REQUEST
+	}
+	for _, status := range request.Statuses {
+		if !tripstatusutils.IsValid(status) {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+	}
+	for _, role := range request.Roles {
+		if !trippermissionutils.IsValidRole(role) {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+	}
+
+	filter := repository.TripListFilter{
+		Statuses:   request.Statuses,
+		City:       request.City,
+		From:       request.From,
+		Roles:      request.Roles,
+		Search:     request.Search,
+		SortColumn: sortColumn,
+		Descending: request.Descending,
+	}
+	if request.Limit > 0 {
+		// one extra row tells whether another page follows
+		filter.Limit = request.Limit + 1
+	}
+	if request.To != nil {
+		// the range includes the whole last day
+		to := request.To.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if request.Cursor != "" {
+		cursor, ok := decodeTripListCursor(request.Cursor)
+		if !ok || cursor.Sort != request.Sort || cursor.Descending != request.Descending {
+			return nil, error_utils.ErrorCode.BAD_REQUEST
+		}
+		filter.After = &repository.TripListCursor{SortValue: cursor.Value, ID: cursor.ID}
+	}
+
+	trips, err := service.tripRepository.GetPageWithUserRoleByUserIdQuery(ctx, userId, filter, nil)
 	if err != nil {
 		log.Error("TripService.GetAllTripsByUserID Error: " + err.Error())
 		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 
-	var tripResponses []*model.TripResponse
-	for _, trip := range trips {
-		members, err := service.tripMemberRepository.GetTripMembersQuery(ctx, trip.ID, nil)
+	response := &model.TripPageResponse{
+		Trips: make([]*model.TripResponse, 0, len(trips)),
+	}
+	if request.Limit > 0 && len(trips) > request.Limit {
+		trips = trips[:request.Limit]
+		last := trips[len(trips)-1]
+		nextCursor, err := encodeTripListCursor(tripListCursor{
+			Sort:       request.Sort,
+			Descending: request.Descending,
+			Value:      tripSortValue(&last.Trip, request.Sort),
+			ID:         last.ID,
+		})
 		if err != nil {
-			log.Error("TripService.GetAllTripsByUserID - Get trip members Error: " + err.Error())
+			log.Error("TripService.GetAllTripsByUserID - encodeTripListCursor Error: " + err.Error())
 			return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 		}
-		memberCount := len(members)
+		response.NextCursor = &nextCursor
+	}
+
+	tripIDs := make([]int64, len(trips))
+	for i, trip := range trips {
+		tripIDs[i] = trip.ID
+	}
+	memberCounts, err := service.tripMemberRepository.CountByTripIDsQuery(ctx, tripIDs, nil)
+	if err != nil {
+		log.Error("TripService.GetAllTripsByUserID - Count trip members Error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	for _, trip := range trips {
 		tripResponse := &model.TripResponse{
 			ID:                    trip.ID,
 			Title:                 trip.Title,
 			City:                  trip.City,
 			StartDate:             trip.StartDate,
+			TimeZone:              trip.TimeZone,
 			Days:                  trip.Days,
 			Budget:                trip.Budget,
+			BudgetCurrency:        trip.BudgetCurrency,
 			ViLocationAttributes:  trip.ViLocationAttributes,
 			ViFoodAttributes:      trip.ViFoodAttributes,
 			ViSpecialRequirements: trip.ViSpecialRequirements,
@@ -131,12 +300,43 @@ func (service *TripService) GetAllTripsByUserID(ctx *gin.Context, userId int64)
 			EnMedicalConditions:   trip.EnMedicalConditions,
 			Status:                trip.Status,
 			Role:                  trip.Role,
-			MemberCount:           memberCount,
+			MemberCount:           memberCounts[trip.ID],
 		}
-		tripResponses = append(tripResponses, tripResponse)
+		response.Trips = append(response.Trips, tripResponse)
+	}
+
+	return response, ""
+}
+
+func tripSortValue(trip *entity.Trip, sort string) time.Time {
+	switch sort {
+	case model.TripSort.CreatedAt:
+		return trip.CreatedAt
+	case model.TripSort.UpdatedAt:
+		return trip.UpdatedAt
+	default:
+		return trip.StartDate
 	}
+}
+
+func encodeTripListCursor(cursor tripListCursor) (string, error) {
+	encoded, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
 
-	return tripResponses, ""
+func decodeTripListCursor(value string) (tripListCursor, bool) {
+	var cursor tripListCursor
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, false
+	}
+	if err := json.Unmarshal(decoded, &cursor); err != nil || cursor.ID < 1 {
+		return cursor, false
+	}
+	return cursor, true
 }
 
 func (service *TripService) GetTripByID(ctx *gin.Context, tripId int64, userId int64) (*model.TripResponse, string) {
@@ -166,13 +366,39 @@ func (service *TripService) GetTripByID(ctx *gin.Context, tripId int64, userId i
 	}
 	memberCount := len(members)
 
+	segments, err := service.tripSegmentRepository.GetAllByTripIDQuery(ctx, trip.ID, nil)
+	if err != nil {
+		log.Error("TripService.GetTripByID - Get trip segments Error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	// trips created before segments existed are a single city
+	if len(segments) == 0 {
+		segments = []entity.TripSegment{singleTripSegment(&trip.Trip)}
+	}
+	segmentResponses := make([]model.TripSegmentResponse, 0, len(segments))
+	for _, segment := range segments {
+		segmentResponses = append(segmentResponses, model.TripSegmentResponse{
+			Order:                segment.SegmentOrder,
+			City:                 segment.City,
+			StartDay:             segment.StartDay,
+			EndDay:               segment.StartDay + segment.Days - 1,
+			Days:                 segment.Days,
+			ViLocationAttributes: segment.ViLocationAttributes,
+			ViFoodAttributes:     segment.ViFoodAttributes,
+			EnLocationAttributes: segment.EnLocationAttributes,
+			EnFoodAttributes:     segment.EnFoodAttributes,
+		})
+	}
+
 	tripResponse := &model.TripResponse{
 		ID:                    trip.ID,
 		Title:                 trip.Title,
 		City:                  trip.City,
 		StartDate:             trip.StartDate,
+		TimeZone:              trip.TimeZone,
 		Days:                  trip.Days,
 		Budget:                trip.Budget,
+		BudgetCurrency:        trip.BudgetCurrency,
 		ViLocationAttributes:  trip.ViLocationAttributes,
 		ViFoodAttributes:      trip.ViFoodAttributes,
 		ViSpecialRequirements: trip.ViSpecialRequirements,
@@ -184,11 +410,74 @@ func (service *TripService) GetTripByID(ctx *gin.Context, tripId int64, userId i
 		Status:                trip.Status,
 		Role:                  trip.Role,
 		MemberCount:           memberCount,
+		Segments:              segmentResponses,
 	}
 
+	tripItems, err := service.tripItemRepository.GetTripItemsByTripIDCommand(ctx, trip.ID, userId, nil)
+	if err != nil {
+		log.Error("TripService.GetTripByID - Get trip items Error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	tripResponse.CostEstimate = costEstimateHelper(&trip.Trip, tripItems)
+
 	return tripResponse, ""
 }
 
+// costEstimateHelper totals the item estimates per day and category and flags where they exceed the budget.
+// Days are measured against an even share of the budget, and nothing is flagged while no budget is set.
+func costEstimateHelper(trip *entity.Trip, tripItems []entity.TripItem) *model.TripCostEstimate {
+	var totalCents int64
+	dayCents := make(map[int64]int64)
+	categoryCents := make(map[string]int64)
+	unestimated := 0
+	for _, item := range tripItems {
+		if item.EstimatedCost == nil {
+			unestimated++
+			continue
+		}
+		cents := int64(math.Round(*item.EstimatedCost * 100))
+		totalCents += cents
+		dayCents[item.TripDay] += cents
+		category := model.ExpenseCategory.Other
+		if item.CostCategory != nil {
+			category = *item.CostCategory
+		}
+		categoryCents[category] += cents
+	}
+
+	budgetCents := int64(math.Round(trip.Budget * 100))
+	var allowanceCents int64
+	if trip.Days > 0 {
+		allowanceCents = budgetCents / int64(trip.Days)
+	}
+
+	estimate := &model.TripCostEstimate{
+		Currency:         trip.BudgetCurrency,
+		Estimated:        float64(totalCents) / 100,
+		OverBudget:       budgetCents > 0 && totalCents > budgetCents,
+		ByCategory:       make([]model.CategoryCostEstimate, 0, len(categoryCents)),
+		Days:             make([]model.DayCostEstimate, 0, trip.Days),
+		UnestimatedItems: unestimated,
+	}
+	for _, category := range model.ExpenseCategories {
+		if cents, ok := categoryCents[category]; ok {
+			estimate.ByCategory = append(estimate.ByCategory, model.CategoryCostEstimate{
+				Category:  category,
+				Estimated: float64(cents) / 100,
+			})
+		}
+	}
+	for tripDay := int64(1); tripDay <= int64(trip.Days); tripDay++ {
+		estimate.Days = append(estimate.Days, model.DayCostEstimate{
+			TripDay:    tripDay,
+			Estimated:  float64(dayCents[tripDay]) / 100,
+			Allowance:  float64(allowanceCents) / 100,
+			OverBudget: budgetCents > 0 && dayCents[tripDay] > allowanceCents,
+		})
+	}
+	return estimate
+}
+
 func (service *TripService) updatedTripHelper(ctx *gin.Context, tripId int64, tripRequest model.TripPatchRequest, tx *sqlx.Tx) string {
 	// Get existing trip
 	existingTrip, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, tx)
@@ -207,8 +496,15 @@ func (service *TripService) updatedTripHelper(ctx *gin.Context, tripId int64, tr
 	if tripRequest.City != nil {
 		existingTrip.City = *tripRequest.City
 	}
+	if tripRequest.TimeZone != nil {
+		if !timezoneutils.IsValid(*tripRequest.TimeZone) {
+			return error_utils.ErrorCode.BAD_REQUEST
+		}
+		existingTrip.TimeZone = *tripRequest.TimeZone
+	}
+	// read after the zone so a date sent with a new zone lands on the right day
 	if tripRequest.StartDate != nil {
-		existingTrip.StartDate = *tripRequest.StartDate
+		existingTrip.StartDate = timezoneutils.TripStartDate(*tripRequest.StartDate, timezoneutils.Location(existingTrip.TimeZone))
 	}
 	if tripRequest.Days != nil {
 		existingTrip.Days = *tripRequest.Days
@@ -216,6 +512,19 @@ func (service *TripService) updatedTripHelper(ctx *gin.Context, tripId int64, tr
 	if tripRequest.Budget != nil {
 		existingTrip.Budget = *tripRequest.Budget
 	}
+	if tripRequest.BudgetCurrency != nil {
+		budgetCurrency, ok := currencyutils.Normalise(*tripRequest.BudgetCurrency)
+		if !ok {
+			return error_utils.ErrorCode.BAD_REQUEST
+		}
+		if budgetCurrency != existingTrip.BudgetCurrency {
+			errCode := service.convertItemCostsHelper(ctx, tripId, existingTrip.BudgetCurrency, budgetCurrency, tx)
+			if errCode != "" {
+				return errCode
+			}
+		}
+		existingTrip.BudgetCurrency = budgetCurrency
+	}
 	if tripRequest.ViLocationAttributes != nil {
 		existingTrip.ViLocationAttributes = *tripRequest.ViLocationAttributes
 	}
@@ -240,13 +549,45 @@ func (service *TripService) updatedTripHelper(ctx *gin.Context, tripId int64, tr
 	if tripRequest.EnMedicalConditions != nil {
 		existingTrip.EnMedicalConditions = *tripRequest.EnMedicalConditions
 	}
-	if tripRequest.Status != nil {
-		existingTrip.Status = *tripRequest.Status
+	if tripRequest.Status != nil && *tripRequest.Status != existingTrip.Status {
+		if !moveTripStatus(existingTrip, *tripRequest.Status) {
+			return error_utils.ErrorCode.TRIP_INVALID_STATUS_TRANSITION
+		}
 	}
 	if tripRequest.ReferenceID != nil {
 		existingTrip.ReferenceID = tripRequest.ReferenceID
 	}
 
+	// keep the segments covering exactly the trip days
+	if tripRequest.Segments != nil || tripRequest.Days != nil || tripRequest.City != nil {
+		segments, err := service.tripSegmentRepository.GetAllByTripIDQuery(ctx, tripId, tx)
+		if err != nil {
+			log.Error("TripService.updatedTripHelper - Get trip segments Error: " + err.Error())
+			return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+
+		var segmentRequests []model.TripSegmentRequest
+		if tripRequest.Segments != nil {
+			segmentRequests = *tripRequest.Segments
+			// the city of a trip is the city of its first segment
+			if tripRequest.City != nil && len(segmentRequests) > 0 && *tripRequest.City != segmentRequests[0].City {
+				return error_utils.ErrorCode.BAD_REQUEST
+			}
+		} else if len(segments) > 1 {
+			// a multi-city trip is resized and moved through its segments, so only a no-op days or city is accepted
+			lastSegment := segments[len(segments)-1]
+			if existingTrip.Days != lastSegment.StartDay+lastSegment.Days-1 || existingTrip.City != segments[0].City {
+				return error_utils.ErrorCode.BAD_REQUEST
+			}
+			segmentRequests = tripSegmentRequests(segments)
+		}
+
+		errCode := service.replaceTripSegmentsHelper(ctx, existingTrip, segmentRequests, segments, tx)
+		if errCode != "" {
+			return errCode
+		}
+	}
+
 	// Update trip
 	err = service.tripRepository.UpdateCommand(ctx, existingTrip, tx)
 	if err != nil {
@@ -257,6 +598,31 @@ func (service *TripService) updatedTripHelper(ctx *gin.Context, tripId int64, tr
 	return ""
 }
 
+// convertItemCostsHelper moves the item estimates, which are kept in the budget currency, over to a new one.
+// A currency without a rate is rejected rather than leaving the estimates in the wrong unit.
+func (service *TripService) convertItemCostsHelper(ctx *gin.Context, tripId int64, from string, to string, tx *sqlx.Tx) string {
+	rates, err := service.exchangeRateRepository.GetAllQuery(ctx, tx)
+	if err != nil {
+		log.Error("TripService.convertItemCostsHelper - Get exchange rates Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	unitsPerUSD := make(map[string]float64, len(rates))
+	for _, rate := range rates {
+		unitsPerUSD[rate.Currency] = rate.UnitsPerUSD
+	}
+	rate, ok := currencyutils.Rate(from, to, unitsPerUSD)
+	if !ok {
+		return error_utils.ErrorCode.BAD_REQUEST
+	}
+
+	err = service.tripItemRepository.ConvertEstimatedCostsCommand(ctx, tripId, rate, tx)
+	if err != nil {
+		log.Error("TripService.convertItemCostsHelper - Convert item costs Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	return ""
+}
+
 func (service *TripService) UpdateTrip(ctx *gin.Context, tripId int64, userId int64, tripRequest model.TripPatchRequest) string {
 	tx, err := service.unitOfWork.Begin(ctx)
 	if err != nil {
@@ -265,21 +631,63 @@ func (service *TripService) UpdateTrip(ctx *gin.Context, tripId int64, userId in
 	}
 	defer service.unitOfWork.Rollback(tx)
 
-	// Check if user is admin
-	isAdmin, err := service.tripMemberRepository.IsUserTripAdminQuery(ctx, tripId, userId, tx)
+	// lock the trip before any other read so concurrent edits and the status cron wait for this one
+	tripBefore, err := service.tripRepository.SelectForUpdateById(ctx, tripId, tx)
 	if err != nil {
-		log.Error("TripService.UpdateTrip - Check admin Error: " + err.Error())
+		log.Error("TripService.UpdateTrip - Lock trip Error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
-	if !isAdmin {
+
+	role, err := service.tripMemberRepository.GetMemberRoleQuery(ctx, tripId, userId, tx)
+	if err != nil {
+		log.Error("TripService.UpdateTrip - Get role Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if !trippermissionutils.CanEditTrip(role) {
 		return error_utils.ErrorCode.FORBIDDEN
 	}
 
+	if tripBefore == nil {
+		return error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+	// cancelling and archiving notify the members, so they have their own endpoints
+	if tripRequest.Status != nil && *tripRequest.Status != tripBefore.Status && tripstatusutils.NeedsDedicatedAction(tripBefore.Status, *tripRequest.Status) {
+		return error_utils.ErrorCode.TRIP_INVALID_STATUS_TRANSITION
+	}
+
 	errCode := service.updatedTripHelper(ctx, tripId, tripRequest, tx)
 	if errCode != "" {
 		return errCode
 	}
 
+	tripAfter, err := service.tripRepository.GetOneByIDQuery(ctx, tripId, tx)
+	if err != nil {
+		log.Error("TripService.UpdateTrip - Get trip Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	before, after, err := changedTripFields(tripBefore, tripAfter)
+	if err != nil {
+		log.Error("TripService.UpdateTrip - changedTripFields Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if len(after) > 0 {
+		err = recordTripActivity(ctx, service.tripActivityRepository, tripActivityRecord{
+			activity: entity.TripActivity{
+				TripID:     tripId,
+				ActorID:    &userId,
+				Action:     model.TripActivityAction.TripUpdated,
+				EntityType: model.TripActivityEntityType.Trip,
+				EntityID:   &tripId,
+			},
+			before: before,
+			after:  after,
+		}, tx)
+		if err != nil {
+			log.Error("TripService.UpdateTrip - recordTripActivity Error: " + err.Error())
+			return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+	}
+
 	// Commit transaction
 	err = service.unitOfWork.Commit(tx)
 	if err != nil {
@@ -287,6 +695,64 @@ func (service *TripService) UpdateTrip(ctx *gin.Context, tripId int64, userId in
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 
+	service.tripRealtimeService.PublishEvent(ctx, tripId, &userId, model.TripEventType.TripUpdated, tripRequest)
+
+	return ""
+}
+
+type hotelStay struct {
+	hotel    *model.HotelChoiceRequest
+	startDay int
+	days     int
+}
+
+func (service *TripService) createHotelBookings(ctx *gin.Context, tripID int64, userID int64, stays []hotelStay) string {
+	trip, err := service.tripRepository.GetOneByIDQuery(ctx, tripID, nil)
+	if err != nil {
+		log.Error("TripService.createHotelBookings - GetOneByIDQuery Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if trip == nil {
+		return error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripService.createHotelBookings - BeginTx Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	for _, stay := range stays {
+		checkIn := timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, int64(stay.startDay)).Add(hotelCheckInHour * time.Hour)
+		checkOut := timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, int64(stay.startDay+stay.days)).Add(hotelCheckOutHour * time.Hour)
+		booking := &entity.TripBooking{
+			TripID:      tripID,
+			CreatedBy:   userID,
+			Type:        model.BookingType.Hotel,
+			Title:       stay.hotel.Name,
+			Lat:         &stay.hotel.Location.Lat,
+			Long:        &stay.hotel.Location.Long,
+			StartAt:     checkIn,
+			EndAt:       &checkOut,
+			Attachments: make([]string, 0),
+		}
+		if stay.hotel.Address != "" {
+			booking.Address = &stay.hotel.Address
+		}
+
+		_, err = service.tripBookingRepository.CreateCommand(ctx, booking, tx)
+		if err != nil {
+			log.Error("TripService.createHotelBookings - CreateCommand Error: " + err.Error())
+			return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+	}
+
+	err = service.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripService.createHotelBookings - Commit Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
 	return ""
 }
 
@@ -361,24 +827,16 @@ func (service *TripService) createTripItems(createTourURL string, token string,
 	return tripItemsResp.Data.TripItems, tripItemsResp.Data.ReferenceID, ""
 }
 
-func (service *TripService) CreateTripByAI(ctx *gin.Context, tripRequest model.CreateTripByAIRequest, userID int64) ([]model.TripItemFromAIResponse, int64, string) {
-	tripToCoreRequest := model.TripToCoreRequest{
-		City:                tripRequest.City,
-		Days:                tripRequest.Days,
-		LocationAttributes:  tripRequest.EnLocationAttributes,
-		FoodAttributes:      tripRequest.EnFoodAttributes,
-		SpecialRequirements: tripRequest.EnSpecialRequirements,
-		MedicalConditions:   tripRequest.EnMedicalConditions,
-		LocationsPerDay:     tripRequest.LocationsPerDay,
-		LocationPreference:  tripRequest.LocationPreference,
-	}
-
+func (service *TripService) CreateTripByAI(ctx *gin.Context, tripRequest model.CreateTripByAIRequest, userID int64) (tripItems []model.TripItemFromAIResponse, tripID int64, errCode string) {
 	// save trip to database
 	createTripManuallyRequest := model.CreateTripManuallyRequest{
 		Title:                 tripRequest.Title,
 		City:                  tripRequest.City,
 		StartDate:             tripRequest.StartDate,
+		TimeZone:              tripRequest.TimeZone,
 		Days:                  tripRequest.Days,
+		Budget:                tripRequest.Budget,
+		BudgetCurrency:        tripRequest.BudgetCurrency,
 		ViLocationAttributes:  tripRequest.ViLocationAttributes,
 		ViFoodAttributes:      tripRequest.ViFoodAttributes,
 		ViSpecialRequirements: tripRequest.ViSpecialRequirements,
@@ -387,24 +845,29 @@ func (service *TripService) CreateTripByAI(ctx *gin.Context, tripRequest model.C
 		EnFoodAttributes:      tripRequest.EnFoodAttributes,
 		EnSpecialRequirements: tripRequest.EnSpecialRequirements,
 		EnMedicalConditions:   tripRequest.EnMedicalConditions,
+		Segments:              tripRequest.Segments,
 		Status:                model.TripStatus.AIGenerating,
 	}
-	tripID, errCode := service.CreateTrip(ctx, createTripManuallyRequest, userID)
+	tripID, errCode = service.CreateTrip(ctx, createTripManuallyRequest, userID)
 	if errCode != "" {
 		return []model.TripItemFromAIResponse{}, tripID, errCode
 	}
-	var tripStatus string
+
+	// the trip leaves ai_generating however this ends: not_started once everything is saved, failed otherwise
+	var referenceID string
+	segmentReferenceIDs := make(map[int]string)
+	defer func() {
+		service.finishAIGenerationHelper(ctx, tripID, errCode == "", referenceID, segmentReferenceIDs)
+	}()
 
 	// get secret key & generate token URL
 	secretKey, getSecretKeyErr := env.GetEnv("CORE_SECRET_KEY")
 	if getSecretKeyErr != nil {
-		tripStatus = model.TripStatus.Failed
 		log.Error("TripService.CreateTripByAI - Get CORE_SECRET_KEY Error: " + getSecretKeyErr.Error())
 		return []model.TripItemFromAIResponse{}, tripID, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 	genTokenURL, getGenTokenURLErr := env.GetEnv("GEN_TOKEN_URL")
 	if getGenTokenURLErr != nil {
-		tripStatus = model.TripStatus.Failed
 		log.Error("TripService.CreateTripByAI - Get GEN_TOKEN_URL Error: " + getGenTokenURLErr.Error())
 		return []model.TripItemFromAIResponse{}, tripID, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
@@ -412,7 +875,6 @@ func (service *TripService) CreateTripByAI(ctx *gin.Context, tripRequest model.C
 	// call gen token URL to get token
 	token, genTokenErr := service.genToken(secretKey, genTokenURL)
 	if genTokenErr != "" {
-		tripStatus = model.TripStatus.Failed
 		log.Error("TripService.CreateTripByAI - Generate token Error: " + genTokenErr)
 		return []model.TripItemFromAIResponse{}, tripID, genTokenErr
 	}
@@ -420,47 +882,72 @@ func (service *TripService) CreateTripByAI(ctx *gin.Context, tripRequest model.C
 	// get create tour URL
 	createTourURL, createTourURLErr := env.GetEnv("CREATE_TOUR_URL")
 	if createTourURLErr != nil {
-		tripStatus = model.TripStatus.Failed
 		log.Error("TripService.CreateTripByAI - Get CREATE_TOUR_URL Error: " + createTourURLErr.Error())
 		return []model.TripItemFromAIResponse{}, tripID, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 
-	// send trip data to core service to get trip items
-	tripItemsRespFromCore, referenceID, createTripItemsError := service.createTripItems(createTourURL, token, tripToCoreRequest)
-	if createTripItemsError != "" {
-		tripStatus = model.TripStatus.Failed
-		log.Error("TripService.CreateTripByAI - Create trip items Error: " + createTripItemsError)
-		return []model.TripItemFromAIResponse{}, tripID, createTripItemsError
+	segments, getSegmentsErr := service.tripSegmentRepository.GetAllByTripIDQuery(ctx, tripID, nil)
+	if getSegmentsErr != nil {
+		log.Error("TripService.CreateTripByAI - Get trip segments Error: " + getSegmentsErr.Error())
+		return []model.TripItemFromAIResponse{}, tripID, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 
-	// update reference ID and status
-	defer func() {
-		if tripID != 0 {
-			tx, txErr := service.unitOfWork.Begin(ctx)
-			if txErr != nil {
-				log.Error("TripService.CreateTripByAI - BeginTx Error: " + txErr.Error())
-				return
-			}
-			defer service.unitOfWork.Rollback(tx)
+	// send every segment to core service separately and stitch the days together
+	var tripItemsRespFromCore []model.TripItemFromAIResponse
+	plannedPlaces := make(map[string]bool)
+	var hotelStays []hotelStay
+	for _, segment := range segments {
+		// every day of the segment starts from the chosen hotel, a per-segment pick beats the trip-wide one
+		hotel := tripRequest.Hotel
+		if segment.SegmentOrder <= len(tripRequest.Segments) && tripRequest.Segments[segment.SegmentOrder-1].Hotel != nil {
+			hotel = tripRequest.Segments[segment.SegmentOrder-1].Hotel
+		}
+		var startLocation *model.GeoPoint
+		if hotel != nil {
+			startLocation = &hotel.Location
+			hotelStays = append(hotelStays, hotelStay{hotel: hotel, startDay: segment.StartDay, days: segment.Days})
+		}
 
-			tripStatus = model.TripStatus.NotStarted
-			tripRequestForUpdate := model.TripPatchRequest{
-				Status:      &tripStatus,
-				ReferenceID: &referenceID,
+		// long segments are planned in chunks the core service accepts
+		for offset := 0; offset < segment.Days; offset += maxCoreDays {
+			tripToCoreRequest := model.TripToCoreRequest{
+				City:                segment.City,
+				Days:                min(maxCoreDays, segment.Days-offset),
+				LocationAttributes:  segment.EnLocationAttributes,
+				FoodAttributes:      segment.EnFoodAttributes,
+				SpecialRequirements: tripRequest.EnSpecialRequirements,
+				MedicalConditions:   tripRequest.EnMedicalConditions,
+				LocationsPerDay:     tripRequest.LocationsPerDay,
+				LocationPreference:  tripRequest.LocationPreference,
+				StartLocation:       startLocation,
 			}
-
-			updateErrCode := service.updatedTripHelper(ctx, tripID, tripRequestForUpdate, tx)
-			if updateErrCode != "" {
-				log.Error("TripService.CreateTripByAI - updateTrip Error: " + updateErrCode)
-				return
+			segmentItems, segmentReferenceID, createTripItemsError := service.createTripItems(createTourURL, token, tripToCoreRequest)
+			if createTripItemsError != "" {
+				log.Error("TripService.CreateTripByAI - Create trip items Error: " + createTripItemsError)
+				return []model.TripItemFromAIResponse{}, tripID, createTripItemsError
 			}
 
-			commitErr := service.unitOfWork.Commit(tx)
-			if commitErr != nil {
-				log.Error("TripService.CreateTripByAI - Commit Error: " + commitErr.Error())
+			// every core call plans on its own, so a place it already picked for an earlier chunk is left out
+			firstDay := int64(segment.StartDay + offset)
+			for _, item := range segmentItems {
+				if plannedPlaces[item.PlaceID] {
+					continue
+				}
+				plannedPlaces[item.PlaceID] = true
+				item.TripDay += firstDay - 1
+				tripItemsRespFromCore = append(tripItemsRespFromCore, item)
+			}
+			if _, ok := segmentReferenceIDs[segment.SegmentOrder]; !ok {
+				segmentReferenceIDs[segment.SegmentOrder] = segmentReferenceID
+			}
+			if referenceID == "" {
+				referenceID = segmentReferenceID
 			}
 		}
-	}()
+	}
+
+	// the schedule checks reject a save outright, so whatever core returned is put in order first
+	tripItemsRespFromCore = normaliseGeneratedTripItems(tripItemsRespFromCore, tripRequest.Days)
 
 	// add tripID to trip items
 	for i := range tripItemsRespFromCore {
@@ -480,16 +967,109 @@ func (service *TripService) CreateTripByAI(ctx *gin.Context, tripRequest model.C
 	}
 
 	// save trip items to database
-	err := service.tripItemService.CreateTripItems(ctx, userID, tripID, tripItemsReqs)
-	if err != "" {
-		log.Error("TripService.CreateTripByAI Error: " + err)
-		return []model.TripItemFromAIResponse{}, tripID, err
+	_, errCode = service.tripItemService.CreateTripItems(ctx, userID, tripID, tripItemsReqs)
+	if errCode != "" {
+		log.Error("TripService.CreateTripByAI Error: " + errCode)
+		return []model.TripItemFromAIResponse{}, tripID, errCode
+	}
+
+	// record the chosen hotels so they show up on the timeline. The itinerary is already saved,
+	// so a trip without them is still a generated trip and members can add the bookings themselves
+	if len(hotelStays) > 0 {
+		if bookingErrCode := service.createHotelBookings(ctx, tripID, userID, hotelStays); bookingErrCode != "" {
+			log.Error("TripService.CreateTripByAI createHotelBookings Error: " + bookingErrCode)
+		}
 	}
 
 	// return trip items to noti
 	return tripItemsRespFromCore, tripID, ""
 }
 
+// normaliseGeneratedTripItems drops items planned outside the trip, numbers the stops of each day from 1 in the
+// order core gave them, and keeps the time of day from going backwards, so a generated plan always passes checkSchedule
+func normaliseGeneratedTripItems(items []model.TripItemFromAIResponse, tripDays int) []model.TripItemFromAIResponse {
+	normalised := make([]model.TripItemFromAIResponse, 0, len(items))
+	for _, item := range items {
+		if item.TripDay >= 1 && item.TripDay <= int64(tripDays) {
+			normalised = append(normalised, item)
+		}
+	}
+	sort.SliceStable(normalised, func(i, j int) bool {
+		if normalised[i].TripDay != normalised[j].TripDay {
+			return normalised[i].TripDay < normalised[j].TripDay
+		}
+		return normalised[i].OrderInDay < normalised[j].OrderInDay
+	})
+
+	for i := range normalised {
+		item := &normalised[i]
+		if i == 0 || normalised[i-1].TripDay != item.TripDay {
+			item.OrderInDay = 1
+			if _, ok := model.TimeInDateStartHour[item.TimeInDay]; !ok {
+				item.TimeInDay = model.TimeInDate.Morning
+			}
+			continue
+		}
+		previous := normalised[i-1]
+		item.OrderInDay = previous.OrderInDay + 1
+		startHour, ok := model.TimeInDateStartHour[item.TimeInDay]
+		if !ok || startHour < model.TimeInDateStartHour[previous.TimeInDay] {
+			item.TimeInDay = previous.TimeInDay
+		}
+	}
+	return normalised
+}
+
+// finishAIGenerationHelper moves a trip out of ai_generating through its lifecycle,
+// to not_started with the core reference ids when its itinerary was saved, and to failed otherwise
+func (service *TripService) finishAIGenerationHelper(ctx *gin.Context, tripID int64, succeeded bool, referenceID string, segmentReferenceIDs map[int]string) {
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripService.finishAIGenerationHelper - BeginTx Error: " + err.Error())
+		return
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	trip, err := service.tripRepository.SelectForUpdateById(ctx, tripID, tx)
+	if err != nil {
+		log.Error("TripService.finishAIGenerationHelper - SelectForUpdateById Error: " + err.Error())
+		return
+	}
+	if trip == nil {
+		return
+	}
+
+	status := model.TripStatus.Failed
+	if succeeded {
+		status = model.TripStatus.NotStarted
+		trip.ReferenceID = &referenceID
+	}
+	if !moveTripStatus(trip, status) {
+		log.Error("TripService.finishAIGenerationHelper - cannot move trip from " + trip.Status + " to " + status)
+		return
+	}
+	err = service.tripRepository.UpdateCommand(ctx, trip, tx)
+	if err != nil {
+		log.Error("TripService.finishAIGenerationHelper - Update trip Error: " + err.Error())
+		return
+	}
+
+	if succeeded {
+		for segmentOrder, segmentReferenceID := range segmentReferenceIDs {
+			err = service.tripSegmentRepository.UpdateReferenceIDCommand(ctx, tripID, segmentOrder, segmentReferenceID, tx)
+			if err != nil {
+				log.Error("TripService.finishAIGenerationHelper - Update segment reference ID Error: " + err.Error())
+				return
+			}
+		}
+	}
+
+	err = service.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripService.finishAIGenerationHelper - Commit Error: " + err.Error())
+	}
+}
+
 func (service *TripService) DeleteTrip(ctx *gin.Context, tripId int64, userId int64) string {
 	tx, err := service.unitOfWork.Begin(ctx)
 	if err != nil {
@@ -498,23 +1078,36 @@ func (service *TripService) DeleteTrip(ctx *gin.Context, tripId int64, userId in
 	}
 	defer service.unitOfWork.Rollback(tx)
 
-	// Check if user is admin
-	isAdmin, err := service.tripMemberRepository.IsUserTripAdminQuery(ctx, tripId, userId, tx)
+	role, err := service.tripMemberRepository.GetMemberRoleQuery(ctx, tripId, userId, tx)
 	if err != nil {
-		log.Error("TripService.DeleteTrip - Check admin Error: " + err.Error())
+		log.Error("TripService.DeleteTrip - Get role Error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
-	if !isAdmin {
+	if !trippermissionutils.CanDeleteTrip(role) {
 		return error_utils.ErrorCode.FORBIDDEN
 	}
 
-	// Delete trip
+	// Move trip to the trash
 	err = service.tripRepository.DeleteByIDCommand(ctx, tripId, tx)
 	if err != nil {
 		log.Error("TripService.DeleteTrip - Delete Error: " + err.Error())
 		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
 	}
 
+	err = recordTripActivity(ctx, service.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     tripId,
+			ActorID:    &userId,
+			Action:     model.TripActivityAction.TripDeleted,
+			EntityType: model.TripActivityEntityType.Trip,
+			EntityID:   &tripId,
+		},
+	}, tx)
+	if err != nil {
+		log.Error("TripService.DeleteTrip - recordTripActivity Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
 	// Commit transaction
 	err = service.unitOfWork.Commit(tx)
 	if err != nil {
@@ -525,18 +1118,373 @@ func (service *TripService) DeleteTrip(ctx *gin.Context, tripId int64, userId in
 	return ""
 }
 
+func (service *TripService) GetTrash(ctx *gin.Context, userId int64) ([]*model.TripTrashResponse, string) {
+	trips, err := service.tripRepository.GetAllDeletedWithUserRoleByUserIdQuery(ctx, userId, time.Now().Add(-constants.TRIP_TRASH_RETENTION), nil)
+	if err != nil {
+		log.Error("TripService.GetTrash - GetAllDeletedWithUserRoleByUserIdQuery Error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	// only those who could restore a trip see it in their trash
+	responses := make([]*model.TripTrashResponse, 0, len(trips))
+	for _, trip := range trips {
+		if !trippermissionutils.CanRestoreTrip(trip.Role) {
+			continue
+		}
+		responses = append(responses, &model.TripTrashResponse{
+			ID:        trip.ID,
+			Title:     trip.Title,
+			City:      trip.City,
+			StartDate: trip.StartDate,
+			Days:      trip.Days,
+			Status:    trip.Status,
+			Role:      trip.Role,
+			DeletedAt: trip.DeletedAt.Time,
+			PurgeAt:   trip.DeletedAt.Time.Add(constants.TRIP_TRASH_RETENTION),
+		})
+	}
+	return responses, ""
+}
+
+func (service *TripService) RestoreTrip(ctx *gin.Context, tripId int64, userId int64) string {
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripService.RestoreTrip - BeginTx Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	trip, err := service.tripRepository.GetOneDeletedWithUserRoleByIDQuery(ctx, tripId, userId, tx)
+	if err != nil {
+		log.Error("TripService.RestoreTrip - GetOneDeletedWithUserRoleByIDQuery Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	// a trip past its retention is waiting to be purged and can no longer come back
+	if trip == nil || time.Since(trip.DeletedAt.Time) > constants.TRIP_TRASH_RETENTION {
+		return error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+	if !trippermissionutils.CanRestoreTrip(trip.Role) {
+		return error_utils.ErrorCode.FORBIDDEN
+	}
+
+	err = service.tripRepository.RestoreByIDCommand(ctx, tripId, tx)
+	if err != nil {
+		log.Error("TripService.RestoreTrip - RestoreByIDCommand Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	// offline clients dropped the trip when it was trashed, so they need all of it again
+	err = service.tripMemberRepository.TouchByTripIDCommand(ctx, tripId, tx)
+	if err != nil {
+		log.Error("TripService.RestoreTrip - TouchByTripIDCommand Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	err = recordTripActivity(ctx, service.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     tripId,
+			ActorID:    &userId,
+			Action:     model.TripActivityAction.TripRestored,
+			EntityType: model.TripActivityEntityType.Trip,
+			EntityID:   &tripId,
+		},
+	}, tx)
+	if err != nil {
+		log.Error("TripService.RestoreTrip - recordTripActivity Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	err = service.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripService.RestoreTrip - Commit Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	return ""
+}
+
+// PurgeDeletedTrips deletes for good the trips that have been in the trash longer than the retention, with their S3 files.
+// A trip whose files cannot all be removed is kept for the next run so nothing is left behind in the bucket.
+func (service *TripService) PurgeDeletedTrips(ctx *gin.Context) error {
+	trips, err := service.tripRepository.GetAllDeletedBeforeQuery(ctx, time.Now().Add(-constants.TRIP_TRASH_RETENTION), nil)
+	if err != nil {
+		log.Error("TripService.PurgeDeletedTrips - GetAllDeletedBeforeQuery Error: " + err.Error())
+		return err
+	}
+
+	for _, trip := range trips {
+		if !service.deleteTripFilesHelper(ctx, trip.ID) {
+			continue
+		}
+		err = service.purgeTripHelper(ctx, trip.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgeTripHelper deletes a trashed trip for good, leaving a tombstone for each of its members
+func (service *TripService) purgeTripHelper(ctx *gin.Context, tripId int64) error {
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripService.purgeTripHelper - BeginTx Error: " + err.Error())
+		return err
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	err = service.syncTombstoneRepository.CreateForTripMembersCommand(ctx, tripId, tx)
+	if err != nil {
+		log.Error("TripService.purgeTripHelper - CreateForTripMembersCommand Error: " + err.Error())
+		return err
+	}
+	err = service.tripRepository.PurgeByIDCommand(ctx, tripId, tx)
+	if err != nil {
+		log.Error("TripService.purgeTripHelper - PurgeByIDCommand Error: " + err.Error())
+		return err
+	}
+
+	err = service.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripService.purgeTripHelper - Commit Error: " + err.Error())
+		return err
+	}
+	return nil
+}
+
+// deleteTripFilesHelper removes the trip's images and documents from S3 and reports whether the trip can be purged.
+// Photos only hold the URL the client sent, so just those pointing into our bucket are deleted. Without S3 nothing
+// can be deleted, so the leftover keys are logged for a manual clean-up rather than keeping the trip forever.
+func (service *TripService) deleteTripFilesHelper(ctx *gin.Context, tripId int64) bool {
+	images, err := service.tripImageRepository.GetAllQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripService.deleteTripFilesHelper - Get images Error: " + err.Error())
+		return false
+	}
+	documents, err := service.tripDocumentRepository.GetAllByTripIDQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripService.deleteTripFilesHelper - Get documents Error: " + err.Error())
+		return false
+	}
+
+	if !service.s3Service.Enabled() {
+		for _, document := range documents {
+			log.Error("TripService.deleteTripFilesHelper - S3 is disabled, leaving document behind: " + document.S3Key)
+		}
+		return true
+	}
+
+	var keys []string
+	for _, image := range images {
+		if key, ok := service.s3Service.KeyFromURL(image.ImageURL); ok {
+			keys = append(keys, key)
+		}
+	}
+	for _, document := range documents {
+		keys = append(keys, document.S3Key)
+	}
+
+	deleted := true
+	for _, key := range keys {
+		if err := service.s3Service.DeleteImage(ctx, key); err != nil {
+			log.Error("TripService.deleteTripFilesHelper - Delete file Error: " + err.Error())
+			deleted = false
+		}
+	}
+	return deleted
+}
+
+func (service *TripService) CancelTrip(ctx *gin.Context, tripId int64, userId int64) string {
+	return service.changeTripStatusHelper(ctx, "CancelTrip", tripId, userId, entity.NotificationType.TripCancelled, func(trip *entity.Trip) string {
+		return model.TripStatus.Cancelled
+	})
+}
+
+func (service *TripService) ArchiveTrip(ctx *gin.Context, tripId int64, userId int64) string {
+	return service.changeTripStatusHelper(ctx, "ArchiveTrip", tripId, userId, entity.NotificationType.TripArchived, func(trip *entity.Trip) string {
+		return model.TripStatus.Archived
+	})
+}
+
+func (service *TripService) UnarchiveTrip(ctx *gin.Context, tripId int64, userId int64) string {
+	return service.changeTripStatusHelper(ctx, "UnarchiveTrip", tripId, userId, entity.NotificationType.TripUnarchived, func(trip *entity.Trip) string {
+		if trip.Status != model.TripStatus.Archived {
+			return ""
+		}
+		if trip.StatusBeforeArchive == nil {
+			return model.TripStatus.Completed
+		}
+		return *trip.StatusBeforeArchive
+	})
+}
+
+// changeTripStatusHelper moves the trip to the status picked by nextStatus, then tells the other members with notificationType
+func (service *TripService) changeTripStatusHelper(ctx *gin.Context, caller string, tripId int64, userId int64, notificationType string, nextStatus func(trip *entity.Trip) string) string {
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripService." + caller + " - BeginTx Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	// lock the trip before any other read so the transition is checked against the latest status
+	trip, err := service.tripRepository.SelectForUpdateById(ctx, tripId, tx)
+	if err != nil {
+		log.Error("TripService." + caller + " - Lock trip Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	role, err := service.tripMemberRepository.GetMemberRoleQuery(ctx, tripId, userId, tx)
+	if err != nil {
+		log.Error("TripService." + caller + " - Get role Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	if !trippermissionutils.CanEditTrip(role) {
+		return error_utils.ErrorCode.FORBIDDEN
+	}
+
+	if trip == nil {
+		return error_utils.ErrorCode.TRIP_NOT_FOUND
+	}
+
+	previousStatus := trip.Status
+	if !moveTripStatus(trip, nextStatus(trip)) {
+		return error_utils.ErrorCode.TRIP_INVALID_STATUS_TRANSITION
+	}
+	err = service.tripRepository.UpdateCommand(ctx, trip, tx)
+	if err != nil {
+		log.Error("TripService." + caller + " - Update trip Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	err = recordTripActivity(ctx, service.tripActivityRepository, tripActivityRecord{
+		activity: entity.TripActivity{
+			TripID:     tripId,
+			ActorID:    &userId,
+			Action:     model.TripActivityAction.TripStatusChanged,
+			EntityType: model.TripActivityEntityType.Trip,
+			EntityID:   &tripId,
+		},
+		before: map[string]interface{}{"status": previousStatus},
+		after:  map[string]interface{}{"status": trip.Status},
+	}, tx)
+	if err != nil {
+		log.Error("TripService." + caller + " - recordTripActivity Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	members, err := service.tripMemberRepository.GetTripMembersQuery(ctx, tripId, tx)
+	if err != nil {
+		log.Error("TripService." + caller + " - GetTripMembersQuery Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	err = service.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripService." + caller + " - Commit Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	service.tripRealtimeService.PublishEvent(ctx, tripId, &userId, model.TripEventType.TripUpdated, model.TripPatchRequest{Status: &trip.Status})
+	for _, member := range members {
+		if member.UserID == userId {
+			continue
+		}
+		errCode := service.notificationService.SaveAndSendNotification(ctx, model.SaveNotificationRequest{
+			ReceiverUserID:      member.UserID,
+			TriggerEntityType:   entity.NotificationTriggerType.User,
+			TriggerEntityID:     &userId,
+			ReferenceEntityType: entity.NotificationReferenceType.Trip,
+			ReferenceEntityID:   &tripId,
+			Type:                notificationType,
+		})
+		if errCode != "" {
+			log.Error("TripService." + caller + " - SaveAndSendNotification Error: " + errCode)
+		}
+	}
+	return ""
+}
+
+// moveTripStatus moves the trip to status if its lifecycle allows it.
+// An archived trip remembers the status it was archived from and can only go back to it.
+func moveTripStatus(trip *entity.Trip, status string) bool {
+	if !tripstatusutils.CanTransition(trip.Status, status) {
+		return false
+	}
+	if trip.Status == model.TripStatus.Archived {
+		if trip.StatusBeforeArchive != nil && *trip.StatusBeforeArchive != status {
+			return false
+		}
+		trip.StatusBeforeArchive = nil
+	}
+	if status == model.TripStatus.Archived {
+		previousStatus := trip.Status
+		trip.StatusBeforeArchive = &previousStatus
+	}
+	trip.Status = status
+	return true
+}
+
+func (service *TripService) GetTripItinerary(ctx *gin.Context, tripId int64, userId int64) (*model.TripItinerary, string) {
+	// GetTripByID also checks that the user is a member of the trip
+	trip, errCode := service.GetTripByID(ctx, tripId, userId)
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	members, err := service.tripMemberRepository.GetTripMembersQuery(ctx, tripId, nil)
+	if err != nil {
+		log.Error("TripService.GetTripItinerary - GetTripMembersQuery Error: " + err.Error())
+		return nil, error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+
+	memberResponses := make([]model.TripMemberResponse, len(members))
+	for i, member := range members {
+		memberResponses[i] = model.TripMemberResponse{
+			ID:       member.ID,
+			TripID:   member.TripID,
+			UserID:   member.UserID,
+			Role:     member.Role,
+			Name:     member.Name,
+			PhotoURL: member.PhotoURL,
+		}
+	}
+
+	tripItems, errCode := service.tripItemService.GetTripItemsByTripID(ctx, userId, tripId, "")
+	if errCode != "" {
+		return nil, errCode
+	}
+
+	sort.SliceStable(tripItems, func(i, j int) bool {
+		if tripItems[i].TripDay != tripItems[j].TripDay {
+			return tripItems[i].TripDay < tripItems[j].TripDay
+		}
+		return tripItems[i].OrderInDay < tripItems[j].OrderInDay
+	})
+
+	return &model.TripItinerary{
+		Trip:    *trip,
+		Members: memberResponses,
+		Items:   tripItems,
+	}, ""
+}
+
+// zoneSlack covers the widest gap between a stored start_date and midnight of that day in the trip's zone
+const zoneSlack = 26 * time.Hour
+
 func (service *TripService) UpdateStatusTripStart(ctx *gin.Context) error {
-	trips, err := service.tripRepository.GetAllNotStartedByStartDateQuery(ctx, time.Now(), nil)
+	now := time.Now()
+	trips, err := service.tripRepository.GetAllNotStartedStartingBeforeQuery(ctx, now.Add(zoneSlack), nil)
 	if err != nil {
 		log.Error("TripService.UpdateStatusTripStart - Get trips Error: " + err.Error())
 		return err
 	}
 
 	for _, trip := range trips {
-		trip.Status = model.TripStatus.InProgress
-		err = service.tripRepository.UpdateCommand(ctx, trip, nil)
+		// the trip starts at midnight of its first day in its own zone
+		err = service.advanceTripStatusHelper(ctx, "UpdateStatusTripStart", trip.ID, model.TripStatus.InProgress, func(trip *entity.Trip) bool {
+			return !now.Before(timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, 1))
+		})
 		if err != nil {
-			log.Error("TripService.UpdateStatusTripStart - Update trip Error: " + err.Error())
 			return err
 		}
 	}
@@ -545,37 +1493,87 @@ func (service *TripService) UpdateStatusTripStart(ctx *gin.Context) error {
 }
 
 func (service *TripService) UpdateStatusTripEnd(ctx *gin.Context) error {
-	trips, err := service.tripRepository.GetAllInProgressEndedBeforeQuery(ctx, time.Now(), nil)
+	now := time.Now()
+	trips, err := service.tripRepository.GetAllInProgressEndingBeforeQuery(ctx, now.Add(zoneSlack), nil)
 	if err != nil {
 		log.Error("TripService.UpdateStatusTripEnd - Get trips Error: " + err.Error())
 		return err
 	}
 
 	for _, trip := range trips {
-		trip.Status = model.TripStatus.Completed
-		err = service.tripRepository.UpdateCommand(ctx, trip, nil)
+		// the trip ends at midnight after its last day in its own zone
+		err = service.advanceTripStatusHelper(ctx, "UpdateStatusTripEnd", trip.ID, model.TripStatus.Completed, func(trip *entity.Trip) bool {
+			return !now.Before(timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, int64(trip.Days)+1))
+		})
 		if err != nil {
-			log.Error("TripService.UpdateStatusTripEnd - Update trip Error: " + err.Error())
 			return err
 		}
 	}
 	return nil
 }
 
+// advanceTripStatusHelper moves a trip listed by the status cron once its locked row shows it is still due,
+// so a status or date changed since the listing is not overwritten
+func (service *TripService) advanceTripStatusHelper(ctx *gin.Context, caller string, tripId int64, status string, due func(trip *entity.Trip) bool) error {
+	tx, err := service.unitOfWork.Begin(ctx)
+	if err != nil {
+		log.Error("TripService." + caller + " - BeginTx Error: " + err.Error())
+		return err
+	}
+	defer service.unitOfWork.Rollback(tx)
+
+	trip, err := service.tripRepository.SelectForUpdateById(ctx, tripId, tx)
+	if err != nil {
+		log.Error("TripService." + caller + " - Lock trip Error: " + err.Error())
+		return err
+	}
+	if trip == nil || !due(trip) || !moveTripStatus(trip, status) {
+		return nil
+	}
+	err = service.tripRepository.UpdateCommand(ctx, trip, tx)
+	if err != nil {
+		log.Error("TripService." + caller + " - Update trip Error: " + err.Error())
+		return err
+	}
+
+	err = service.unitOfWork.Commit(tx)
+	if err != nil {
+		log.Error("TripService." + caller + " - Commit Error: " + err.Error())
+		return err
+	}
+	return nil
+}
+
 func (service *TripService) SendTripStartReminders(ctx *gin.Context) error {
-	targetDate := time.Now().AddDate(0, 0, 3).Truncate(24 * time.Hour)
-	trips, err := service.tripRepository.GetAllByStartDateQuery(ctx, targetDate, nil)
+	now := time.Now()
+	// a wide window, the exact cut-off depends on the zone of each trip
+	trips, err := service.tripRepository.GetAllStartingBetweenQuery(ctx, now.AddDate(0, 0, -2), now.AddDate(0, 0, 5), nil)
 	if err != nil {
-		log.Error("TripService.SendTripStartReminders - GetAllByStartDateQuery Error: " + err.Error())
+		log.Error("TripService.SendTripStartReminders - GetAllStartingBetweenQuery Error: " + err.Error())
 		return err
 	}
 
 	for _, trip := range trips {
+		if !isStartReminderDue(now, trip) {
+			continue
+		}
+		claimed, err := service.tripReminderRepository.ClaimCommand(ctx, trip.ID, entity.TripReminderType.StartingSoon, trip.StartDate, nil)
+		if err != nil {
+			log.Error("TripService.SendTripStartReminders - ClaimCommand Error: " + err.Error())
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		// the claim is only kept once someone got the reminder, otherwise the next run tries again
 		members, err := service.tripMemberRepository.GetTripMembersQuery(ctx, trip.ID, nil)
 		if err != nil {
 			log.Error("TripService.SendTripStartReminders - GetTripMembersQuery Error: " + err.Error())
+			service.releaseReminderHelper(ctx, "SendTripStartReminders", trip, entity.TripReminderType.StartingSoon)
 			continue
 		}
+		sent := false
 		for _, member := range members {
 			errCode := service.notificationService.SaveAndSendNotification(ctx, model.SaveNotificationRequest{
 				ReceiverUserID:      member.UserID,
@@ -588,7 +1586,106 @@ func (service *TripService) SendTripStartReminders(ctx *gin.Context) error {
 				log.Error("TripService.SendTripStartReminders - SaveAndSendNotification Error: " + errCode)
 				continue
 			}
+			sent = true
+		}
+		if len(members) > 0 && !sent {
+			service.releaseReminderHelper(ctx, "SendTripStartReminders", trip, entity.TripReminderType.StartingSoon)
 		}
 	}
 	return nil
 }
+
+func (service *TripService) releaseReminderHelper(ctx *gin.Context, caller string, trip *entity.Trip, reminderType string) {
+	err := service.tripReminderRepository.ReleaseCommand(ctx, trip.ID, reminderType, trip.StartDate, nil)
+	if err != nil {
+		log.Error("TripService." + caller + " - ReleaseCommand Error: " + err.Error())
+	}
+}
+
+// isStartReminderDue reports whether the trip starts within 3 days, counted from midnight of its zone.
+// The cron runs hourly, so a run that is missed is made up by the next one; the reminder table keeps it to one send
+func isStartReminderDue(now time.Time, trip *entity.Trip) bool {
+	start := timezoneutils.TripStart(trip.StartDate, timezoneutils.Location(trip.TimeZone))
+	return !now.Before(start.AddDate(0, 0, -3)) && now.Before(start)
+}
+
+// replaceTripSegmentsHelper lays the requested segments out on consecutive trip days and stores them.
+// Without requests the trip becomes a single segment of its own city and attributes.
+// A segment keeps the core reference id of the previous segment at the same place with the same city.
+func (service *TripService) replaceTripSegmentsHelper(ctx *gin.Context, trip *entity.Trip, segmentRequests []model.TripSegmentRequest, previousSegments []entity.TripSegment, tx *sqlx.Tx) string {
+	segments := []entity.TripSegment{singleTripSegment(trip)}
+	if len(segmentRequests) > 0 {
+		segments = make([]entity.TripSegment, 0, len(segmentRequests))
+		startDay := 1
+		for i, segmentRequest := range segmentRequests {
+			segments = append(segments, entity.TripSegment{
+				TripID:               trip.ID,
+				SegmentOrder:         i + 1,
+				City:                 segmentRequest.City,
+				StartDay:             startDay,
+				Days:                 segmentRequest.Days,
+				ViLocationAttributes: segmentRequest.ViLocationAttributes,
+				ViFoodAttributes:     segmentRequest.ViFoodAttributes,
+				EnLocationAttributes: segmentRequest.EnLocationAttributes,
+				EnFoodAttributes:     segmentRequest.EnFoodAttributes,
+			})
+			startDay += segmentRequest.Days
+		}
+		if startDay-1 != trip.Days {
+			return error_utils.ErrorCode.BAD_REQUEST
+		}
+		trip.City = segments[0].City
+	}
+	for i := range segments {
+		for _, previous := range previousSegments {
+			if previous.SegmentOrder == segments[i].SegmentOrder && previous.StartDay == segments[i].StartDay && previous.City == segments[i].City {
+				segments[i].ReferenceID = previous.ReferenceID
+			}
+		}
+	}
+
+	err := service.tripSegmentRepository.DeleteByTripIDCommand(ctx, trip.ID, tx)
+	if err != nil {
+		log.Error("TripService.replaceTripSegmentsHelper - Delete segments Error: " + err.Error())
+		return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+	}
+	for i := range segments {
+		err = service.tripSegmentRepository.CreateCommand(ctx, &segments[i], tx)
+		if err != nil {
+			log.Error("TripService.replaceTripSegmentsHelper - Create segment Error: " + err.Error())
+			return error_utils.ErrorCode.INTERNAL_SERVER_ERROR
+		}
+	}
+
+	return ""
+}
+
+func singleTripSegment(trip *entity.Trip) entity.TripSegment {
+	return entity.TripSegment{
+		TripID:               trip.ID,
+		SegmentOrder:         1,
+		City:                 trip.City,
+		StartDay:             1,
+		Days:                 trip.Days,
+		ViLocationAttributes: trip.ViLocationAttributes,
+		ViFoodAttributes:     trip.ViFoodAttributes,
+		EnLocationAttributes: trip.EnLocationAttributes,
+		EnFoodAttributes:     trip.EnFoodAttributes,
+		ReferenceID:          trip.ReferenceID,
+	}
+}
+
+func tripSegmentRequests(segments []entity.TripSegment) []model.TripSegmentRequest {
+	segmentRequests := make([]model.TripSegmentRequest, 0, len(segments))
+	for _, segment := range segments {
+		segmentRequests = append(segmentRequests, model.TripSegmentRequest{
+			City:                 segment.City,
+			Days:                 segment.Days,
+			ViLocationAttributes: segment.ViLocationAttributes,
+			ViFoodAttributes:     segment.ViFoodAttributes,
+			EnLocationAttributes: segment.EnLocationAttributes,
+			EnFoodAttributes:     segment.EnFoodAttributes,
+		})
+	}
+	return segmentRequests
+}
@@ -0,0 +1,47 @@
+package serviceimplement
+
+import (
+	"testing"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+func TestMoveTripStatus(t *testing.T) {
+	completed := model.TripStatus.Completed
+	cancelled := model.TripStatus.Cancelled
+
+	tests := []struct {
+		name                    string
+		status                  string
+		statusBeforeArchive     *string
+		to                      string
+		wantMoved               bool
+		wantStatus              string
+		wantStatusBeforeArchive *string
+	}{
+		{"generation fails", model.TripStatus.AIGenerating, nil, model.TripStatus.Failed, true, model.TripStatus.Failed, nil},
+		{"generation succeeds", model.TripStatus.AIGenerating, nil, model.TripStatus.NotStarted, true, model.TripStatus.NotStarted, nil},
+		{"forbidden move is refused", model.TripStatus.Completed, nil, model.TripStatus.InProgress, false, model.TripStatus.Completed, nil},
+		{"archive remembers the status", model.TripStatus.Completed, nil, model.TripStatus.Archived, true, model.TripStatus.Archived, &completed},
+		{"unarchive restores the status", model.TripStatus.Archived, &completed, model.TripStatus.Completed, true, model.TripStatus.Completed, nil},
+		{"unarchive to another status is refused", model.TripStatus.Archived, &cancelled, model.TripStatus.Completed, false, model.TripStatus.Archived, &cancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip := &entity.Trip{Status: tt.status, StatusBeforeArchive: tt.statusBeforeArchive}
+			if moved := moveTripStatus(trip, tt.to); moved != tt.wantMoved {
+				t.Fatalf("moveTripStatus() = %v, want %v", moved, tt.wantMoved)
+			}
+			if trip.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", trip.Status, tt.wantStatus)
+			}
+			switch {
+			case tt.wantStatusBeforeArchive == nil && trip.StatusBeforeArchive != nil:
+				t.Errorf("status before archive = %q, want none", *trip.StatusBeforeArchive)
+			case tt.wantStatusBeforeArchive != nil && (trip.StatusBeforeArchive == nil || *trip.StatusBeforeArchive != *tt.wantStatusBeforeArchive):
+				t.Errorf("status before archive = %v, want %q", trip.StatusBeforeArchive, *tt.wantStatusBeforeArchive)
+			}
+		})
+	}
+}
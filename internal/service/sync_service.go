@@ -0,0 +1,12 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type SyncService interface {
+	GetChanges(ctx *gin.Context, userId int64, cursor string) (*model.SyncResponse, string)
+	ApplyMutations(ctx *gin.Context, userId int64, mutations []model.SyncMutation) *model.SyncMutationResponse
+	PurgeTombstones(ctx *gin.Context) error
+}
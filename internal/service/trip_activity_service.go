@@ -0,0 +1,10 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type TripActivityService interface {
+	GetActivities(ctx *gin.Context, userId int64, tripId int64, cursor *int64, limit int) (*model.TripActivityPageResponse, string)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type TripBookingService interface {
+	CreateBooking(ctx *gin.Context, userId int64, tripId int64, bookingRequest model.TripBookingRequest) (*model.TripBookingResponse, string)
+	GetBookings(ctx *gin.Context, userId int64, tripId int64) ([]model.TripBookingResponse, string)
+	UpdateBooking(ctx *gin.Context, userId int64, tripId int64, bookingId int64, bookingRequest model.TripBookingRequest) (*model.TripBookingResponse, string)
+	DeleteBooking(ctx *gin.Context, userId int64, tripId int64, bookingId int64) string
+	GetTripTimeline(ctx *gin.Context, userId int64, tripId int64) (*model.TripTimelineResponse, string)
+}
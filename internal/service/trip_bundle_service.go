@@ -0,0 +1,10 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type TripBundleService interface {
+	// GetTripBundle returns the zip archive of the trip, leaving out the files whose hash is in knownHashes
+	GetTripBundle(ctx *gin.Context, userId int64, tripId int64, language string, knownHashes []string) ([]byte, string)
+}
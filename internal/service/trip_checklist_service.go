@@ -0,0 +1,17 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type TripChecklistService interface {
+	CreateChecklist(ctx *gin.Context, userId int64, tripId int64, checklistRequest model.TripChecklistRequest) (*model.TripChecklistResponse, string)
+	GetChecklists(ctx *gin.Context, userId int64, tripId int64) ([]model.TripChecklistResponse, string)
+	DeleteChecklist(ctx *gin.Context, userId int64, tripId int64, checklistId int64) string
+	CreateChecklistItem(ctx *gin.Context, userId int64, tripId int64, checklistId int64, itemRequest model.TripChecklistItemRequest) (*model.TripChecklistItemResponse, string)
+	UpdateChecklistItem(ctx *gin.Context, userId int64, tripId int64, checklistId int64, itemId int64, itemRequest model.TripChecklistItemRequest) (*model.TripChecklistItemResponse, string)
+	CheckChecklistItem(ctx *gin.Context, userId int64, tripId int64, checklistId int64, itemId int64, checkRequest model.TripChecklistItemCheckRequest) (*model.TripChecklistItemResponse, string)
+	DeleteChecklistItem(ctx *gin.Context, userId int64, tripId int64, checklistId int64, itemId int64) string
+	SendChecklistReminders(ctx *gin.Context) error
+}
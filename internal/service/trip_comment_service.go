@@ -0,0 +1,13 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type TripCommentService interface {
+	CreateComment(ctx *gin.Context, userId int64, tripId int64, commentRequest model.TripCommentRequest) (*model.TripCommentResponse, string)
+	GetComments(ctx *gin.Context, userId int64, tripId int64, tripItemId *int64) ([]model.TripCommentResponse, string)
+	UpdateComment(ctx *gin.Context, userId int64, tripId int64, commentId int64, commentRequest model.TripCommentUpdateRequest) (*model.TripCommentResponse, string)
+	DeleteComment(ctx *gin.Context, userId int64, tripId int64, commentId int64) string
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type TripDocumentService interface {
+	CreateDocument(ctx *gin.Context, userId int64, tripId int64, documentRequest model.TripDocumentRequest) (*model.TripDocumentUploadResponse, string)
+	GetDocuments(ctx *gin.Context, userId int64, tripId int64) ([]model.TripDocumentResponse, string)
+	GetDocumentDownloadURL(ctx *gin.Context, userId int64, tripId int64, documentId int64) (*model.TripDocumentDownloadResponse, string)
+	DeleteDocument(ctx *gin.Context, userId int64, tripId int64, documentId int64) string
+}
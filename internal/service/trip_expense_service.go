@@ -0,0 +1,16 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type TripExpenseService interface {
+	CreateExpense(ctx *gin.Context, userId int64, tripId int64, expenseRequest model.TripExpenseRequest) (*model.TripExpenseResponse, string)
+	GetExpenses(ctx *gin.Context, userId int64, tripId int64) ([]model.TripExpenseResponse, string)
+	DeleteExpense(ctx *gin.Context, userId int64, tripId int64, expenseId int64) string
+	GetBalances(ctx *gin.Context, userId int64, tripId int64) (*model.TripBalancesResponse, string)
+	GetBudgetSummary(ctx *gin.Context, userId int64, tripId int64) (*model.TripBudgetSummaryResponse, string)
+	GetExpenseReport(ctx *gin.Context, userId int64, tripId int64) (*model.TripExpenseReport, string)
+	CreateSettlement(ctx *gin.Context, userId int64, tripId int64, settlementRequest model.TripSettlementRequest) string
+}
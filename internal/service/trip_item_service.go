@@ -6,6 +6,10 @@ import (
 )
 
 type TripItemService interface {
-	CreateTripItems(ctx *gin.Context, userId int64, tripId int64, tripItemRequests []model.TripItemRequest) string
-	GetTripItemsByTripID(ctx *gin.Context, userId int64, tripId int64) ([]model.TripItemResponse, string)
+	CreateTripItems(ctx *gin.Context, userId int64, tripId int64, tripItemRequests []model.TripItemRequest) ([]model.TripValidationIssue, string)
+	GetTripItemsByTripID(ctx *gin.Context, userId int64, tripId int64, travelMode string) ([]model.TripItemResponse, string)
+	GetTripRoute(ctx *gin.Context, userId int64, tripId int64) (*model.TripRoute, string)
+	GetTripTravelSummary(ctx *gin.Context, userId int64, tripId int64, mode string) (*model.TripTravelSummaryResponse, string)
+	ValidateTripSchedule(ctx *gin.Context, userId int64, tripId int64, mode string) (*model.TripValidationResponse, string)
+	OptimizeTripDay(ctx *gin.Context, userId int64, tripId int64, tripDay int64, request model.OptimizeTripDayRequest) (*model.OptimizeTripDayResponse, string)
 }
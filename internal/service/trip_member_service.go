@@ -3,10 +3,14 @@ package service
 import (
 	"context"
 
+	"github.com/gin-gonic/gin"
 	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
 )
 
 type TripMemberService interface {
 	GetTripMembersIfUserInTrip(ctx context.Context, tripID int64, userID int64) ([]model.TripMemberResponse, string)
 	DeleteMemberFromTrip(ctx context.Context, tripID int64, memberID int64, deleterID int64) string
+	ChangeMemberRole(ctx context.Context, tripID int64, memberID int64, changerID int64, role string) string
+	LeaveTrip(ctx *gin.Context, tripID int64, userID int64) string
+	TransferOwnership(ctx *gin.Context, tripID int64, ownerID int64, newOwnerID int64) string
 }
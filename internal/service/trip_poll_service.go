@@ -0,0 +1,16 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type TripPollService interface {
+	CreatePoll(ctx *gin.Context, userId int64, tripId int64, pollRequest model.TripPollRequest) (*model.TripPollResponse, string)
+	GetPolls(ctx *gin.Context, userId int64, tripId int64) ([]model.TripPollResponse, string)
+	GetPoll(ctx *gin.Context, userId int64, tripId int64, pollId int64) (*model.TripPollResponse, string)
+	Vote(ctx *gin.Context, userId int64, tripId int64, pollId int64, voteRequest model.TripPollVoteRequest) (*model.TripPollResponse, string)
+	ClosePoll(ctx *gin.Context, userId int64, tripId int64, pollId int64) (*model.TripPollResponse, string)
+	DeletePoll(ctx *gin.Context, userId int64, tripId int64, pollId int64) string
+	CloseExpiredPolls(ctx *gin.Context) error
+}
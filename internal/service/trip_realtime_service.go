@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type TripRealtimeService interface {
+	// PublishEvent fans an event out to every replica; failures are logged and never fail the caller
+	PublishEvent(ctx context.Context, tripId int64, actorId *int64, eventType string, data interface{})
+	Connect(ctx *gin.Context, userId int64, tripId int64) (*model.TripRealtimeSession, string)
+	UpdatePresence(ctx context.Context, session *model.TripRealtimeSession, presenceRequest model.TripPresenceRequest)
+	// RefreshPresence keeps a quiet session from expiring out of the presence list
+	RefreshPresence(ctx context.Context, session *model.TripRealtimeSession)
+	Disconnect(ctx context.Context, session *model.TripRealtimeSession)
+}
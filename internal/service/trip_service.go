@@ -7,12 +7,19 @@ import (
 
 type TripService interface {
 	CreateTrip(ctx *gin.Context, tripRequest model.CreateTripManuallyRequest, userId int64) (int64, string)
-	GetAllTripsByUserID(ctx *gin.Context, userId int64) ([]*model.TripResponse, string)
+	GetAllTripsByUserID(ctx *gin.Context, userId int64, request model.TripListRequest) (*model.TripPageResponse, string)
 	GetTripByID(ctx *gin.Context, tripId int64, userId int64) (*model.TripResponse, string)
 	UpdateTrip(ctx *gin.Context, tripId int64, userId int64, tripRequest model.TripPatchRequest) string
 	CreateTripByAI(ctx *gin.Context, tripRequest model.CreateTripByAIRequest, userID int64) ([]model.TripItemFromAIResponse, int64, string)
 	DeleteTrip(ctx *gin.Context, tripId int64, userId int64) string
+	GetTrash(ctx *gin.Context, userId int64) ([]*model.TripTrashResponse, string)
+	RestoreTrip(ctx *gin.Context, tripId int64, userId int64) string
+	CancelTrip(ctx *gin.Context, tripId int64, userId int64) string
+	ArchiveTrip(ctx *gin.Context, tripId int64, userId int64) string
+	UnarchiveTrip(ctx *gin.Context, tripId int64, userId int64) string
+	GetTripItinerary(ctx *gin.Context, tripId int64, userId int64) (*model.TripItinerary, string)
 	UpdateStatusTripStart(ctx *gin.Context) error
 	UpdateStatusTripEnd(ctx *gin.Context) error
 	SendTripStartReminders(ctx *gin.Context) error
+	PurgeDeletedTrips(ctx *gin.Context) error
 }
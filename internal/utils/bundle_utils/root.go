@@ -0,0 +1,106 @@
+package bundleutils
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/jpeg"
+	"path"
+	"strings"
+
+	// decoders for the photo formats clients upload
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const photoQuality = 80
+
+// maxPhotoPixels keeps a small file that claims a huge canvas from allocating gigabytes when it is decoded
+const maxPhotoPixels = 50_000_000
+
+var ErrTooManyPixels = errors.New("photo has too many pixels to resize")
+
+// File is one entry of a bundle archive
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Hash returns the hex SHA-256 of the content, as listed in the bundle manifest
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// WriteArchive zips the files in order. Photos and documents are mostly compressed already, so only JSON is deflated
+func WriteArchive(files []File) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, file := range files {
+		method := zip.Store
+		if path.Ext(file.Path) == ".json" {
+			method = zip.Deflate
+		}
+		entry, err := writer.CreateHeader(&zip.FileHeader{Name: file.Path, Method: method})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := entry.Write(file.Content); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// DownscalePhoto shrinks a photo so that its longer side is at most maxSide pixels and re-encodes it as JPEG.
+// Photos that are already small enough are returned unchanged with resized false. Formats without a decoder,
+// such as HEIC, fail with image.ErrFormat and canvases above maxPhotoPixels with ErrTooManyPixels
+func DownscalePhoto(content []byte, maxSide int) (result []byte, resized bool, err error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(config.Width)*int64(config.Height) > maxPhotoPixels {
+		return nil, false, ErrTooManyPixels
+	}
+	if config.Width <= maxSide && config.Height <= maxSide {
+		return content, false, nil
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, false, err
+	}
+	width, height := maxSide, config.Height*maxSide/config.Width
+	if config.Height > config.Width {
+		width, height = config.Width*maxSide/config.Height, maxSide
+	}
+	target := image.NewRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	// JPEG has no alpha, so transparent areas are laid over white instead of turning black
+	draw.Draw(target, target.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(target, target.Bounds(), source, source.Bounds(), draw.Over, nil)
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, target, &jpeg.Options{Quality: photoQuality}); err != nil {
+		return nil, false, err
+	}
+	return buffer.Bytes(), true, nil
+}
+
+// SafeFileName keeps a user supplied file name from escaping its folder in the archive
+func SafeFileName(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(strings.TrimSpace(name))
+	if name == "" || name == "." || name == ".." {
+		return "file"
+	}
+	return name
+}
@@ -0,0 +1,176 @@
+package bundleutils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int, fill color.Color) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+// pngHeader is a PNG that stops after its IHDR chunk, enough for DecodeConfig to read the claimed size
+func pngHeader(width, height uint32) []byte {
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:], width)
+	binary.BigEndian.PutUint32(ihdr[4:], height)
+	ihdr[8], ihdr[9] = 8, 6 // 8-bit RGBA
+
+	var buffer bytes.Buffer
+	buffer.WriteString("\x89PNG\r\n\x1a\n")
+	binary.Write(&buffer, binary.BigEndian, uint32(len(ihdr)))
+	chunk := append([]byte("IHDR"), ihdr...)
+	buffer.Write(chunk)
+	binary.Write(&buffer, binary.BigEndian, crc32.ChecksumIEEE(chunk))
+	return buffer.Bytes()
+}
+
+func TestDownscalePhoto(t *testing.T) {
+	small := encodePNG(t, 40, 20, color.NRGBA{R: 200, A: 255})
+	large := encodePNG(t, 200, 100, color.NRGBA{G: 200, A: 255})
+
+	tests := []struct {
+		name        string
+		content     []byte
+		wantResized bool
+		wantWidth   int
+		wantHeight  int
+		wantErr     error
+		wantAnyErr  bool
+	}{
+		{name: "small photo is kept", content: small, wantWidth: 40, wantHeight: 20},
+		{name: "wide photo", content: large, wantResized: true, wantWidth: 64, wantHeight: 32},
+		{name: "tall photo", content: encodePNG(t, 50, 200, color.NRGBA{B: 200, A: 255}), wantResized: true, wantWidth: 16, wantHeight: 64},
+		{name: "canvas above the pixel limit", content: pngHeader(30000, 30000), wantErr: ErrTooManyPixels},
+		{name: "format without a decoder", content: []byte("\x00\x00\x00\x18ftypheic"), wantErr: image.ErrFormat},
+		{name: "truncated photo", content: large[:len(large)/2], wantAnyErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, resized, err := DownscalePhoto(tt.content, 64)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("DownscalePhoto() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DownscalePhoto() error = %v", err)
+			}
+			if resized != tt.wantResized {
+				t.Errorf("DownscalePhoto() resized = %v, want %v", resized, tt.wantResized)
+			}
+			if !resized && !bytes.Equal(result, tt.content) {
+				t.Errorf("DownscalePhoto() changed a photo it did not resize")
+			}
+			config, format, err := image.DecodeConfig(bytes.NewReader(result))
+			if err != nil {
+				t.Fatalf("result does not decode: %v", err)
+			}
+			if resized && format != "jpeg" {
+				t.Errorf("resized format = %q, want jpeg", format)
+			}
+			if config.Width != tt.wantWidth || config.Height != tt.wantHeight {
+				t.Errorf("result is %dx%d, want %dx%d", config.Width, config.Height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestDownscalePhotoFlattensTransparencyOnWhite(t *testing.T) {
+	result, resized, err := DownscalePhoto(encodePNG(t, 100, 100, color.NRGBA{}), 10)
+	if err != nil || !resized {
+		t.Fatalf("DownscalePhoto() = resized %v, error %v", resized, err)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(result))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, g, b, _ := img.At(5, 5).RGBA()
+	if r < 0xf000 || g < 0xf000 || b < 0xf000 {
+		t.Errorf("transparent pixel became (%d, %d, %d), want white", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	files := []File{
+		{Path: "trip.json", Content: []byte(`{"title":"Hà Nội"}`)},
+		{Path: "photos/1.jpg", Content: []byte("jpeg bytes")},
+	}
+	content, err := WriteArchive(files)
+	if err != nil {
+		t.Fatalf("WriteArchive() error = %v", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reader.File) != len(files) {
+		t.Fatalf("archive has %d entries, want %d", len(reader.File), len(files))
+	}
+
+	wantMethods := []uint16{zip.Deflate, zip.Store}
+	for i, entry := range reader.File {
+		if entry.Name != files[i].Path {
+			t.Errorf("entry %d = %q, want %q", i, entry.Name, files[i].Path)
+		}
+		if entry.Method != wantMethods[i] {
+			t.Errorf("entry %q method = %d, want %d", entry.Name, entry.Method, wantMethods[i])
+		}
+		opened, err := entry.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _ := io.ReadAll(opened)
+		opened.Close()
+		if !bytes.Equal(got, files[i].Content) {
+			t.Errorf("entry %q = %q, want %q", entry.Name, got, files[i].Content)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	if got := Hash([]byte("abc")); got != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+		t.Errorf("Hash(abc) = %s", got)
+	}
+}
+
+func TestSafeFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"passport.pdf", "passport.pdf"},
+		{"../../etc/passwd", ".._.._etc_passwd"},
+		{`..\boot.ini`, `.._boot.ini`},
+		{"  ", "file"},
+		{"..", "file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SafeFileName(tt.name); got != tt.want {
+				t.Errorf("SafeFileName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -7,3 +7,7 @@ const RESET_PASSWORD_EXP_TIME = 5 * time.Minute
 
 const VERIFY_EMAIL_KEY = "VERIFY_EMAIL"
 const VERIFY_EMAIL_EXP_TIME = 5 * time.Minute
+
+const TRIP_EVENTS_CHANNEL = "TRIP_EVENTS"
+const TRIP_PRESENCE_KEY = "TRIP_PRESENCE"
+const TRIP_PRESENCE_EXP_TIME = 2 * time.Minute
@@ -0,0 +1,9 @@
+package constants
+
+import "time"
+
+// SYNC_TOMBSTONE_RETENTION is how long deletions are remembered; clients that last synced before that get a full resync
+const SYNC_TOMBSTONE_RETENTION = 90 * 24 * time.Hour // 90 days
+
+// SYNC_CURSOR_OVERLAP moves each sync cursor back so that rows written by transactions still open during the sync are not missed
+const SYNC_CURSOR_OVERLAP = time.Minute
@@ -0,0 +1,12 @@
+package constants
+
+import "time"
+
+// TRIP_TRASH_RETENTION is how long a deleted trip can still be restored before it is purged
+const TRIP_TRASH_RETENTION = 30 * 24 * time.Hour // 30 days
+
+// TRIP_BUNDLE_PHOTO_MAX_SIDE is the longest side in pixels of the photos in an offline trip bundle
+const TRIP_BUNDLE_PHOTO_MAX_SIDE = 1280
+
+// TRIP_BUNDLE_MAX_FILE_SIZE is the largest document or photo put in an offline trip bundle
+const TRIP_BUNDLE_MAX_FILE_SIZE = 25 << 20 // 25 MiB
@@ -0,0 +1,141 @@
+package currencyutils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/env"
+)
+
+// FallbackCurrency is used when neither the request nor DEFAULT_CURRENCY names one
+const FallbackCurrency = "VND"
+
+// PivotCurrency is the currency every stored rate is expressed against
+const PivotCurrency = "USD"
+
+// Normalise upper-cases an ISO 4217 code and reports whether it looks like one
+func Normalise(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", false
+		}
+	}
+	return code, true
+}
+
+// Default returns the budget currency for trips that don't set one
+func Default() string {
+	if value, err := env.GetEnv("DEFAULT_CURRENCY"); err == nil {
+		if code, ok := Normalise(value); ok {
+			return code
+		}
+	}
+	return FallbackCurrency
+}
+
+// Rate returns how many units of "to" one unit of "from" buys, given units of each currency per USD
+func Rate(from string, to string, unitsPerUSD map[string]float64) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+	fromRate, ok := unitsPerUSD[from]
+	if !ok || fromRate <= 0 {
+		return 0, false
+	}
+	toRate, ok := unitsPerUSD[to]
+	if !ok || toRate <= 0 {
+		return 0, false
+	}
+	return toRate / fromRate, true
+}
+
+// Rebase turns rates quoted as units per one "base" into units per USD.
+// Rates quoted against another base must include USD so they can be pivoted.
+func Rebase(base string, rates map[string]float64) (map[string]float64, error) {
+	base, ok := Normalise(base)
+	if !ok {
+		return nil, errors.New("invalid base currency")
+	}
+
+	normalised := make(map[string]float64, len(rates)+1)
+	for rawCode, rate := range rates {
+		code, ok := Normalise(rawCode)
+		if !ok || rate <= 0 {
+			return nil, errors.New("invalid rate for " + rawCode)
+		}
+		normalised[code] = rate
+	}
+	normalised[base] = 1
+
+	usdRate, ok := normalised[PivotCurrency]
+	if !ok {
+		return nil, errors.New("rates against " + base + " must include " + PivotCurrency)
+	}
+	unitsPerUSD := make(map[string]float64, len(normalised))
+	for code, rate := range normalised {
+		unitsPerUSD[code] = rate / usdRate
+	}
+	return unitsPerUSD, nil
+}
+
+// ParseJSON reads either {"base": "USD", "rates": {"VND": 25400}} or a bare {"VND": 25400} object
+func ParseJSON(data []byte) (string, map[string]float64, error) {
+	var wrapped struct {
+		Base  string             `json:"base"`
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err == nil && len(wrapped.Rates) > 0 {
+		if wrapped.Base == "" {
+			wrapped.Base = PivotCurrency
+		}
+		return wrapped.Base, wrapped.Rates, nil
+	}
+
+	var rates map[string]float64
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return "", nil, err
+	}
+	return PivotCurrency, rates, nil
+}
+
+// ParseCSV reads "currency,rate" rows, skipping a header row if there is one
+func ParseCSV(data []byte) (map[string]float64, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rates := make(map[string]float64)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			return nil, errors.New("line " + strconv.Itoa(line) + ": expected currency and rate")
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, errors.New("line " + strconv.Itoa(line) + ": invalid rate " + record[1])
+		}
+		rates[record[0]] = rate
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("no rates found")
+	}
+	return rates, nil
+}
@@ -0,0 +1,173 @@
+package currencyutils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"configured", "usd", "USD"},
+		{"not a currency code", "dollars", FallbackCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DEFAULT_CURRENCY", tt.value)
+			if got := Default(); got != tt.want {
+				t.Errorf("Default() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	unitsPerUSD := map[string]float64{"USD": 1, "VND": 25000, "EUR": 0.5, "BAD": 0}
+
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		want   float64
+		wantOK bool
+	}{
+		{"same currency without a rate", "JPY", "JPY", 1, true},
+		{"from the pivot", "USD", "VND", 25000, true},
+		{"to the pivot", "VND", "USD", 0.00004, true},
+		{"across the pivot", "EUR", "VND", 50000, true},
+		{"unknown source", "JPY", "USD", 0, false},
+		{"unknown target", "USD", "JPY", 0, false},
+		{"zero rate", "BAD", "USD", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Rate(tt.from, tt.to, unitsPerUSD)
+			if ok != tt.wantOK || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Rate(%q, %q) = (%v, %v), want (%v, %v)", tt.from, tt.to, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRebase(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		rates   map[string]float64
+		want    map[string]float64
+		wantErr bool
+	}{
+		{
+			name:  "already against the pivot",
+			base:  "USD",
+			rates: map[string]float64{"VND": 25000},
+			want:  map[string]float64{"USD": 1, "VND": 25000},
+		},
+		{
+			name:  "against another base",
+			base:  "eur",
+			rates: map[string]float64{"usd": 2, "VND": 50000},
+			want:  map[string]float64{"USD": 1, "EUR": 0.5, "VND": 25000},
+		},
+		{
+			name:    "another base without the pivot",
+			base:    "EUR",
+			rates:   map[string]float64{"VND": 50000},
+			wantErr: true,
+		},
+		{
+			name:    "invalid base",
+			base:    "EURO",
+			rates:   map[string]float64{"USD": 1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid code",
+			base:    "USD",
+			rates:   map[string]float64{"V1D": 25000},
+			wantErr: true,
+		},
+		{
+			name:    "non-positive rate",
+			base:    "USD",
+			rates:   map[string]float64{"VND": 0},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Rebase(tt.base, tt.rates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Rebase() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Rebase() = %v, want %v", got, tt.want)
+			}
+			for code, rate := range tt.want {
+				if math.Abs(got[code]-rate) > 1e-9 {
+					t.Errorf("Rebase()[%s] = %v, want %v", code, got[code], rate)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]float64
+		wantErr bool
+	}{
+		{
+			name: "with a header",
+			data: "currency,rate\nVND,25000\nEUR, 0.92\n",
+			want: map[string]float64{"VND": 25000, "EUR": 0.92},
+		},
+		{
+			name: "without a header",
+			data: "VND,25000\n",
+			want: map[string]float64{"VND": 25000},
+		},
+		{
+			name:    "invalid rate after the header",
+			data:    "currency,rate\nVND,lots\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing rate column",
+			data:    "VND\n",
+			wantErr: true,
+		},
+		{
+			name:    "only a header",
+			data:    "currency,rate\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCSV([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseCSV() = %v, want %v", got, tt.want)
+			}
+			for code, rate := range tt.want {
+				if got[code] != rate {
+					t.Errorf("ParseCSV()[%s] = %v, want %v", code, got[code], rate)
+				}
+			}
+		})
+	}
+}
@@ -57,6 +57,13 @@ type errorCode struct {
 	TRIP_INVITATION_RECEIVER_ALREADY_MEMBER string
 	INTERNAL_SERVER_ERROR                   string
 	BAD_REQUEST                             string
+
+	TRIP_DAY_OPTIMIZATION_INFEASIBLE string
+	TRIP_ITEM_SCHEDULE_CONFLICT      string
+	EXCHANGE_RATE_NOT_FOUND          string
+	TRIP_POLL_CLOSED                 string
+	TRIP_SOLE_MEMBER                 string
+	TRIP_INVALID_STATUS_TRANSITION   string
 }
 
 var ErrorCode = errorCode{
@@ -92,4 +99,11 @@ var ErrorCode = errorCode{
 	TRIP_INVITATION_RECEIVER_ALREADY_MEMBER: "TRIP_INVITATION_RECEIVER_ALREADY_MEMBER",
 	INTERNAL_SERVER_ERROR:                   "INTERNAL_SERVER_ERROR",
 	BAD_REQUEST:                             "BAD_REQUEST",
+
+	TRIP_DAY_OPTIMIZATION_INFEASIBLE: "TRIP_DAY_OPTIMIZATION_INFEASIBLE",
+	TRIP_ITEM_SCHEDULE_CONFLICT:      "TRIP_ITEM_SCHEDULE_CONFLICT",
+	EXCHANGE_RATE_NOT_FOUND:          "EXCHANGE_RATE_NOT_FOUND",
+	TRIP_POLL_CLOSED:                 "TRIP_POLL_CLOSED",
+	TRIP_SOLE_MEMBER:                 "TRIP_SOLE_MEMBER",
+	TRIP_INVALID_STATUS_TRANSITION:   "TRIP_INVALID_STATUS_TRANSITION",
 }
@@ -232,6 +232,48 @@ func ErrorCodeToHttpResponse(errCode string, field string) (statusCode int, http
 			Field:   field,
 			Code:    ErrorCode.TRIP_INVITATION_RECEIVER_ALREADY_MEMBER,
 		})
+	case ErrorCode.TRIP_DAY_OPTIMIZATION_INFEASIBLE:
+		statusCode = http.StatusUnprocessableEntity
+		httpErrResponse = httpcommon.NewErrorResponse(httpcommon.Error{
+			Message: "No ordering of this day satisfies the fixed start, end and pinned items",
+			Field:   field,
+			Code:    ErrorCode.TRIP_DAY_OPTIMIZATION_INFEASIBLE,
+		})
+	case ErrorCode.TRIP_ITEM_SCHEDULE_CONFLICT:
+		statusCode = http.StatusUnprocessableEntity
+		httpErrResponse = httpcommon.NewErrorResponse(httpcommon.Error{
+			Message: "The trip items overlap, are out of order or fall outside the trip. The issues are listed in data",
+			Field:   field,
+			Code:    ErrorCode.TRIP_ITEM_SCHEDULE_CONFLICT,
+		})
+	case ErrorCode.EXCHANGE_RATE_NOT_FOUND:
+		statusCode = http.StatusUnprocessableEntity
+		httpErrResponse = httpcommon.NewErrorResponse(httpcommon.Error{
+			Message: "No exchange rate is loaded between this currency and the trip budget currency",
+			Field:   field,
+			Code:    ErrorCode.EXCHANGE_RATE_NOT_FOUND,
+		})
+	case ErrorCode.TRIP_POLL_CLOSED:
+		statusCode = http.StatusConflict
+		httpErrResponse = httpcommon.NewErrorResponse(httpcommon.Error{
+			Message: "This poll is closed and no longer accepts votes",
+			Field:   field,
+			Code:    ErrorCode.TRIP_POLL_CLOSED,
+		})
+	case ErrorCode.TRIP_SOLE_MEMBER:
+		statusCode = http.StatusConflict
+		httpErrResponse = httpcommon.NewErrorResponse(httpcommon.Error{
+			Message: "You are the only member of this trip, delete it instead",
+			Field:   field,
+			Code:    ErrorCode.TRIP_SOLE_MEMBER,
+		})
+	case ErrorCode.TRIP_INVALID_STATUS_TRANSITION:
+		statusCode = http.StatusConflict
+		httpErrResponse = httpcommon.NewErrorResponse(httpcommon.Error{
+			Message: "The trip cannot move to this status from its current one",
+			Field:   field,
+			Code:    ErrorCode.TRIP_INVALID_STATUS_TRANSITION,
+		})
 	default:
 		statusCode = http.StatusInternalServerError
 		httpErrResponse = httpcommon.NewErrorResponse(httpcommon.Error{
@@ -0,0 +1,360 @@
+package expensereportutils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	timezoneutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/timezone_utils"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+func IsValidFormat(format string) bool {
+	return format == string(FormatCSV) || format == string(FormatXLSX)
+}
+
+type labels struct {
+	ExpensesSheet    string
+	MembersSheet     string
+	SettlementsSheet string
+	PaymentsTitle    string
+	Date             string
+	Day              string
+	Description      string
+	Category         string
+	Payer            string
+	Amount           string
+	Currency         string
+	ExchangeRate     string
+	AmountIn         string
+	SplitType        string
+	Participants     string
+	Member           string
+	Paid             string
+	Owed             string
+	Settled          string
+	Net              string
+	From             string
+	To               string
+	NothingToSettle  string
+	DateLayout       string
+	Categories       map[string]string
+	SplitTypes       map[string]string
+}
+
+var reportLabels = map[string]labels{
+	"vi": {
+		ExpensesSheet:    "Chi phí",
+		MembersSheet:     "Tổng theo thành viên",
+		SettlementsSheet: "Thanh toán",
+		PaymentsTitle:    "Các khoản đã trả",
+		Date:             "Ngày",
+		Day:              "Ngày thứ",
+		Description:      "Mô tả",
+		Category:         "Danh mục",
+		Payer:            "Người trả",
+		Amount:           "Số tiền",
+		Currency:         "Tiền tệ",
+		ExchangeRate:     "Tỷ giá",
+		AmountIn:         "Số tiền (%s)",
+		SplitType:        "Cách chia",
+		Participants:     "Người tham gia",
+		Member:           "Thành viên",
+		Paid:             "Đã trả",
+		Owed:             "Phải chịu",
+		Settled:          "Đã thanh toán",
+		Net:              "Còn lại",
+		From:             "Người trả",
+		To:               "Người nhận",
+		NothingToSettle:  "Mọi người đã thanh toán xong",
+		DateLayout:       "02/01/2006",
+		Categories: map[string]string{
+			model.ExpenseCategory.Food:      "Ăn uống",
+			model.ExpenseCategory.Tickets:   "Vé",
+			model.ExpenseCategory.Transport: "Di chuyển",
+			model.ExpenseCategory.Lodging:   "Lưu trú",
+			model.ExpenseCategory.Shopping:  "Mua sắm",
+			model.ExpenseCategory.Other:     "Khác",
+		},
+		SplitTypes: map[string]string{
+			model.ExpenseSplitType.Equal:  "Chia đều",
+			model.ExpenseSplitType.Shares: "Theo phần",
+			model.ExpenseSplitType.Exact:  "Số tiền cụ thể",
+		},
+	},
+	"en": {
+		ExpensesSheet:    "Expenses",
+		MembersSheet:     "Member totals",
+		SettlementsSheet: "Settle up",
+		PaymentsTitle:    "Recorded payments",
+		Date:             "Date",
+		Day:              "Day",
+		Description:      "Description",
+		Category:         "Category",
+		Payer:            "Paid by",
+		Amount:           "Amount",
+		Currency:         "Currency",
+		ExchangeRate:     "Exchange rate",
+		AmountIn:         "Amount (%s)",
+		SplitType:        "Split",
+		Participants:     "Participants",
+		Member:           "Member",
+		Paid:             "Paid",
+		Owed:             "Share",
+		Settled:          "Settled",
+		Net:              "Balance",
+		From:             "From",
+		To:               "To",
+		NothingToSettle:  "Everyone is settled up",
+		DateLayout:       "Jan 2, 2006",
+		Categories: map[string]string{
+			model.ExpenseCategory.Food:      "Food",
+			model.ExpenseCategory.Tickets:   "Tickets",
+			model.ExpenseCategory.Transport: "Transport",
+			model.ExpenseCategory.Lodging:   "Lodging",
+			model.ExpenseCategory.Shopping:  "Shopping",
+			model.ExpenseCategory.Other:     "Other",
+		},
+		SplitTypes: map[string]string{
+			model.ExpenseSplitType.Equal:  "Equal",
+			model.ExpenseSplitType.Shares: "Shares",
+			model.ExpenseSplitType.Exact:  "Exact amounts",
+		},
+	},
+}
+
+// cell is either text or a number, so spreadsheets can sum the amount columns
+type cell struct {
+	text   string
+	number *float64
+}
+
+func textCell(text string) cell {
+	return cell{text: text}
+}
+
+func numberCell(number float64) cell {
+	return cell{number: &number}
+}
+
+func (c cell) String() string {
+	if c.number != nil {
+		return strconv.FormatFloat(*c.number, 'f', -1, 64)
+	}
+	return c.text
+}
+
+// section is one sheet of the workbook, or one block of the CSV file
+type section struct {
+	title  string
+	header []string
+	rows   [][]cell
+}
+
+// Render produces the expense list, per-member totals and the settlement plan in the given format.
+// Column headers and enum values follow lang, which falls back to vi like the rest of the API.
+func Render(report model.TripExpenseReport, format Format, lang string) ([]byte, error) {
+	text, ok := reportLabels[lang]
+	if !ok {
+		text = reportLabels["vi"]
+	}
+
+	sections := []section{
+		expenseSection(report, text),
+		memberSection(report, text),
+		settlementSection(report, text),
+		paymentSection(report, text),
+	}
+
+	switch format {
+	case FormatCSV:
+		return renderCSV(sections)
+	case FormatXLSX:
+		// the recorded payments share the settle-up sheet, below the plan
+		settle := sections[2]
+		settle.rows = append(settle.rows, nil, []cell{textCell(sections[3].title)}, textCells(sections[3].header))
+		settle.rows = append(settle.rows, sections[3].rows...)
+		return renderXLSX(append(sections[:2], settle))
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func expenseSection(report model.TripExpenseReport, text labels) section {
+	location := timezoneutils.Location(report.TimeZone)
+	result := section{
+		title: text.ExpensesSheet,
+		header: []string{
+			text.Date, text.Day, text.Description, text.Category, text.Payer, text.Amount, text.Currency,
+			text.ExchangeRate, fmt.Sprintf(text.AmountIn, report.BudgetCurrency), text.SplitType, text.Participants,
+		},
+	}
+
+	for _, expense := range report.Expenses {
+		day := textCell("")
+		if expense.TripDay != nil {
+			day = numberCell(float64(*expense.TripDay))
+		}
+		rate, baseAmount := textCell(""), textCell("")
+		if expense.ExchangeRate != nil && expense.BaseAmount != nil &&
+			expense.BaseCurrency != nil && *expense.BaseCurrency == report.BudgetCurrency {
+			rate = numberCell(*expense.ExchangeRate)
+			baseAmount = numberCell(*expense.BaseAmount)
+		}
+
+		var participants []string
+		for _, split := range expense.Splits {
+			participants = append(participants, fmt.Sprintf("%s: %s", split.Name, strconv.FormatFloat(split.Amount, 'f', 2, 64)))
+		}
+
+		result.rows = append(result.rows, []cell{
+			textCell(expense.CreatedAt.In(location).Format(text.DateLayout)),
+			day,
+			textCell(expense.Description),
+			textCell(translate(text.Categories, expense.Category)),
+			textCell(expense.PayerName),
+			numberCell(expense.Amount),
+			textCell(expense.Currency),
+			rate,
+			baseAmount,
+			textCell(translate(text.SplitTypes, expense.SplitType)),
+			textCell(strings.Join(participants, "; ")),
+		})
+	}
+	return result
+}
+
+func memberSection(report model.TripExpenseReport, text labels) section {
+	result := section{
+		title:  text.MembersSheet,
+		header: []string{text.Currency, text.Member, text.Paid, text.Owed, text.Settled, text.Net},
+	}
+	for _, balance := range report.Balances {
+		for _, member := range balance.Members {
+			result.rows = append(result.rows, []cell{
+				textCell(balance.Currency),
+				textCell(member.Name),
+				numberCell(member.Paid),
+				numberCell(member.Owed),
+				numberCell(member.Settled),
+				numberCell(member.Net),
+			})
+		}
+	}
+	return result
+}
+
+func settlementSection(report model.TripExpenseReport, text labels) section {
+	result := section{
+		title:  text.SettlementsSheet,
+		header: []string{text.Currency, text.From, text.To, text.Amount},
+	}
+	for _, balance := range report.Balances {
+		for _, transfer := range balance.Transfers {
+			result.rows = append(result.rows, []cell{
+				textCell(balance.Currency),
+				textCell(transfer.FromName),
+				textCell(transfer.ToName),
+				numberCell(transfer.Amount),
+			})
+		}
+	}
+	if len(result.rows) == 0 {
+		result.rows = append(result.rows, []cell{textCell(text.NothingToSettle)})
+	}
+	return result
+}
+
+func paymentSection(report model.TripExpenseReport, text labels) section {
+	location := timezoneutils.Location(report.TimeZone)
+	result := section{
+		title:  text.PaymentsTitle,
+		header: []string{text.Date, text.From, text.To, text.Amount, text.Currency},
+	}
+	for _, settlement := range report.Settlements {
+		result.rows = append(result.rows, []cell{
+			textCell(settlement.CreatedAt.In(location).Format(text.DateLayout)),
+			textCell(settlement.FromName),
+			textCell(settlement.ToName),
+			numberCell(settlement.Amount),
+			textCell(settlement.Currency),
+		})
+	}
+	return result
+}
+
+func renderCSV(sections []section) ([]byte, error) {
+	var buf bytes.Buffer
+	// the byte order mark makes Excel read the file as UTF-8 instead of mangling Vietnamese
+	buf.WriteString("\ufeff")
+	writer := csv.NewWriter(&buf)
+
+	for i, s := range sections {
+		if i > 0 {
+			if err := writer.Write([]string{}); err != nil {
+				return nil, err
+			}
+		}
+		if err := writer.Write([]string{csvText(s.title)}); err != nil {
+			return nil, err
+		}
+		header := make([]string, len(s.header))
+		for j, title := range s.header {
+			header[j] = csvText(title)
+		}
+		if err := writer.Write(header); err != nil {
+			return nil, err
+		}
+		for _, row := range s.rows {
+			record := make([]string, len(row))
+			for j, c := range row {
+				record[j] = c.String()
+				if c.number == nil {
+					record[j] = csvText(record[j])
+				}
+			}
+			if err := writer.Write(record); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvText keeps spreadsheet apps from running member-typed text as a formula, by prefixing text
+// that starts like one with an apostrophe. Number cells are written as they are, minus sign included.
+func csvText(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+func textCells(values []string) []cell {
+	cells := make([]cell, len(values))
+	for i, value := range values {
+		cells[i] = textCell(value)
+	}
+	return cells
+}
+
+func translate(values map[string]string, key string) string {
+	if value, ok := values[key]; ok {
+		return value
+	}
+	return key
+}
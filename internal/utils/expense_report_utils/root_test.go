@@ -0,0 +1,46 @@
+package expensereportutils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSVText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"+1+1", "'+1+1"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+		{"Phở bò", "Phở bò"},
+		{"a=b", "a=b"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := csvText(tt.text); got != tt.want {
+			t.Errorf("csvText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRenderCSVEscapesOnlyText(t *testing.T) {
+	content, err := renderCSV([]section{{
+		title:  "Expenses",
+		header: []string{"Description", "Amount"},
+		rows:   [][]cell{{textCell("=cmd|' /C calc'!A0"), numberCell(-12.5)}},
+	}})
+	if err != nil {
+		t.Fatalf("renderCSV() error = %v", err)
+	}
+	got := string(content)
+	if !strings.Contains(got, "'=cmd|' /C calc'!A0") {
+		t.Errorf("formula text was not escaped:\n%s", got)
+	}
+	if !strings.Contains(got, ",-12.5") {
+		t.Errorf("negative number was escaped:\n%s", got)
+	}
+}
@@ -0,0 +1,62 @@
+package mentionutils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Find returns the ids of the members mentioned in content as "@Name", in order of first mention.
+// Names may contain spaces, so the longest matching name wins ("@Lan Anh" over "@Lan").
+func Find(content string, names map[int64]string) []int64 {
+	type candidate struct {
+		id   int64
+		name string
+	}
+	candidates := make([]candidate, 0, len(names))
+	for id, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			candidates = append(candidates, candidate{id: id, name: name})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i].name) != len(candidates[j].name) {
+			return len(candidates[i].name) > len(candidates[j].name)
+		}
+		return candidates[i].id < candidates[j].id
+	})
+
+	lowered := strings.ToLower(content)
+	mentioned := make([]int64, 0)
+	seen := make(map[int64]bool)
+	for offset := 0; offset < len(lowered); {
+		index := strings.IndexByte(lowered[offset:], '@')
+		if index == -1 {
+			break
+		}
+		rest := lowered[offset+index+1:]
+		offset += index + 1
+
+		for _, c := range candidates {
+			if !strings.HasPrefix(rest, c.name) || !endsWord(rest[len(c.name):]) {
+				continue
+			}
+			if !seen[c.id] {
+				seen[c.id] = true
+				mentioned = append(mentioned, c.id)
+			}
+			break
+		}
+	}
+	return mentioned
+}
+
+func endsWord(rest string) bool {
+	if rest == "" {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,41 @@
+package mentionutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	names := map[int64]string{
+		1: "Lan",
+		2: "Lan Anh",
+		3: "Minh",
+		4: "Đức",
+		5: "  ",
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    []int64
+	}{
+		{"no mentions", "see you at the airport", []int64{}},
+		{"single mention", "@Minh bring the tickets", []int64{3}},
+		{"case insensitive", "thanks @minh", []int64{3}},
+		{"longest name wins", "@Lan Anh and @Lan", []int64{2, 1}},
+		{"order of first mention without repeats", "@Minh @Lan @Minh", []int64{3, 1}},
+		{"name must end at a word boundary", "@Minhthu is not Minh", []int64{}},
+		{"punctuation ends a name", "ask @Lan, then @Minh.", []int64{1, 3}},
+		{"vietnamese name", "@đức ơi", []int64{4}},
+		{"unknown name", "@Hoa are you in?", []int64{}},
+		{"blank names never match", "@  hello", []int64{}},
+		{"lone at sign", "meet @ 9am @", []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Find(tt.content, names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Find(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
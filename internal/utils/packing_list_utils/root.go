@@ -0,0 +1,176 @@
+package packinglistutils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type label struct {
+	en string
+	vi string
+}
+
+func (l label) in(lang string) string {
+	if lang == "en" {
+		return l.en
+	}
+	return l.vi
+}
+
+// essentials go on every packing list regardless of the trip
+var essentials = []label{
+	{en: "Passport / ID card", vi: "Hộ chiếu / CCCD"},
+	{en: "Wallet, cards and some cash", vi: "Ví, thẻ và tiền mặt"},
+	{en: "Phone and charger", vi: "Điện thoại và sạc"},
+	{en: "Power bank", vi: "Sạc dự phòng"},
+	{en: "Toothbrush and toiletries", vi: "Bàn chải và đồ vệ sinh cá nhân"},
+	{en: "Basic first aid kit", vi: "Bộ sơ cứu cơ bản"},
+}
+
+// requirementItems maps a keyword in EnSpecialRequirements to what it adds
+var requirementItems = []struct {
+	keywords []string
+	items    []label
+}{
+	{keywords: []string{"baby", "babies", "infant", "toddler"}, items: []label{
+		{en: "Diapers and wipes", vi: "Tã và khăn ướt"},
+		{en: "Baby food and bottles", vi: "Đồ ăn dặm và bình sữa"},
+	}},
+	{keywords: []string{"child", "children", "kid", "family"}, items: []label{
+		{en: "Snacks and entertainment for kids", vi: "Đồ ăn vặt và đồ chơi cho trẻ"},
+	}},
+	{keywords: []string{"wheelchair", "mobility", "disabled", "disability", "disabilities"}, items: []label{
+		{en: "Mobility aids and spare parts", vi: "Dụng cụ hỗ trợ di chuyển và phụ tùng"},
+	}},
+	{keywords: []string{"pet"}, items: []label{
+		{en: "Pet food, leash and documents", vi: "Thức ăn, dây dắt và giấy tờ cho thú cưng"},
+	}},
+	{keywords: []string{"vegetarian", "vegan", "halal", "kosher"}, items: []label{
+		{en: "Dietary snacks for long transfers", vi: "Đồ ăn vặt phù hợp chế độ ăn cho chặng dài"},
+	}},
+	{keywords: []string{"elder", "elderly", "senior"}, items: []label{
+		{en: "Comfortable walking shoes and seat cushion", vi: "Giày đi bộ êm và đệm ngồi"},
+	}},
+}
+
+// conditionItems maps a keyword in EnMedicalConditions to what it adds
+var conditionItems = []struct {
+	keywords []string
+	items    []label
+}{
+	{keywords: []string{"asthma"}, items: []label{
+		{en: "Inhaler", vi: "Bình xịt hen suyễn"},
+	}},
+	{keywords: []string{"diabetes", "diabetic"}, items: []label{
+		{en: "Insulin, glucose meter and sugar tablets", vi: "Insulin, máy đo đường huyết và viên đường"},
+	}},
+	{keywords: []string{"allergy", "allergies", "allergic"}, items: []label{
+		{en: "Antihistamines and epinephrine auto-injector", vi: "Thuốc chống dị ứng và bút tiêm epinephrine"},
+	}},
+	{keywords: []string{"motion", "sickness", "nausea"}, items: []label{
+		{en: "Motion sickness tablets", vi: "Thuốc chống say tàu xe"},
+	}},
+	{keywords: []string{"heart", "blood pressure", "hypertension"}, items: []label{
+		{en: "Blood pressure monitor", vi: "Máy đo huyết áp"},
+	}},
+}
+
+// maxOutfits caps clothing so long trips assume a laundry stop
+const maxOutfits = 7
+
+// DefaultPackingList builds a packing list from the trip length, special requirements and medical conditions.
+// Requirements and conditions are matched on their English values, items are labelled in lang (vi or en).
+func DefaultPackingList(days int, specialRequirements []string, medicalConditions []string, lang string) []string {
+	items := make([]string, 0, len(essentials)+8)
+	for _, item := range essentials {
+		items = append(items, item.in(lang))
+	}
+
+	outfits := min(max(days, 1), maxOutfits)
+	if lang == "en" {
+		items = append(items, fmt.Sprintf("Clothes for %d days", outfits))
+	} else {
+		items = append(items, fmt.Sprintf("Quần áo cho %d ngày", outfits))
+	}
+	if days > maxOutfits {
+		items = append(items, label{en: "Laundry bag and detergent", vi: "Túi giặt và bột giặt"}.in(lang))
+	}
+
+	seen := make(map[string]bool)
+	add := func(item label) {
+		if !seen[item.en] {
+			seen[item.en] = true
+			items = append(items, item.in(lang))
+		}
+	}
+
+	for _, requirement := range specialRequirements {
+		for _, rule := range requirementItems {
+			if containsAny(requirement, rule.keywords) {
+				for _, item := range rule.items {
+					add(item)
+				}
+			}
+		}
+	}
+
+	for _, condition := range medicalConditions {
+		condition = strings.TrimSpace(condition)
+		if condition == "" {
+			continue
+		}
+		// every condition gets its prescription, known ones also get their specific kit
+		add(label{
+			en: "Prescription medication for " + condition,
+			vi: "Thuốc theo đơn cho " + condition,
+		})
+		for _, rule := range conditionItems {
+			if containsAny(condition, rule.keywords) {
+				for _, item := range rule.items {
+					add(item)
+				}
+			}
+		}
+	}
+	if len(medicalConditions) > 0 {
+		add(label{en: "Doctor's letter and medical records", vi: "Giấy bác sĩ và hồ sơ bệnh án"})
+	}
+
+	return items
+}
+
+// containsAny reports whether a keyword appears in value as a whole word or its plural,
+// so "pet" matches "pets" but not "competitive", "petrol" or "petite"
+func containsAny(value string, keywords []string) bool {
+	value = strings.ToLower(value)
+	for _, keyword := range keywords {
+		for offset := 0; offset < len(value); {
+			index := strings.Index(value[offset:], keyword)
+			if index == -1 {
+				break
+			}
+			index += offset
+			end := index + len(keyword)
+			if strings.HasPrefix(value[end:], "s") && endsWord(value, end+1) {
+				end++
+			}
+			if startsWord(value, index) && endsWord(value, end) {
+				return true
+			}
+			offset = index + 1
+		}
+	}
+	return false
+}
+
+func startsWord(value string, index int) bool {
+	previous, _ := utf8.DecodeLastRuneInString(value[:index])
+	return index == 0 || !unicode.IsLetter(previous)
+}
+
+func endsWord(value string, index int) bool {
+	next, _ := utf8.DecodeRuneInString(value[index:])
+	return index == len(value) || !unicode.IsLetter(next)
+}
@@ -0,0 +1,114 @@
+package packinglistutils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestContainsAny(t *testing.T) {
+	tests := []struct {
+		value    string
+		keywords []string
+		want     bool
+	}{
+		{"Travelling with a pet", []string{"pet"}, true},
+		{"two pets", []string{"pet"}, true},
+		{"Pet-friendly hotel", []string{"pet"}, true},
+		{"petrol car rental", []string{"pet"}, false},
+		{"petite sizes", []string{"pet"}, false},
+		{"competitive cycling", []string{"pet"}, false},
+		{"carpet cleaning", []string{"pet"}, false},
+		{"kids under 5", []string{"child", "kid"}, true},
+		{"kidney condition", []string{"kid"}, false},
+		{"young children", []string{"child", "children"}, true},
+		{"Type 2 diabetes", []string{"diabetes", "diabetic"}, true},
+		{"high blood pressure", []string{"blood pressure"}, true},
+		{"peanut allergies", []string{"allergy", "allergies"}, true},
+		{"hearty meals", []string{"heart"}, false},
+		{"épet", []string{"pet"}, false},
+		{"", []string{"pet"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := containsAny(tt.value, tt.keywords); got != tt.want {
+				t.Errorf("containsAny(%q, %v) = %v, want %v", tt.value, tt.keywords, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultPackingList(t *testing.T) {
+	tests := []struct {
+		name                string
+		days                int
+		specialRequirements []string
+		medicalConditions   []string
+		lang                string
+		want                []string
+		notWant             []string
+	}{
+		{
+			name:    "short trip",
+			days:    3,
+			lang:    "en",
+			want:    []string{"Passport / ID card", "Clothes for 3 days"},
+			notWant: []string{"Laundry bag and detergent", "Doctor's letter and medical records"},
+		},
+		{
+			name: "long trip caps outfits",
+			days: 12,
+			lang: "en",
+			want: []string{"Clothes for 7 days", "Laundry bag and detergent"},
+		},
+		{
+			name:                "pets but not petrol",
+			days:                2,
+			specialRequirements: []string{"Bringing our pets", "Petrol car rental"},
+			lang:                "en",
+			want:                []string{"Pet food, leash and documents"},
+		},
+		{
+			name:                "petrol alone adds nothing",
+			days:                2,
+			specialRequirements: []string{"Petrol car rental", "petite sizes"},
+			lang:                "en",
+			notWant:             []string{"Pet food, leash and documents"},
+		},
+		{
+			name:              "medical conditions",
+			days:              2,
+			medicalConditions: []string{"Diabetes", " ", "asthma"},
+			lang:              "en",
+			want: []string{
+				"Prescription medication for Diabetes",
+				"Insulin, glucose meter and sugar tablets",
+				"Prescription medication for asthma",
+				"Inhaler",
+				"Doctor's letter and medical records",
+			},
+			notWant: []string{"Prescription medication for "},
+		},
+		{
+			name:                "vietnamese labels",
+			days:                2,
+			specialRequirements: []string{"infant"},
+			lang:                "vi",
+			want:                []string{"Hộ chiếu / CCCD", "Quần áo cho 2 ngày", "Tã và khăn ướt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DefaultPackingList(tt.days, tt.specialRequirements, tt.medicalConditions, tt.lang)
+			for _, item := range tt.want {
+				if !slices.Contains(got, item) {
+					t.Errorf("DefaultPackingList() = %v, missing %q", got, item)
+				}
+			}
+			for _, item := range tt.notWant {
+				if slices.Contains(got, item) {
+					t.Errorf("DefaultPackingList() = %v, should not have %q", got, item)
+				}
+			}
+		})
+	}
+}
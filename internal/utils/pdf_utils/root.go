@@ -0,0 +1,286 @@
+package pdfutils
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/env"
+	timezoneutils "github.com/swefinal-travel-planner/travel-app-be/internal/utils/timezone_utils"
+)
+
+const fontFamily = "itinerary"
+
+// DejaVu Sans covers the full Vietnamese alphabet, see fonts/LICENSE
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	regularFont []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	boldFont []byte
+)
+
+type labels struct {
+	City       string
+	Dates      string
+	Members    string
+	Day        string
+	Time       string
+	Place      string
+	Address    string
+	Note       string
+	NoItems    string
+	Unresolved string
+	Page       string
+	DateLayout string
+	TimeInDate map[string]string
+}
+
+var itineraryLabels = map[string]labels{
+	"vi": {
+		City:       "Thành phố",
+		Dates:      "Thời gian",
+		Members:    "Thành viên",
+		Day:        "Ngày",
+		Time:       "Buổi",
+		Place:      "Địa điểm",
+		Address:    "Địa chỉ",
+		Note:       "Ghi chú",
+		NoItems:    "Chưa có lịch trình cho ngày này",
+		Unresolved: "Không tìm thấy thông tin địa điểm",
+		Page:       "Trang",
+		DateLayout: "02/01/2006",
+		TimeInDate: map[string]string{
+			model.TimeInDate.Morning:   "Sáng",
+			model.TimeInDate.Afternoon: "Chiều",
+			model.TimeInDate.Evening:   "Tối",
+			model.TimeInDate.Night:     "Đêm",
+		},
+	},
+	"en": {
+		City:       "City",
+		Dates:      "Dates",
+		Members:    "Members",
+		Day:        "Day",
+		Time:       "Time",
+		Place:      "Place",
+		Address:    "Address",
+		Note:       "Note",
+		NoItems:    "Nothing planned for this day yet",
+		Unresolved: "Place information unavailable",
+		Page:       "Page",
+		DateLayout: "Jan 2, 2006",
+		TimeInDate: map[string]string{
+			model.TimeInDate.Morning:   "Morning",
+			model.TimeInDate.Afternoon: "Afternoon",
+			model.TimeInDate.Evening:   "Evening",
+			model.TimeInDate.Night:     "Night",
+		},
+	},
+}
+
+type itineraryWriter struct {
+	pdf *fpdf.Fpdf
+	// tableTop is where the rows start below the table header on the current page
+	tableTop float64
+}
+
+// RenderItinerary renders a cover page followed by a day-by-day table of the trip items.
+// The embedded DejaVu font is used unless PDF_FONT_PATH (and optionally PDF_BOLD_FONT_PATH)
+// points to another TTF.
+func RenderItinerary(itinerary model.TripItinerary, lang string) ([]byte, error) {
+	text, ok := itineraryLabels[lang]
+	if !ok {
+		text = itineraryLabels["vi"]
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	writer := &itineraryWriter{pdf: pdf}
+	if err := writer.loadFonts(); err != nil {
+		return nil, err
+	}
+
+	pdf.SetTitle(itinerary.Trip.Title, true)
+	pdf.SetMargins(15, 15, 15)
+	// the bottom margin leaves room for the footer, text that runs past it continues on a new page
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AliasNbPages("{nb}")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%s %d/{nb}", text.Page, pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	writer.coverPage(itinerary, text)
+	writer.dayPages(itinerary, text)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (w *itineraryWriter) loadFonts() error {
+	regular := regularFont
+	bold := boldFont
+
+	if path, err := env.GetEnv("PDF_FONT_PATH"); err == nil && path != "" {
+		fontBytes, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read PDF_FONT_PATH: %w", err)
+		}
+		regular = fontBytes
+		bold = fontBytes
+
+		if boldPath, err := env.GetEnv("PDF_BOLD_FONT_PATH"); err == nil && boldPath != "" {
+			boldBytes, err := os.ReadFile(boldPath)
+			if err != nil {
+				return fmt.Errorf("failed to read PDF_BOLD_FONT_PATH: %w", err)
+			}
+			bold = boldBytes
+		}
+	}
+
+	w.pdf.AddUTF8FontFromBytes(fontFamily, "", regular)
+	w.pdf.AddUTF8FontFromBytes(fontFamily, "B", bold)
+	return w.pdf.Error()
+}
+
+func (w *itineraryWriter) coverPage(itinerary model.TripItinerary, text labels) {
+	pdf := w.pdf
+	trip := itinerary.Trip
+	pdf.AddPage()
+
+	pdf.SetY(70)
+	pdf.SetFont(fontFamily, "B", 26)
+	pdf.MultiCell(0, 12, trip.Title, "", "C", false)
+	pdf.Ln(10)
+
+	startDate := timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, 1)
+	endDate := startDate.AddDate(0, 0, trip.Days-1)
+	rows := [][2]string{
+		{text.City, trip.City},
+		{text.Dates, fmt.Sprintf("%s - %s (%d %s)", startDate.Format(text.DateLayout), endDate.Format(text.DateLayout), trip.Days, strings.ToLower(text.Day))},
+	}
+	var names []string
+	for _, member := range itinerary.Members {
+		names = append(names, member.Name)
+	}
+	rows = append(rows, [2]string{text.Members, strings.Join(names, ", ")})
+
+	for _, row := range rows {
+		pdf.SetX(35)
+		pdf.SetFont(fontFamily, "B", 12)
+		pdf.CellFormat(35, 8, row[0], "", 0, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 12)
+		pdf.MultiCell(105, 8, row[1], "", "L", false)
+	}
+}
+
+var columnWidths = []float64{22, 50, 60, 48}
+
+func (w *itineraryWriter) dayPages(itinerary model.TripItinerary, text labels) {
+	pdf := w.pdf
+	trip := itinerary.Trip
+
+	itemsByDay := make(map[int64][]model.TripItemResponse)
+	for _, item := range itinerary.Items {
+		itemsByDay[item.TripDay] = append(itemsByDay[item.TripDay], item)
+	}
+
+	for day := 1; day <= trip.Days; day++ {
+		pdf.AddPage()
+		date := timezoneutils.TripDayStart(trip.StartDate, trip.TimeZone, int64(day))
+		pdf.SetFont(fontFamily, "B", 16)
+		pdf.CellFormat(0, 10, fmt.Sprintf("%s %d - %s", text.Day, day, date.Format(text.DateLayout)), "", 1, "L", false, 0, "")
+		pdf.Ln(2)
+
+		items := itemsByDay[int64(day)]
+		if len(items) == 0 {
+			pdf.SetFont(fontFamily, "", 11)
+			pdf.CellFormat(0, 8, text.NoItems, "", 1, "L", false, 0, "")
+			continue
+		}
+
+		w.tableHeader(text)
+		for _, item := range items {
+			place := text.Unresolved
+			address := ""
+			if item.PlaceInfo != nil {
+				place = item.PlaceInfo.Name
+				address = item.PlaceInfo.Address
+			}
+			slot, ok := text.TimeInDate[item.TimeInDate]
+			if !ok {
+				slot = item.TimeInDate
+			}
+			note := ""
+			if item.Note != nil {
+				note = *item.Note
+			}
+			w.tableRow(text, []string{slot, place, address, note})
+		}
+	}
+}
+
+func (w *itineraryWriter) tableHeader(text labels) {
+	pdf := w.pdf
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.SetFillColor(230, 236, 245)
+	for i, header := range []string{text.Time, text.Place, text.Address, text.Note} {
+		pdf.CellFormat(columnWidths[i], 8, header, "1", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+	w.tableTop = pdf.GetY()
+}
+
+func (w *itineraryWriter) tableRow(text labels, cells []string) {
+	pdf := w.pdf
+	pdf.SetFont(fontFamily, "", 10)
+	const lineHeight = 5.0
+
+	// wrap every cell first so the whole row gets the height of its tallest cell
+	lines := make([][]string, len(cells))
+	rowLines := 1
+	for i, cell := range cells {
+		lines[i] = pdf.SplitText(cell, columnWidths[i]-2)
+		if len(lines[i]) > rowLines {
+			rowLines = len(lines[i])
+		}
+	}
+
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottomMargin := pdf.GetMargins()
+	pageBottom := pageHeight - bottomMargin
+
+	// a row that does not fit moves to the next page, and one taller than a whole page
+	// continues over the following pages instead of running off the bottom
+	for start := 0; start < rowLines; {
+		fits := int((pageBottom - pdf.GetY() - 2) / lineHeight)
+		if fits < 1 || (fits < rowLines-start && pdf.GetY() > w.tableTop) {
+			pdf.AddPage()
+			w.tableHeader(text)
+			pdf.SetFont(fontFamily, "", 10)
+			continue
+		}
+		count := min(fits, rowLines-start)
+		rowHeight := float64(count)*lineHeight + 2
+
+		x, y := pdf.GetXY()
+		for i := range cells {
+			pdf.Rect(x, y, columnWidths[i], rowHeight, "D")
+			for j := start; j < start+count && j < len(lines[i]); j++ {
+				pdf.SetXY(x+1, y+1+float64(j-start)*lineHeight)
+				pdf.CellFormat(columnWidths[i]-2, lineHeight, lines[i][j], "", 0, "L", false, 0, "")
+			}
+			x += columnWidths[i]
+		}
+		pdf.SetXY(15, y+rowHeight)
+		start += count
+	}
+}
@@ -0,0 +1,117 @@
+package pdfutils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+func testItinerary(days int) model.TripItinerary {
+	return model.TripItinerary{
+		Trip: model.TripResponse{
+			Title:     "Hà Nội mùa thu",
+			City:      "Hà Nội",
+			StartDate: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+			TimeZone:  "Asia/Ho_Chi_Minh",
+			Days:      days,
+		},
+		Members: []model.TripMemberResponse{{Name: "Nguyễn Văn Ấn"}},
+	}
+}
+
+func pageCount(content []byte) int {
+	return bytes.Count(content, []byte("/Type /Page\n")) + bytes.Count(content, []byte("/Type /Page "))
+}
+
+func TestRenderItinerary(t *testing.T) {
+	longNote := strings.Repeat("Đặt bàn trước ở quán phở gần Hồ Gươm. ", 200)
+	var manyMembers []model.TripMemberResponse
+	for i := 0; i < 400; i++ {
+		manyMembers = append(manyMembers, model.TripMemberResponse{Name: "Trần Thị Phương Thảo"})
+	}
+
+	tests := []struct {
+		name         string
+		itinerary    func() model.TripItinerary
+		lang         string
+		wantMinPages int
+	}{
+		{
+			name:         "cover and one page per day",
+			itinerary:    func() model.TripItinerary { return testItinerary(2) },
+			lang:         "vi",
+			wantMinPages: 3,
+		},
+		{
+			name: "note longer than a page continues on the next one",
+			itinerary: func() model.TripItinerary {
+				itinerary := testItinerary(1)
+				itinerary.Items = []model.TripItemResponse{{TripDay: 1, TimeInDate: model.TimeInDate.Morning, Note: &longNote}}
+				return itinerary
+			},
+			lang:         "en",
+			wantMinPages: 4,
+		},
+		{
+			name: "member list longer than the cover continues on the next page",
+			itinerary: func() model.TripItinerary {
+				itinerary := testItinerary(1)
+				itinerary.Members = manyMembers
+				return itinerary
+			},
+			lang:         "vi",
+			wantMinPages: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := RenderItinerary(tt.itinerary(), tt.lang)
+			if err != nil {
+				t.Fatalf("RenderItinerary() error = %v", err)
+			}
+			if pages := pageCount(content); pages < tt.wantMinPages {
+				t.Errorf("RenderItinerary() has %d pages, want at least %d", pages, tt.wantMinPages)
+			}
+		})
+	}
+}
+
+func TestTableRowStaysAboveFooter(t *testing.T) {
+	text := itineraryLabels["vi"]
+	tests := []struct {
+		name  string
+		cells []string
+		rows  int
+	}{
+		{"many short rows", []string{"Sáng", "Văn Miếu", "58 Quốc Tử Giám", ""}, 80},
+		{"row taller than a page", []string{"Tối", "Phố cổ", "", strings.Repeat("Ghi chú rất dài. ", 400)}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &itineraryWriter{pdf: fpdf.New("P", "mm", "A4", "")}
+			if err := writer.loadFonts(); err != nil {
+				t.Fatalf("loadFonts() error = %v", err)
+			}
+			writer.pdf.SetMargins(15, 15, 15)
+			writer.pdf.SetAutoPageBreak(true, 20)
+			writer.pdf.AddPage()
+			writer.tableHeader(text)
+
+			_, pageHeight := writer.pdf.GetPageSize()
+			for i := 0; i < tt.rows; i++ {
+				writer.tableRow(text, tt.cells)
+				if y := writer.pdf.GetY(); y > pageHeight-20 {
+					t.Fatalf("row %d ends at %.1fmm, below the %.1fmm page bottom", i, y, pageHeight-20)
+				}
+			}
+			if writer.pdf.PageNo() < 2 {
+				t.Errorf("PageNo() = %d, want the rows to continue on a second page", writer.pdf.PageNo())
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package routeutils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+type gpxWaypoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Time string  `xml:"time,omitempty"`
+	Name string  `xml:"name"`
+	Desc string  `xml:"desc,omitempty"`
+}
+
+type gpxRoute struct {
+	Name   string        `xml:"name"`
+	Number int64         `xml:"number"`
+	Points []gpxWaypoint `xml:"rtept"`
+}
+
+type gpxMetadata struct {
+	Name string `xml:"name"`
+	Desc string `xml:"desc,omitempty"`
+}
+
+type gpxDocument struct {
+	XMLName  xml.Name    `xml:"gpx"`
+	Version  string      `xml:"version,attr"`
+	Creator  string      `xml:"creator,attr"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Metadata gpxMetadata `xml:"metadata"`
+	Routes   []gpxRoute  `xml:"rte"`
+}
+
+// EncodeGPX renders one <rte> per trip day, waypoints in order_in_day order
+func EncodeGPX(route model.TripRoute) ([]byte, error) {
+	doc := gpxDocument{
+		Version: "1.1",
+		Creator: "travel-app-be",
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		Metadata: gpxMetadata{
+			Name: route.Title,
+			Desc: unresolvedDescription(route.UnresolvedItems),
+		},
+	}
+
+	for _, day := range route.Days {
+		gpxRte := gpxRoute{
+			Name:   dayName(day),
+			Number: day.TripDay,
+		}
+		for _, waypoint := range day.Waypoints {
+			gpxRte.Points = append(gpxRte.Points, gpxWaypoint{
+				Lat:  waypoint.Lat,
+				Lon:  waypoint.Long,
+				Time: waypoint.Time.UTC().Format(time.RFC3339),
+				Name: waypoint.Name,
+				Desc: waypoint.Address,
+			})
+		}
+		doc.Routes = append(doc.Routes, gpxRte)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlLineString struct {
+	Tessellate  int    `xml:"tessellate"`
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlTimeStamp struct {
+	When string `xml:"when"`
+}
+
+type kmlPlacemark struct {
+	Name        string         `xml:"name"`
+	Description string         `xml:"description,omitempty"`
+	TimeStamp   *kmlTimeStamp  `xml:"TimeStamp,omitempty"`
+	Point       *kmlPoint      `xml:"Point,omitempty"`
+	LineString  *kmlLineString `xml:"LineString,omitempty"`
+}
+
+type kmlFolder struct {
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlDocument struct {
+	Name        string      `xml:"name"`
+	Description string      `xml:"description,omitempty"`
+	Folders     []kmlFolder `xml:"Folder"`
+}
+
+type kmlRoot struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Document kmlDocument `xml:"Document"`
+}
+
+// EncodeKML renders one folder per trip day holding a placemark per stop and the day's path
+func EncodeKML(route model.TripRoute) ([]byte, error) {
+	doc := kmlRoot{
+		Xmlns: "http://www.opengis.net/kml/2.2",
+		Document: kmlDocument{
+			Name:        route.Title,
+			Description: unresolvedDescription(route.UnresolvedItems),
+		},
+	}
+
+	for _, day := range route.Days {
+		folder := kmlFolder{Name: dayName(day)}
+		var path []string
+		for _, waypoint := range day.Waypoints {
+			coordinates := fmt.Sprintf("%f,%f", waypoint.Long, waypoint.Lat)
+			path = append(path, coordinates)
+			folder.Placemarks = append(folder.Placemarks, kmlPlacemark{
+				Name:        waypoint.Name,
+				Description: waypoint.Address,
+				TimeStamp:   &kmlTimeStamp{When: waypoint.Time.UTC().Format(time.RFC3339)},
+				Point:       &kmlPoint{Coordinates: coordinates},
+			})
+		}
+		if len(path) > 1 {
+			folder.Placemarks = append(folder.Placemarks, kmlPlacemark{
+				Name:       dayName(day) + " route",
+				LineString: &kmlLineString{Tessellate: 1, Coordinates: strings.Join(path, " ")},
+			})
+		}
+		doc.Document.Folders = append(doc.Document.Folders, folder)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONFeatureCollection struct {
+	Type            string                          `json:"type"`
+	Name            string                          `json:"name"`
+	Features        []geoJSONFeature                `json:"features"`
+	UnresolvedItems []model.TripRouteUnresolvedItem `json:"unresolvedItems"`
+}
+
+// EncodeGeoJSON renders a Point feature per stop and a LineString feature per trip day
+func EncodeGeoJSON(route model.TripRoute) ([]byte, error) {
+	collection := geoJSONFeatureCollection{
+		Type:            "FeatureCollection",
+		Name:            route.Title,
+		Features:        make([]geoJSONFeature, 0),
+		UnresolvedItems: route.UnresolvedItems,
+	}
+
+	for _, day := range route.Days {
+		var path [][]float64
+		for _, waypoint := range day.Waypoints {
+			coordinates := []float64{waypoint.Long, waypoint.Lat}
+			path = append(path, coordinates)
+			collection.Features = append(collection.Features, geoJSONFeature{
+				Type:     "Feature",
+				Geometry: geoJSONGeometry{Type: "Point", Coordinates: coordinates},
+				Properties: map[string]interface{}{
+					"tripItemId": waypoint.TripItemID,
+					"placeId":    waypoint.PlaceID,
+					"name":       waypoint.Name,
+					"address":    waypoint.Address,
+					"tripDay":    day.TripDay,
+					"orderInDay": waypoint.OrderInDay,
+					"timeInDate": waypoint.TimeInDate,
+					"time":       waypoint.Time.UTC().Format(time.RFC3339),
+				},
+			})
+		}
+		if len(path) > 1 {
+			collection.Features = append(collection.Features, geoJSONFeature{
+				Type:     "Feature",
+				Geometry: geoJSONGeometry{Type: "LineString", Coordinates: path},
+				Properties: map[string]interface{}{
+					"name":    dayName(day),
+					"tripDay": day.TripDay,
+					"date":    day.Date.Format(time.DateOnly),
+				},
+			})
+		}
+	}
+
+	return json.MarshalIndent(collection, "", "  ")
+}
+
+func dayName(day model.TripRouteDay) string {
+	return fmt.Sprintf("Day %d (%s)", day.TripDay, day.Date.Format(time.DateOnly))
+}
+
+// unresolvedDescription lists the stops that were left out because their place info could not be resolved
+func unresolvedDescription(items []model.TripRouteUnresolvedItem) string {
+	if len(items) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, item := range items {
+		parts = append(parts, fmt.Sprintf("day %d #%d (place %s)", item.TripDay, item.OrderInDay, item.PlaceID))
+	}
+	return "Unresolved stops: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,210 @@
+package routeutils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+func testRoute(unresolved []model.TripRouteUnresolvedItem) model.TripRoute {
+	dayOne := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	dayTwo := dayOne.AddDate(0, 0, 1)
+	return model.TripRoute{
+		TripID: 7,
+		Title:  "Saigon weekend",
+		City:   "Ho Chi Minh City",
+		Days: []model.TripRouteDay{
+			{
+				TripDay: 1,
+				Date:    dayOne,
+				Waypoints: []model.TripRouteWaypoint{
+					{TripItemID: 11, PlaceID: "p-market", Name: "Ben Thanh Market", Address: "District 1", OrderInDay: 1, TimeInDate: "morning", Time: time.Date(2025, 8, 1, 2, 0, 0, 0, time.UTC), Lat: 10.772, Long: 106.698},
+					{TripItemID: 12, PlaceID: "p-post", Name: "Central Post Office", Address: "2 Cong Xa Paris", OrderInDay: 2, TimeInDate: "afternoon", Time: time.Date(2025, 8, 1, 7, 0, 0, 0, time.UTC), Lat: 10.78, Long: 106.7},
+				},
+			},
+			{
+				TripDay: 2,
+				Date:    dayTwo,
+				Waypoints: []model.TripRouteWaypoint{
+					{TripItemID: 21, PlaceID: "p-tunnels", Name: "Cu Chi Tunnels", OrderInDay: 1, TimeInDate: "morning", Time: time.Date(2025, 8, 2, 2, 0, 0, 0, time.UTC), Lat: 11.143, Long: 106.464},
+				},
+			},
+		},
+		UnresolvedItems: unresolved,
+	}
+}
+
+var routeTests = []struct {
+	name            string
+	unresolved      []model.TripRouteUnresolvedItem
+	wantDescription string
+}{
+	{"all stops resolved", []model.TripRouteUnresolvedItem{}, ""},
+	{
+		"some stops unresolved",
+		[]model.TripRouteUnresolvedItem{
+			{TripItemID: 13, PlaceID: "p-gone", TripDay: 1, OrderInDay: 3},
+			{TripItemID: 22, PlaceID: "p-missing", TripDay: 2, OrderInDay: 2},
+		},
+		"Unresolved stops: day 1 #3 (place p-gone), day 2 #2 (place p-missing)",
+	},
+}
+
+func TestEncodeGPX(t *testing.T) {
+	for _, tt := range routeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := EncodeGPX(testRoute(tt.unresolved))
+			if err != nil {
+				t.Fatalf("EncodeGPX() error = %v", err)
+			}
+			if !strings.HasPrefix(string(body), xml.Header) {
+				t.Errorf("EncodeGPX() is missing the xml header")
+			}
+
+			var doc gpxDocument
+			if err := xml.Unmarshal(body, &doc); err != nil {
+				t.Fatalf("xml.Unmarshal() error = %v", err)
+			}
+			if doc.Version != "1.1" || doc.XMLName.Space != "http://www.topografix.com/GPX/1/1" {
+				t.Errorf("gpx version = %q, namespace = %q", doc.Version, doc.XMLName.Space)
+			}
+			if doc.Metadata.Name != "Saigon weekend" || doc.Metadata.Desc != tt.wantDescription {
+				t.Errorf("metadata = %+v, want name %q and desc %q", doc.Metadata, "Saigon weekend", tt.wantDescription)
+			}
+
+			if len(doc.Routes) != 2 {
+				t.Fatalf("got %d routes, want one per day", len(doc.Routes))
+			}
+			first := doc.Routes[0]
+			if first.Name != "Day 1 (2025-08-01)" || first.Number != 1 || len(first.Points) != 2 {
+				t.Errorf("day 1 route = %q #%d with %d points", first.Name, first.Number, len(first.Points))
+			}
+			if first.Points[0].Name != "Ben Thanh Market" || first.Points[1].Name != "Central Post Office" {
+				t.Errorf("day 1 waypoints out of order: %q, %q", first.Points[0].Name, first.Points[1].Name)
+			}
+			if first.Points[0].Lat != 10.772 || first.Points[0].Lon != 106.698 || first.Points[0].Time != "2025-08-01T02:00:00Z" {
+				t.Errorf("first waypoint = %+v", first.Points[0])
+			}
+			if second := doc.Routes[1]; second.Number != 2 || len(second.Points) != 1 {
+				t.Errorf("day 2 route = #%d with %d points", second.Number, len(second.Points))
+			}
+		})
+	}
+}
+
+func TestEncodeKML(t *testing.T) {
+	for _, tt := range routeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := EncodeKML(testRoute(tt.unresolved))
+			if err != nil {
+				t.Fatalf("EncodeKML() error = %v", err)
+			}
+
+			var doc kmlRoot
+			if err := xml.Unmarshal(body, &doc); err != nil {
+				t.Fatalf("xml.Unmarshal() error = %v", err)
+			}
+			if doc.XMLName.Space != "http://www.opengis.net/kml/2.2" {
+				t.Errorf("kml namespace = %q", doc.XMLName.Space)
+			}
+			if doc.Document.Name != "Saigon weekend" || doc.Document.Description != tt.wantDescription {
+				t.Errorf("document = %q / %q, want description %q", doc.Document.Name, doc.Document.Description, tt.wantDescription)
+			}
+
+			if len(doc.Document.Folders) != 2 {
+				t.Fatalf("got %d folders, want one per day", len(doc.Document.Folders))
+			}
+			first := doc.Document.Folders[0]
+			if first.Name != "Day 1 (2025-08-01)" || len(first.Placemarks) != 3 {
+				t.Fatalf("day 1 folder = %q with %d placemarks, want two stops and a path", first.Name, len(first.Placemarks))
+			}
+			stop := first.Placemarks[0]
+			if stop.Point == nil || stop.Point.Coordinates != "106.698000,10.772000" {
+				t.Errorf("first stop point = %+v, want long,lat", stop.Point)
+			}
+			if stop.TimeStamp == nil || stop.TimeStamp.When != "2025-08-01T02:00:00Z" {
+				t.Errorf("first stop timestamp = %+v", stop.TimeStamp)
+			}
+			path := first.Placemarks[2]
+			if path.Point != nil || path.LineString == nil || path.LineString.Coordinates != "106.698000,10.772000 106.700000,10.780000" {
+				t.Errorf("day 1 path = %+v", path.LineString)
+			}
+
+			// a single stop has no path to draw
+			second := doc.Document.Folders[1]
+			if len(second.Placemarks) != 1 || second.Placemarks[0].LineString != nil {
+				t.Errorf("day 2 folder has %d placemarks, want only the stop", len(second.Placemarks))
+			}
+		})
+	}
+}
+
+func TestEncodeGeoJSON(t *testing.T) {
+	for _, tt := range routeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := EncodeGeoJSON(testRoute(tt.unresolved))
+			if err != nil {
+				t.Fatalf("EncodeGeoJSON() error = %v", err)
+			}
+
+			var collection struct {
+				Type     string `json:"type"`
+				Name     string `json:"name"`
+				Features []struct {
+					Type     string `json:"type"`
+					Geometry struct {
+						Type        string          `json:"type"`
+						Coordinates json.RawMessage `json:"coordinates"`
+					} `json:"geometry"`
+					Properties map[string]interface{} `json:"properties"`
+				} `json:"features"`
+				UnresolvedItems []model.TripRouteUnresolvedItem `json:"unresolvedItems"`
+			}
+			if err := json.Unmarshal(body, &collection); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if collection.Type != "FeatureCollection" || collection.Name != "Saigon weekend" {
+				t.Errorf("collection = %q %q", collection.Type, collection.Name)
+			}
+
+			var geometries []string
+			for _, feature := range collection.Features {
+				if feature.Type != "Feature" {
+					t.Errorf("feature type = %q", feature.Type)
+				}
+				geometries = append(geometries, feature.Geometry.Type)
+			}
+			if got, want := strings.Join(geometries, ","), "Point,Point,LineString,Point"; got != want {
+				t.Fatalf("geometries = %s, want %s", got, want)
+			}
+
+			point := collection.Features[0]
+			if got := strings.Join(strings.Fields(string(point.Geometry.Coordinates)), ""); got != "[106.698,10.772]" {
+				t.Errorf("point coordinates = %s, want [long,lat]", got)
+			}
+			if point.Properties["placeId"] != "p-market" || point.Properties["tripDay"] != float64(1) || point.Properties["time"] != "2025-08-01T02:00:00Z" {
+				t.Errorf("point properties = %v", point.Properties)
+			}
+			line := collection.Features[2]
+			if got := strings.Join(strings.Fields(string(line.Geometry.Coordinates)), ""); got != "[[106.698,10.772],[106.7,10.78]]" {
+				t.Errorf("line coordinates = %s", got)
+			}
+			if line.Properties["date"] != "2025-08-01" {
+				t.Errorf("line properties = %v", line.Properties)
+			}
+
+			if len(collection.UnresolvedItems) != len(tt.unresolved) {
+				t.Fatalf("got %d unresolved items, want %d", len(collection.UnresolvedItems), len(tt.unresolved))
+			}
+			for i, item := range tt.unresolved {
+				if collection.UnresolvedItems[i] != item {
+					t.Errorf("unresolved item %d = %+v, want %+v", i, collection.UnresolvedItems[i], item)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package timezoneutils
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	// embed the IANA database so zones resolve on slim images without tzdata
+	_ "time/tzdata"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/utils/env"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// FallbackZone is used when neither the request nor the city tells us where the trip happens
+const FallbackZone = "Asia/Ho_Chi_Minh"
+
+// cityZones maps a normalised city name to its IANA zone
+var cityZones = map[string]string{
+	"ho chi minh":   "Asia/Ho_Chi_Minh",
+	"saigon":        "Asia/Ho_Chi_Minh",
+	"sai gon":       "Asia/Ho_Chi_Minh",
+	"ha noi":        "Asia/Ho_Chi_Minh",
+	"hanoi":         "Asia/Ho_Chi_Minh",
+	"da nang":       "Asia/Ho_Chi_Minh",
+	"hue":           "Asia/Ho_Chi_Minh",
+	"hoi an":        "Asia/Ho_Chi_Minh",
+	"nha trang":     "Asia/Ho_Chi_Minh",
+	"da lat":        "Asia/Ho_Chi_Minh",
+	"phu quoc":      "Asia/Ho_Chi_Minh",
+	"vung tau":      "Asia/Ho_Chi_Minh",
+	"can tho":       "Asia/Ho_Chi_Minh",
+	"hai phong":     "Asia/Ho_Chi_Minh",
+	"ha long":       "Asia/Ho_Chi_Minh",
+	"sa pa":         "Asia/Ho_Chi_Minh",
+	"sapa":          "Asia/Ho_Chi_Minh",
+	"quy nhon":      "Asia/Ho_Chi_Minh",
+	"bangkok":       "Asia/Bangkok",
+	"phuket":        "Asia/Bangkok",
+	"chiang mai":    "Asia/Bangkok",
+	"phnom penh":    "Asia/Phnom_Penh",
+	"siem reap":     "Asia/Phnom_Penh",
+	"vientiane":     "Asia/Vientiane",
+	"luang prabang": "Asia/Vientiane",
+	"singapore":     "Asia/Singapore",
+	"kuala lumpur":  "Asia/Kuala_Lumpur",
+	"jakarta":       "Asia/Jakarta",
+	"bali":          "Asia/Makassar",
+	"manila":        "Asia/Manila",
+	"hong kong":     "Asia/Hong_Kong",
+	"taipei":        "Asia/Taipei",
+	"shanghai":      "Asia/Shanghai",
+	"beijing":       "Asia/Shanghai",
+	"tokyo":         "Asia/Tokyo",
+	"osaka":         "Asia/Tokyo",
+	"kyoto":         "Asia/Tokyo",
+	"seoul":         "Asia/Seoul",
+	"busan":         "Asia/Seoul",
+	"delhi":         "Asia/Kolkata",
+	"new delhi":     "Asia/Kolkata",
+	"mumbai":        "Asia/Kolkata",
+	"dubai":         "Asia/Dubai",
+	"istanbul":      "Europe/Istanbul",
+	"london":        "Europe/London",
+	"paris":         "Europe/Paris",
+	"berlin":        "Europe/Berlin",
+	"rome":          "Europe/Rome",
+	"madrid":        "Europe/Madrid",
+	"barcelona":     "Europe/Madrid",
+	"amsterdam":     "Europe/Amsterdam",
+	"new york":      "America/New_York",
+	"los angeles":   "America/Los_Angeles",
+	"san francisco": "America/Los_Angeles",
+	"chicago":       "America/Chicago",
+	"toronto":       "America/Toronto",
+	"vancouver":     "America/Vancouver",
+	"sydney":        "Australia/Sydney",
+	"melbourne":     "Australia/Melbourne",
+}
+
+// ForCity guesses the zone of a city, falling back to DEFAULT_TIME_ZONE and then FallbackZone
+func ForCity(city string) string {
+	if zone, ok := cityZones[normaliseCity(city)]; ok {
+		return zone
+	}
+	if zone, err := env.GetEnv("DEFAULT_TIME_ZONE"); err == nil && IsValid(zone) {
+		return zone
+	}
+	return FallbackZone
+}
+
+// IsValid reports whether zone is a loadable IANA zone name
+func IsValid(zone string) bool {
+	if zone == "" {
+		return false
+	}
+	_, err := time.LoadLocation(zone)
+	return err == nil
+}
+
+// Location loads zone, falling back to FallbackZone for empty or unknown names
+func Location(zone string) *time.Location {
+	location, err := time.LoadLocation(zone)
+	if err != nil || zone == "" {
+		location, _ = time.LoadLocation(FallbackZone)
+	}
+	return location
+}
+
+// StartOfDay returns midnight of the calendar day the instant t falls on in location
+func StartOfDay(t time.Time, location *time.Location) time.Time {
+	local := t.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+}
+
+// TripStart returns midnight of the first trip day in location. start_date holds a calendar date rather than an
+// instant, so its year, month and day are kept as stored instead of being converted into the trip's zone
+func TripStart(startDate time.Time, location *time.Location) time.Time {
+	return time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, location)
+}
+
+// TripStartDate turns the start date a client sent into the form start_date is stored in, midnight UTC of its
+// calendar date. Midnight in the client's own offset already names the date, any other instant is read in the
+// trip's zone, so "2025-08-10T00:00:00+07:00" and "2025-08-09T17:00:00Z" both become 2025-08-10 in Vietnam
+func TripStartDate(startDate time.Time, location *time.Location) time.Time {
+	if startDate.Hour() != 0 || startDate.Minute() != 0 || startDate.Second() != 0 || startDate.Nanosecond() != 0 {
+		startDate = startDate.In(location)
+	}
+	return time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// TripDayStart returns midnight of the given 1-based trip day in the trip's zone
+func TripDayStart(startDate time.Time, zone string, tripDay int64) time.Time {
+	return TripStart(startDate, Location(zone)).AddDate(0, 0, int(tripDay-1))
+}
+
+// TripDayOf returns the 1-based trip day that t falls on in the trip's zone, which may lie outside the trip
+func TripDayOf(startDate time.Time, zone string, t time.Time) int64 {
+	day := StartOfDay(t, Location(zone))
+	// count calendar days in UTC so a DST change doesn't leave a 23 or 25 hour day
+	startUTC := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	dayUTC := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	return int64(dayUTC.Sub(startUTC).Hours()/24) + 1
+}
+
+func normaliseCity(city string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), city)
+	if err != nil {
+		folded = city
+	}
+	// đ is a letter of its own rather than d with a mark, so NFD leaves it alone
+	folded = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(folded)), "đ", "d")
+	folded = strings.TrimPrefix(folded, "thanh pho ")
+	folded = strings.TrimPrefix(folded, "tp. ")
+	folded = strings.TrimSuffix(folded, " city")
+	return strings.TrimSpace(folded)
+}
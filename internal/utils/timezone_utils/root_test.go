@@ -0,0 +1,119 @@
+package timezoneutils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForCity(t *testing.T) {
+	t.Setenv("DEFAULT_TIME_ZONE", "Europe/Paris")
+	tests := []struct {
+		city string
+		want string
+	}{
+		{"Tokyo", "Asia/Tokyo"},
+		{"  new york ", "America/New_York"},
+		{"Thành phố Hồ Chí Minh", "Asia/Ho_Chi_Minh"},
+		{"Đà Nẵng", "Asia/Ho_Chi_Minh"},
+		{"Da Lat City", "Asia/Ho_Chi_Minh"},
+		{"Atlantis", "Europe/Paris"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.city, func(t *testing.T) {
+			if got := ForCity(tt.city); got != tt.want {
+				t.Errorf("ForCity(%q) = %q, want %q", tt.city, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForCityFallback(t *testing.T) {
+	t.Setenv("DEFAULT_TIME_ZONE", "Not/AZone")
+	if got := ForCity("Atlantis"); got != FallbackZone {
+		t.Errorf("ForCity() = %q, want %q", got, FallbackZone)
+	}
+}
+
+func TestTripDayStart(t *testing.T) {
+	tests := []struct {
+		name      string
+		startDate time.Time
+		zone      string
+		tripDay   int64
+		want      string
+	}{
+		{"new york keeps the stored date", time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), "America/New_York", 1, "2025-08-01T00:00:00-04:00"},
+		{"tokyo keeps the stored date", time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), "Asia/Tokyo", 1, "2025-08-01T00:00:00+09:00"},
+		{"later day", time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), "Asia/Ho_Chi_Minh", 3, "2025-08-03T00:00:00+07:00"},
+		{"across the end of dst", time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), "America/New_York", 3, "2025-11-03T00:00:00-05:00"},
+		{"unknown zone falls back", time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), "Mars/Olympus", 1, "2025-08-01T00:00:00+07:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TripDayStart(tt.startDate, tt.zone, tt.tripDay).Format(time.RFC3339); got != tt.want {
+				t.Errorf("TripDayStart() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTripDayOf(t *testing.T) {
+	startDate := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		zone string
+		at   time.Time
+		want int64
+	}{
+		{"first morning in new york", "America/New_York", time.Date(2025, 8, 1, 13, 0, 0, 0, time.UTC), 1},
+		{"evening before in new york", "America/New_York", time.Date(2025, 8, 1, 3, 0, 0, 0, time.UTC), 0},
+		{"second day in tokyo", "Asia/Tokyo", time.Date(2025, 8, 1, 16, 0, 0, 0, time.UTC), 2},
+		{"day after a dst change", "America/New_York", time.Date(2025, 11, 3, 12, 0, 0, 0, time.UTC), 95},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TripDayOf(startDate, tt.zone, tt.at); got != tt.want {
+				t.Errorf("TripDayOf() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	location := Location("America/New_York")
+	got := StartOfDay(time.Date(2025, 8, 1, 3, 0, 0, 0, time.UTC), location).Format(time.RFC3339)
+	if want := "2025-07-31T00:00:00-04:00"; got != want {
+		t.Errorf("StartOfDay() = %s, want %s", got, want)
+	}
+}
+
+func TestTripStartDate(t *testing.T) {
+	saigon := Location("Asia/Ho_Chi_Minh")
+	newYork := Location("America/New_York")
+	want := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		startDate time.Time
+		location  *time.Location
+	}{
+		{"midnight in the client offset", time.Date(2025, 8, 10, 0, 0, 0, 0, time.FixedZone("+07", 7*3600)), saigon},
+		{"local midnight sent as UTC", time.Date(2025, 8, 9, 17, 0, 0, 0, time.UTC), saigon},
+		{"date sent as UTC midnight", time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC), saigon},
+		{"date sent as UTC midnight for a zone behind UTC", time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC), newYork},
+		{"local midnight behind UTC", time.Date(2025, 8, 10, 4, 0, 0, 0, time.UTC), newYork},
+		{"afternoon in the trip zone", time.Date(2025, 8, 10, 15, 30, 0, 0, time.FixedZone("+07", 7*3600)), saigon},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TripStartDate(tt.startDate, tt.location)
+			if !got.Equal(want) || got.Location() != time.UTC {
+				t.Errorf("TripStartDate(%v) = %v, want %v", tt.startDate, got, want)
+			}
+			// the trip then starts at local midnight of that date, not a day early
+			if start := TripStart(got, tt.location); start.Day() != 10 || start.Hour() != 0 {
+				t.Errorf("TripStart() = %v, want midnight of the 10th", start)
+			}
+		})
+	}
+}
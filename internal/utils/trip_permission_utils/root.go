@@ -0,0 +1,160 @@
+package trippermissionutils
+
+import "github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+
+// rank orders the trip roles from least to most privileged; an empty role means "not a member".
+var rank = map[string]int{
+	entity.TripRole.Viewer:        1,
+	entity.TripRole.Member:        2,
+	entity.TripRole.Editor:        3,
+	entity.TripRole.Administrator: 4,
+	entity.TripRole.Owner:         5,
+}
+
+func atLeast(role string, minimum string) bool {
+	return rank[role] >= rank[minimum]
+}
+
+// ownOrAdmin lets admins act on anything, and others on what is their own as long as they still hold minimum.
+func ownOrAdmin(role string, own bool, minimum string) bool {
+	return IsAdmin(role) || (own && atLeast(role, minimum))
+}
+
+// IsValidRole reports whether role is one of the known trip roles.
+func IsValidRole(role string) bool {
+	_, ok := rank[role]
+	return ok
+}
+
+// IsMember reports whether role belongs to a member of the trip, whatever their role.
+func IsMember(role string) bool {
+	return atLeast(role, entity.TripRole.Viewer)
+}
+
+// IsAdmin reports whether role administers the trip. The owner is always an admin.
+func IsAdmin(role string) bool {
+	return atLeast(role, entity.TripRole.Administrator)
+}
+
+// CanEditTrip reports whether role may change the trip's own fields (title, dates, budget...).
+func CanEditTrip(role string) bool {
+	return atLeast(role, entity.TripRole.Administrator)
+}
+
+// CanDeleteTrip reports whether role may delete the whole trip.
+func CanDeleteTrip(role string) bool {
+	return atLeast(role, entity.TripRole.Owner)
+}
+
+// CanRestoreTrip reports whether role may bring a deleted trip back from the trash.
+func CanRestoreTrip(role string) bool {
+	return atLeast(role, entity.TripRole.Administrator)
+}
+
+// CanEditItems reports whether role may add, move, edit or remove itinerary items.
+func CanEditItems(role string) bool {
+	return atLeast(role, entity.TripRole.Editor)
+}
+
+// CanInvite reports whether role may invite friends to the trip.
+func CanInvite(role string) bool {
+	return atLeast(role, entity.TripRole.Member)
+}
+
+// CanUploadPhotos reports whether role may add photos to the trip.
+func CanUploadPhotos(role string) bool {
+	return atLeast(role, entity.TripRole.Member)
+}
+
+// CanDeletePhotos reports whether role may delete any photo of the trip.
+func CanDeletePhotos(role string) bool {
+	return atLeast(role, entity.TripRole.Administrator)
+}
+
+// CanAddBookings reports whether role may add bookings to the trip.
+func CanAddBookings(role string) bool {
+	return atLeast(role, entity.TripRole.Member)
+}
+
+// CanEditBooking reports whether role may change or remove a booking, own telling whether the booking was added by them.
+func CanEditBooking(role string, own bool) bool {
+	return ownOrAdmin(role, own, entity.TripRole.Member)
+}
+
+// CanRecordExpenses reports whether role may record expenses.
+func CanRecordExpenses(role string) bool {
+	return atLeast(role, entity.TripRole.Member)
+}
+
+// CanDeleteExpense reports whether role may remove an expense, own telling whether they recorded or paid it.
+func CanDeleteExpense(role string, own bool) bool {
+	return ownOrAdmin(role, own, entity.TripRole.Member)
+}
+
+// CanRecordSettlement reports whether role may record a payment between members, own telling whether they are one of the two sides.
+func CanRecordSettlement(role string, own bool) bool {
+	return ownOrAdmin(role, own, entity.TripRole.Member)
+}
+
+// CanEditChecklists reports whether role may create checklists and add, edit or tick off their items.
+func CanEditChecklists(role string) bool {
+	return atLeast(role, entity.TripRole.Member)
+}
+
+// CanDeleteChecklistEntry reports whether role may remove a checklist or one of its items, own telling whether they created it.
+func CanDeleteChecklistEntry(role string, own bool) bool {
+	return ownOrAdmin(role, own, entity.TripRole.Member)
+}
+
+// CanComment reports whether role may write comments, and edit the ones they wrote.
+func CanComment(role string) bool {
+	return atLeast(role, entity.TripRole.Member)
+}
+
+// CanDeleteComment reports whether role may remove a comment, own telling whether they wrote it.
+func CanDeleteComment(role string, own bool) bool {
+	return ownOrAdmin(role, own, entity.TripRole.Member)
+}
+
+// CanUploadDocuments reports whether role may add documents to the trip.
+func CanUploadDocuments(role string) bool {
+	return atLeast(role, entity.TripRole.Member)
+}
+
+// CanDeleteDocument reports whether role may remove a document, own telling whether they uploaded it.
+func CanDeleteDocument(role string, own bool) bool {
+	return ownOrAdmin(role, own, entity.TripRole.Member)
+}
+
+// CanCreatePolls reports whether role may open polls.
+func CanCreatePolls(role string) bool {
+	return atLeast(role, entity.TripRole.Member)
+}
+
+// CanVote reports whether role may vote in polls.
+func CanVote(role string) bool {
+	return atLeast(role, entity.TripRole.Member)
+}
+
+// CanManagePoll reports whether role may close or delete a poll, own telling whether they opened it.
+func CanManagePoll(role string, own bool) bool {
+	return ownOrAdmin(role, own, entity.TripRole.Member)
+}
+
+// CanRemoveMember reports whether a member with role actorRole may remove one with targetRole.
+// Admins can only remove members ranked below them, so the owner can never be removed.
+func CanRemoveMember(actorRole string, targetRole string) bool {
+	return IsAdmin(actorRole) && IsMember(targetRole) && rank[actorRole] > rank[targetRole]
+}
+
+// CanChangeRole reports whether a member with role actorRole may move one with targetRole to newRole.
+// Ownership is never granted this way, and admins can neither touch their peers nor promote anyone to their own rank.
+func CanChangeRole(actorRole string, targetRole string, newRole string) bool {
+	if newRole == entity.TripRole.Owner || !IsValidRole(newRole) {
+		return false
+	}
+	if !CanRemoveMember(actorRole, targetRole) {
+		return false
+	}
+	return actorRole == entity.TripRole.Owner || rank[actorRole] > rank[newRole]
+}
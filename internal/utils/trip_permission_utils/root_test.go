@@ -0,0 +1,133 @@
+package trippermissionutils
+
+import (
+	"testing"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/entity"
+)
+
+func TestRolePolicies(t *testing.T) {
+	viewer := entity.TripRole.Viewer
+	member := entity.TripRole.Member
+	editor := entity.TripRole.Editor
+	admin := entity.TripRole.Administrator
+	owner := entity.TripRole.Owner
+
+	tests := []struct {
+		name    string
+		policy  func(role string) bool
+		allowed []string
+		denied  []string
+	}{
+		{"IsMember", IsMember, []string{viewer, member, editor, admin, owner}, []string{""}},
+		{"IsAdmin", IsAdmin, []string{admin, owner}, []string{"", viewer, member, editor}},
+		{"CanEditTrip", CanEditTrip, []string{admin, owner}, []string{"", viewer, member, editor}},
+		{"CanDeleteTrip", CanDeleteTrip, []string{owner}, []string{"", viewer, member, editor, admin}},
+		{"CanEditItems", CanEditItems, []string{editor, admin, owner}, []string{"", viewer, member}},
+		{"CanInvite", CanInvite, []string{member, editor, admin, owner}, []string{"", viewer}},
+		{"CanUploadPhotos", CanUploadPhotos, []string{member, owner}, []string{"", viewer}},
+		{"CanAddBookings", CanAddBookings, []string{member, editor, admin, owner}, []string{"", viewer}},
+		{"CanRecordExpenses", CanRecordExpenses, []string{member, owner}, []string{"", viewer}},
+		{"CanEditChecklists", CanEditChecklists, []string{member, owner}, []string{"", viewer}},
+		{"CanComment", CanComment, []string{member, owner}, []string{"", viewer}},
+		{"CanUploadDocuments", CanUploadDocuments, []string{member, owner}, []string{"", viewer}},
+		{"CanCreatePolls", CanCreatePolls, []string{member, owner}, []string{"", viewer}},
+		{"CanVote", CanVote, []string{member, owner}, []string{"", viewer}},
+		{"unknown role", IsMember, nil, []string{"guest"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, role := range tt.allowed {
+				if !tt.policy(role) {
+					t.Errorf("%s(%q) = false, want true", tt.name, role)
+				}
+			}
+			for _, role := range tt.denied {
+				if tt.policy(role) {
+					t.Errorf("%s(%q) = true, want false", tt.name, role)
+				}
+			}
+		})
+	}
+}
+
+func TestOwnershipPolicies(t *testing.T) {
+	policies := map[string]func(role string, own bool) bool{
+		"CanEditBooking":          CanEditBooking,
+		"CanDeleteExpense":        CanDeleteExpense,
+		"CanRecordSettlement":     CanRecordSettlement,
+		"CanDeleteChecklistEntry": CanDeleteChecklistEntry,
+		"CanDeleteComment":        CanDeleteComment,
+		"CanDeleteDocument":       CanDeleteDocument,
+		"CanManagePoll":           CanManagePoll,
+	}
+	tests := []struct {
+		name string
+		role string
+		own  bool
+		want bool
+	}{
+		{"member on their own", entity.TripRole.Member, true, true},
+		{"member on someone else's", entity.TripRole.Member, false, false},
+		{"editor on someone else's", entity.TripRole.Editor, false, false},
+		{"admin on someone else's", entity.TripRole.Administrator, false, true},
+		{"owner on someone else's", entity.TripRole.Owner, false, true},
+		{"viewer on their own after a demotion", entity.TripRole.Viewer, true, false},
+		{"former member on their own", "", true, false},
+	}
+	for policyName, policy := range policies {
+		for _, tt := range tests {
+			t.Run(policyName+"/"+tt.name, func(t *testing.T) {
+				if got := policy(tt.role, tt.own); got != tt.want {
+					t.Errorf("%s(%q, %v) = %v, want %v", policyName, tt.role, tt.own, got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestCanRemoveMember(t *testing.T) {
+	tests := []struct {
+		name   string
+		actor  string
+		target string
+		want   bool
+	}{
+		{"owner removes admin", entity.TripRole.Owner, entity.TripRole.Administrator, true},
+		{"admin removes member", entity.TripRole.Administrator, entity.TripRole.Member, true},
+		{"admin cannot remove admin", entity.TripRole.Administrator, entity.TripRole.Administrator, false},
+		{"admin cannot remove owner", entity.TripRole.Administrator, entity.TripRole.Owner, false},
+		{"editor cannot remove viewer", entity.TripRole.Editor, entity.TripRole.Viewer, false},
+		{"target not in trip", entity.TripRole.Owner, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanRemoveMember(tt.actor, tt.target); got != tt.want {
+				t.Errorf("CanRemoveMember(%q, %q) = %v, want %v", tt.actor, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanChangeRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   string
+		target  string
+		newRole string
+		want    bool
+	}{
+		{"owner promotes member to admin", entity.TripRole.Owner, entity.TripRole.Member, entity.TripRole.Administrator, true},
+		{"admin promotes viewer to editor", entity.TripRole.Administrator, entity.TripRole.Viewer, entity.TripRole.Editor, true},
+		{"admin cannot promote to admin", entity.TripRole.Administrator, entity.TripRole.Member, entity.TripRole.Administrator, false},
+		{"ownership is never granted", entity.TripRole.Owner, entity.TripRole.Administrator, entity.TripRole.Owner, false},
+		{"unknown role", entity.TripRole.Owner, entity.TripRole.Member, "guest", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanChangeRole(tt.actor, tt.target, tt.newRole); got != tt.want {
+				t.Errorf("CanChangeRole(%q, %q, %q) = %v, want %v", tt.actor, tt.target, tt.newRole, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package tripstatusutils
+
+import "github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+
+// transitions lists, for every trip status, the statuses a trip may move to from it.
+// Leaving the archive is only allowed back to the status the trip was archived from.
+var transitions = map[string][]string{
+	model.TripStatus.Draft:        {model.TripStatus.AIGenerating, model.TripStatus.NotStarted, model.TripStatus.Cancelled},
+	model.TripStatus.AIGenerating: {model.TripStatus.NotStarted, model.TripStatus.Failed},
+	model.TripStatus.Failed:       {model.TripStatus.AIGenerating, model.TripStatus.Draft, model.TripStatus.Archived},
+	model.TripStatus.NotStarted:   {model.TripStatus.Draft, model.TripStatus.InProgress, model.TripStatus.Cancelled},
+	model.TripStatus.InProgress:   {model.TripStatus.Completed, model.TripStatus.Cancelled},
+	model.TripStatus.Completed:    {model.TripStatus.Archived},
+	model.TripStatus.Cancelled:    {model.TripStatus.Archived},
+	model.TripStatus.Archived:     {model.TripStatus.Failed, model.TripStatus.Completed, model.TripStatus.Cancelled},
+}
+
+// IsValid reports whether status is a known trip status.
+func IsValid(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// IsInitial reports whether a new trip may be created with status.
+func IsInitial(status string) bool {
+	return status == model.TripStatus.Draft || status == model.TripStatus.AIGenerating || status == model.TripStatus.NotStarted
+}
+
+// CanTransition reports whether a trip may move from one status to the other.
+func CanTransition(from string, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsDedicatedAction reports whether the transition notifies the members and so must go through its own endpoint
+// (cancel, archive or unarchive) rather than a plain trip update.
+func NeedsDedicatedAction(from string, to string) bool {
+	return to == model.TripStatus.Cancelled || to == model.TripStatus.Archived || from == model.TripStatus.Archived
+}
@@ -0,0 +1,78 @@
+package tripstatusutils
+
+import (
+	"testing"
+
+	"github.com/swefinal-travel-planner/travel-app-be/internal/domain/model"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want bool
+	}{
+		{"generation succeeds", model.TripStatus.AIGenerating, model.TripStatus.NotStarted, true},
+		{"generation fails", model.TripStatus.AIGenerating, model.TripStatus.Failed, true},
+		{"failed trip is regenerated", model.TripStatus.Failed, model.TripStatus.AIGenerating, true},
+		{"trip starts", model.TripStatus.NotStarted, model.TripStatus.InProgress, true},
+		{"trip ends", model.TripStatus.InProgress, model.TripStatus.Completed, true},
+		{"completed trip is archived", model.TripStatus.Completed, model.TripStatus.Archived, true},
+		{"generating trip cannot start", model.TripStatus.AIGenerating, model.TripStatus.InProgress, false},
+		{"completed trip cannot restart", model.TripStatus.Completed, model.TripStatus.InProgress, false},
+		{"cancelled trip cannot resume", model.TripStatus.Cancelled, model.TripStatus.NotStarted, false},
+		{"draft cannot be archived", model.TripStatus.Draft, model.TripStatus.Archived, false},
+		{"unknown status", "cancel", model.TripStatus.NotStarted, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsInitial(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{model.TripStatus.Draft, true},
+		{model.TripStatus.AIGenerating, true},
+		{model.TripStatus.NotStarted, true},
+		{model.TripStatus.InProgress, false},
+		{model.TripStatus.Failed, false},
+		{model.TripStatus.Archived, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := IsInitial(tt.status); got != tt.want {
+				t.Errorf("IsInitial(%q) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsDedicatedAction(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want bool
+	}{
+		{"cancel", model.TripStatus.NotStarted, model.TripStatus.Cancelled, true},
+		{"archive", model.TripStatus.Completed, model.TripStatus.Archived, true},
+		{"unarchive", model.TripStatus.Archived, model.TripStatus.Completed, true},
+		{"back to draft", model.TripStatus.NotStarted, model.TripStatus.Draft, false},
+		{"start", model.TripStatus.NotStarted, model.TripStatus.InProgress, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NeedsDedicatedAction(tt.from, tt.to); got != tt.want {
+				t.Errorf("NeedsDedicatedAction(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
@@ -37,6 +37,17 @@ var handlerSet = wire.NewSet(
 	v1.NewInvitationTripHandler,
 	v1.NewTripMemberHandler,
 	v1.NewTripImageHandler,
+	v1.NewTripExpenseHandler,
+	v1.NewExchangeRateHandler,
+	v1.NewTripBookingHandler,
+	v1.NewTripDocumentHandler,
+	v1.NewTripChecklistHandler,
+	v1.NewTripCommentHandler,
+	v1.NewTripPollHandler,
+	v1.NewTripRealtimeHandler,
+	v1.NewTripActivityHandler,
+	v1.NewSyncHandler,
+	v1.NewTripBundleHandler,
 )
 
 var cronjobSet = wire.NewSet(
@@ -54,6 +65,17 @@ var serviceSet = wire.NewSet(
 	serviceimplement.NewInvitationTripService,
 	serviceimplement.NewTripMemberService,
 	serviceimplement.NewTripImageService,
+	serviceimplement.NewTripExpenseService,
+	serviceimplement.NewExchangeRateService,
+	serviceimplement.NewTripBookingService,
+	serviceimplement.NewTripDocumentService,
+	serviceimplement.NewTripChecklistService,
+	serviceimplement.NewTripCommentService,
+	serviceimplement.NewTripPollService,
+	serviceimplement.NewTripRealtimeService,
+	serviceimplement.NewTripActivityService,
+	serviceimplement.NewSyncService,
+	serviceimplement.NewTripBundleService,
 )
 
 var repositorySet = wire.NewSet(
@@ -65,10 +87,22 @@ var repositorySet = wire.NewSet(
 	repositoryimplement.NewTripRepository,
 	repositoryimplement.NewTripItemRepository,
 	repositoryimplement.NewTripMemberRepository,
+	repositoryimplement.NewTripSegmentRepository,
 	repositoryimplement.NewUnitOfWork,
 	repositoryimplement.NewNotificationRepository,
 	repositoryimplement.NewInvitationTripRepository,
 	repositoryimplement.NewTripImageRepository,
+	repositoryimplement.NewTripExpenseRepository,
+	repositoryimplement.NewTripSettlementRepository,
+	repositoryimplement.NewExchangeRateRepository,
+	repositoryimplement.NewTripBookingRepository,
+	repositoryimplement.NewTripDocumentRepository,
+	repositoryimplement.NewTripChecklistRepository,
+	repositoryimplement.NewTripCommentRepository,
+	repositoryimplement.NewTripPollRepository,
+	repositoryimplement.NewTripActivityRepository,
+	repositoryimplement.NewSyncTombstoneRepository,
+	repositoryimplement.NewTripReminderRepository,
 )
 
 var middlewareSet = wire.NewSet(
@@ -79,6 +113,8 @@ var beanSet = wire.NewSet(
 	beanimplement.NewBcryptPasswordEncoder,
 	beanimplement.NewRedisService,
 	beanimplement.NewMailClient,
+	beanimplement.NewHaversineRoutingProvider,
+	beanimplement.NewS3Service,
 )
 
 func InitializeContainer(
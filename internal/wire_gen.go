@@ -33,7 +33,8 @@ func InitializeContainer(db database.Db) *controller.ApiContainer {
 	friendRepository := repositoryimplement.NewFriendRepository(db)
 	invitationCooldownRepository := repositoryimplement.NewInvitationCooldownRepository(db)
 	notificationRepository := repositoryimplement.NewNotificationRepository(db)
-	notificationService := serviceimplement.NewExpoNotificationService(notificationRepository, userRepository)
+	syncTombstoneRepository := repositoryimplement.NewSyncTombstoneRepository(db)
+	notificationService := serviceimplement.NewExpoNotificationService(notificationRepository, userRepository, syncTombstoneRepository)
 	invitationFriendService := serviceimplement.NewInvitationFriendService(invitationFriendRepository, userRepository, friendRepository, invitationCooldownRepository, notificationService)
 	invitationFriendHandler := v1.NewInvitationFriendHandler(invitationFriendService)
 	friendService := serviceimplement.NewFriendService(friendRepository, userRepository)
@@ -46,20 +47,55 @@ func InitializeContainer(db database.Db) *controller.ApiContainer {
 	tripRepository := repositoryimplement.NewTripRepository(db)
 	unitOfWork := repositoryimplement.NewUnitOfWork(db)
 	tripMemberRepository := repositoryimplement.NewTripMemberRepository(db)
+	tripSegmentRepository := repositoryimplement.NewTripSegmentRepository(db)
 	tripItemRepository := repositoryimplement.NewTripItemRepository(db)
-	tripItemService := serviceimplement.NewTripItemService(tripItemRepository, tripRepository, tripMemberRepository, unitOfWork)
-	tripService := serviceimplement.NewTripService(tripRepository, unitOfWork, tripMemberRepository, tripItemService, notificationService)
+	exchangeRateRepository := repositoryimplement.NewExchangeRateRepository(db)
+	routingProvider := beanimplement.NewHaversineRoutingProvider()
+	tripRealtimeService := serviceimplement.NewTripRealtimeService(tripRepository, tripMemberRepository, redisClient)
+	tripActivityRepository := repositoryimplement.NewTripActivityRepository(db)
+	tripItemService := serviceimplement.NewTripItemService(tripItemRepository, tripRepository, tripMemberRepository, exchangeRateRepository, unitOfWork, routingProvider, tripRealtimeService, tripActivityRepository, syncTombstoneRepository)
+	tripBookingRepository := repositoryimplement.NewTripBookingRepository(db)
+	tripImageRepository := repositoryimplement.NewTripImageRepository(db)
+	tripDocumentRepository := repositoryimplement.NewTripDocumentRepository(db)
+	s3Service := beanimplement.NewS3Service()
+	tripReminderRepository := repositoryimplement.NewTripReminderRepository(db)
+	tripService := serviceimplement.NewTripService(tripRepository, unitOfWork, tripMemberRepository, tripSegmentRepository, tripItemRepository, tripItemService, notificationService, tripBookingRepository, tripRealtimeService, tripActivityRepository, tripImageRepository, tripDocumentRepository, s3Service, syncTombstoneRepository, tripReminderRepository, exchangeRateRepository)
 	tripHandler := v1.NewTripHandler(tripService, tripItemService, notificationService)
 	invitationTripRepository := repositoryimplement.NewInvitationTripRepository(db)
-	invitationTripService := serviceimplement.NewInvitationTripService(invitationTripRepository, tripRepository, tripMemberRepository, unitOfWork, notificationService)
+	invitationTripService := serviceimplement.NewInvitationTripService(invitationTripRepository, tripRepository, tripMemberRepository, unitOfWork, notificationService, tripRealtimeService, tripActivityRepository, syncTombstoneRepository)
 	invitationTripHandler := v1.NewInvitationTripHandler(invitationTripService)
-	tripMemberService := serviceimplement.NewTripMemberService(tripMemberRepository)
+	tripMemberService := serviceimplement.NewTripMemberService(tripMemberRepository, unitOfWork, notificationService, tripRealtimeService, tripActivityRepository, syncTombstoneRepository, tripRepository)
 	tripMemberHandler := v1.NewTripMemberHandler(tripMemberService)
-	tripImageRepository := repositoryimplement.NewTripImageRepository(db)
-	tripImageService := serviceimplement.NewTripImageService(tripImageRepository, tripRepository, tripMemberRepository, tripItemRepository, unitOfWork)
+	tripImageService := serviceimplement.NewTripImageService(tripImageRepository, tripRepository, tripMemberRepository, tripItemRepository, unitOfWork, tripActivityRepository, syncTombstoneRepository)
 	tripImageHandler := v1.NewTripImageHandler(tripImageService)
-	server := http.NewServer(authHandler, invitationFriendHandler, friendHandler, userHandler, authMiddleware, healthHandler, notificationHandler, tripHandler, invitationTripHandler, tripMemberHandler, tripImageHandler)
-	cronJobRegister := cronjob.NewCronJobRegister(tripService)
+	tripExpenseRepository := repositoryimplement.NewTripExpenseRepository(db)
+	tripSettlementRepository := repositoryimplement.NewTripSettlementRepository(db)
+	tripExpenseService := serviceimplement.NewTripExpenseService(tripExpenseRepository, tripSettlementRepository, tripRepository, tripMemberRepository, tripItemRepository, userRepository, exchangeRateRepository, unitOfWork)
+	tripExpenseHandler := v1.NewTripExpenseHandler(tripExpenseService)
+	exchangeRateService := serviceimplement.NewExchangeRateService(exchangeRateRepository, unitOfWork)
+	exchangeRateHandler := v1.NewExchangeRateHandler(exchangeRateService)
+	tripBookingService := serviceimplement.NewTripBookingService(tripBookingRepository, tripRepository, tripMemberRepository, tripItemService)
+	tripBookingHandler := v1.NewTripBookingHandler(tripBookingService)
+	tripDocumentService := serviceimplement.NewTripDocumentService(tripDocumentRepository, tripRepository, tripMemberRepository, tripItemRepository, tripBookingRepository, s3Service)
+	tripDocumentHandler := v1.NewTripDocumentHandler(tripDocumentService)
+	tripChecklistRepository := repositoryimplement.NewTripChecklistRepository(db)
+	tripChecklistService := serviceimplement.NewTripChecklistService(tripChecklistRepository, tripRepository, tripMemberRepository, unitOfWork, notificationService, tripReminderRepository)
+	tripChecklistHandler := v1.NewTripChecklistHandler(tripChecklistService)
+	tripCommentRepository := repositoryimplement.NewTripCommentRepository(db)
+	tripCommentService := serviceimplement.NewTripCommentService(tripCommentRepository, tripRepository, tripMemberRepository, tripItemRepository, userRepository, notificationService)
+	tripCommentHandler := v1.NewTripCommentHandler(tripCommentService)
+	tripPollRepository := repositoryimplement.NewTripPollRepository(db)
+	tripPollService := serviceimplement.NewTripPollService(tripPollRepository, tripRepository, tripMemberRepository, tripItemRepository, unitOfWork, notificationService, tripRealtimeService, tripActivityRepository)
+	tripPollHandler := v1.NewTripPollHandler(tripPollService)
+	tripRealtimeHandler := v1.NewTripRealtimeHandler(tripRealtimeService)
+	tripActivityService := serviceimplement.NewTripActivityService(tripActivityRepository, tripRepository, tripMemberRepository)
+	tripActivityHandler := v1.NewTripActivityHandler(tripActivityService)
+	syncService := serviceimplement.NewSyncService(tripRepository, tripItemRepository, tripMemberRepository, tripImageRepository, invitationTripRepository, notificationRepository, syncTombstoneRepository, tripService, tripItemService, tripImageService, invitationTripService, notificationService)
+	syncHandler := v1.NewSyncHandler(syncService)
+	tripBundleService := serviceimplement.NewTripBundleService(tripService, tripItemService, tripMemberService, tripDocumentRepository, tripImageRepository, s3Service)
+	tripBundleHandler := v1.NewTripBundleHandler(tripBundleService)
+	server := http.NewServer(authHandler, invitationFriendHandler, friendHandler, userHandler, authMiddleware, healthHandler, notificationHandler, tripHandler, invitationTripHandler, tripMemberHandler, tripImageHandler, tripExpenseHandler, exchangeRateHandler, tripBookingHandler, tripDocumentHandler, tripChecklistHandler, tripCommentHandler, tripPollHandler, tripRealtimeHandler, tripActivityHandler, syncHandler, tripBundleHandler)
+	cronJobRegister := cronjob.NewCronJobRegister(tripService, tripChecklistService, tripPollService, syncService)
 	apiContainer := controller.NewApiContainer(server, cronJobRegister)
 	return apiContainer
 }
@@ -72,14 +108,14 @@ var container = wire.NewSet(controller.NewApiContainer)
 var serverSet = wire.NewSet(http.NewServer)
 
 // handler === controller | with service and repository layers to form 3 layers architecture
-var handlerSet = wire.NewSet(v1.NewAuthHandler, v1.NewInvitationFriendHandler, v1.NewFriendHandler, v1.NewUserHandler, v1.NewHealthHandler, v1.NewNotificationHandler, v1.NewTripHandler, v1.NewInvitationTripHandler, v1.NewTripMemberHandler, v1.NewTripImageHandler)
+var handlerSet = wire.NewSet(v1.NewAuthHandler, v1.NewInvitationFriendHandler, v1.NewFriendHandler, v1.NewUserHandler, v1.NewHealthHandler, v1.NewNotificationHandler, v1.NewTripHandler, v1.NewInvitationTripHandler, v1.NewTripMemberHandler, v1.NewTripImageHandler, v1.NewTripExpenseHandler, v1.NewExchangeRateHandler, v1.NewTripBookingHandler, v1.NewTripDocumentHandler, v1.NewTripChecklistHandler, v1.NewTripCommentHandler, v1.NewTripPollHandler, v1.NewTripRealtimeHandler, v1.NewTripActivityHandler, v1.NewSyncHandler, v1.NewTripBundleHandler)
 
 var cronjobSet = wire.NewSet(cronjob.NewCronJobRegister)
 
-var serviceSet = wire.NewSet(serviceimplement.NewAuthService, serviceimplement.NewInvitationFriendService, serviceimplement.NewFriendService, serviceimplement.NewUserService, serviceimplement.NewExpoNotificationService, serviceimplement.NewTripService, serviceimplement.NewTripItemService, serviceimplement.NewInvitationTripService, serviceimplement.NewTripMemberService, serviceimplement.NewTripImageService)
+var serviceSet = wire.NewSet(serviceimplement.NewAuthService, serviceimplement.NewInvitationFriendService, serviceimplement.NewFriendService, serviceimplement.NewUserService, serviceimplement.NewExpoNotificationService, serviceimplement.NewTripService, serviceimplement.NewTripItemService, serviceimplement.NewInvitationTripService, serviceimplement.NewTripMemberService, serviceimplement.NewTripImageService, serviceimplement.NewTripExpenseService, serviceimplement.NewExchangeRateService, serviceimplement.NewTripBookingService, serviceimplement.NewTripDocumentService, serviceimplement.NewTripChecklistService, serviceimplement.NewTripCommentService, serviceimplement.NewTripPollService, serviceimplement.NewTripRealtimeService, serviceimplement.NewTripActivityService, serviceimplement.NewSyncService, serviceimplement.NewTripBundleService)
 
-var repositorySet = wire.NewSet(repositoryimplement.NewUserRepository, repositoryimplement.NewAuthenticationRepository, repositoryimplement.NewInvitationFriendRepository, repositoryimplement.NewFriendRepository, repositoryimplement.NewInvitationCooldownRepository, repositoryimplement.NewTripRepository, repositoryimplement.NewTripItemRepository, repositoryimplement.NewTripMemberRepository, repositoryimplement.NewUnitOfWork, repositoryimplement.NewNotificationRepository, repositoryimplement.NewInvitationTripRepository, repositoryimplement.NewTripImageRepository)
+var repositorySet = wire.NewSet(repositoryimplement.NewUserRepository, repositoryimplement.NewAuthenticationRepository, repositoryimplement.NewInvitationFriendRepository, repositoryimplement.NewFriendRepository, repositoryimplement.NewInvitationCooldownRepository, repositoryimplement.NewTripRepository, repositoryimplement.NewTripItemRepository, repositoryimplement.NewTripMemberRepository, repositoryimplement.NewTripSegmentRepository, repositoryimplement.NewUnitOfWork, repositoryimplement.NewNotificationRepository, repositoryimplement.NewInvitationTripRepository, repositoryimplement.NewTripImageRepository, repositoryimplement.NewTripExpenseRepository, repositoryimplement.NewTripSettlementRepository, repositoryimplement.NewExchangeRateRepository, repositoryimplement.NewTripBookingRepository, repositoryimplement.NewTripDocumentRepository, repositoryimplement.NewTripChecklistRepository, repositoryimplement.NewTripCommentRepository, repositoryimplement.NewTripPollRepository, repositoryimplement.NewTripActivityRepository, repositoryimplement.NewSyncTombstoneRepository, repositoryimplement.NewTripReminderRepository)
 
 var middlewareSet = wire.NewSet(middleware.NewAuthMiddleware)
 
-var beanSet = wire.NewSet(beanimplement.NewBcryptPasswordEncoder, beanimplement.NewRedisService, beanimplement.NewMailClient)
+var beanSet = wire.NewSet(beanimplement.NewBcryptPasswordEncoder, beanimplement.NewRedisService, beanimplement.NewMailClient, beanimplement.NewHaversineRoutingProvider, beanimplement.NewS3Service)
@@ -0,0 +1 @@
+ALTER TABLE trip_items DROP COLUMN note;
@@ -0,0 +1 @@
+ALTER TABLE trip_items ADD COLUMN note TEXT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE trip_items
+    DROP COLUMN start_time,
+    DROP COLUMN end_time;
@@ -0,0 +1,3 @@
+ALTER TABLE trip_items
+    ADD COLUMN start_time TIME NULL,
+    ADD COLUMN end_time TIME NULL;
@@ -0,0 +1 @@
+ALTER TABLE trips ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'Asia/Ho_Chi_Minh' AFTER start_date;
@@ -0,0 +1,17 @@
+CREATE TABLE trip_segments (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   trip_id INT NOT NULL,
+   segment_order INT NOT NULL,
+   city VARCHAR(255) NOT NULL,
+   start_day INT NOT NULL,
+   days INT NOT NULL,
+   vi_location_attributes JSON,
+   vi_food_attributes JSON,
+   en_location_attributes JSON,
+   en_food_attributes JSON,
+   reference_id VARCHAR(255) NULL,
+   CONSTRAINT fk_trip_trip_segment FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+   CONSTRAINT uq_trip_segment_order UNIQUE (trip_id, segment_order),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
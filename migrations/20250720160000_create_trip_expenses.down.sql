@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS trip_settlements;
+DROP TABLE IF EXISTS trip_expense_splits;
+DROP TABLE IF EXISTS trip_expenses;
@@ -0,0 +1,39 @@
+CREATE TABLE trip_expenses (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   trip_id INT NOT NULL,
+   trip_item_id INT NULL,
+   payer_id INT NOT NULL,
+   created_by INT NOT NULL,
+   description VARCHAR(255) NOT NULL,
+   amount DECIMAL(14, 2) NOT NULL,
+   currency CHAR(3) NOT NULL,
+   split_type ENUM('equal', 'shares', 'exact') NOT NULL,
+   CONSTRAINT fk_trip_trip_expense FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+   CONSTRAINT fk_trip_item_trip_expense FOREIGN KEY (trip_item_id) REFERENCES trip_items(id) ON DELETE SET NULL,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+   deleted_at TIMESTAMP NULL DEFAULT NULL
+);
+
+CREATE TABLE trip_expense_splits (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   expense_id INT NOT NULL,
+   user_id INT NOT NULL,
+   share DECIMAL(14, 4) NOT NULL,
+   amount DECIMAL(14, 2) NOT NULL,
+   CONSTRAINT fk_trip_expense_split FOREIGN KEY (expense_id) REFERENCES trip_expenses(id) ON DELETE CASCADE,
+   CONSTRAINT uq_trip_expense_split_user UNIQUE (expense_id, user_id)
+);
+
+CREATE TABLE trip_settlements (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   trip_id INT NOT NULL,
+   from_user_id INT NOT NULL,
+   to_user_id INT NOT NULL,
+   created_by INT NOT NULL,
+   amount DECIMAL(14, 2) NOT NULL,
+   currency CHAR(3) NOT NULL,
+   CONSTRAINT fk_trip_trip_settlement FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
@@ -0,0 +1,10 @@
+ALTER TABLE trip_expenses
+   DROP COLUMN base_amount,
+   DROP COLUMN exchange_rate,
+   DROP COLUMN base_currency,
+   DROP COLUMN trip_day,
+   DROP COLUMN category;
+
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE trips DROP COLUMN budget_currency;
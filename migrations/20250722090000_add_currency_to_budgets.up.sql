@@ -0,0 +1,17 @@
+ALTER TABLE trips ADD COLUMN budget_currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER budget;
+
+CREATE TABLE exchange_rates (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   currency CHAR(3) NOT NULL,
+   units_per_usd DECIMAL(24, 10) NOT NULL,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+   CONSTRAINT uq_exchange_rate_currency UNIQUE (currency)
+);
+
+ALTER TABLE trip_expenses
+   ADD COLUMN category ENUM('food', 'tickets', 'transport', 'lodging', 'shopping', 'other') NOT NULL DEFAULT 'other' AFTER split_type,
+   ADD COLUMN trip_day INT NULL AFTER category,
+   ADD COLUMN base_currency CHAR(3) NULL AFTER trip_day,
+   ADD COLUMN exchange_rate DECIMAL(24, 10) NULL AFTER base_currency,
+   ADD COLUMN base_amount DECIMAL(14, 2) NULL AFTER exchange_rate;
@@ -0,0 +1,3 @@
+ALTER TABLE trip_items
+    DROP COLUMN cost_category,
+    DROP COLUMN estimated_cost;
@@ -0,0 +1,3 @@
+ALTER TABLE trip_items
+    ADD COLUMN estimated_cost DECIMAL(14, 2) NULL,
+    ADD COLUMN cost_category ENUM('food', 'tickets', 'transport', 'lodging', 'shopping', 'other') NULL;
@@ -0,0 +1,22 @@
+CREATE TABLE trip_bookings (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   trip_id INT NOT NULL,
+   created_by INT NOT NULL,
+   type ENUM('hotel', 'flight', 'train', 'car_rental') NOT NULL,
+   title VARCHAR(255) NOT NULL,
+   provider VARCHAR(255) NULL,
+   confirmation_number VARCHAR(64) NULL,
+   address VARCHAR(512) NULL,
+   lat DOUBLE NULL,
+   `long` DOUBLE NULL,
+   from_location VARCHAR(255) NULL,
+   to_location VARCHAR(255) NULL,
+   start_at TIMESTAMP NOT NULL,
+   end_at TIMESTAMP NULL,
+   note TEXT NULL,
+   attachments JSON NOT NULL,
+   CONSTRAINT fk_trip_trip_booking FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+   deleted_at TIMESTAMP NULL DEFAULT NULL
+);
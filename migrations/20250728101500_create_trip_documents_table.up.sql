@@ -0,0 +1,18 @@
+CREATE TABLE trip_documents (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   trip_id INT NOT NULL,
+   uploaded_by INT NOT NULL,
+   title VARCHAR(255) NOT NULL,
+   file_name VARCHAR(255) NOT NULL,
+   content_type VARCHAR(100) NOT NULL,
+   s3_key VARCHAR(500) NOT NULL,
+   visibility ENUM('trip', 'private') NOT NULL DEFAULT 'trip',
+   trip_item_id INT NULL,
+   trip_booking_id INT NULL,
+   CONSTRAINT fk_trip_trip_document FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+   CONSTRAINT fk_trip_documents_trip_item FOREIGN KEY (trip_item_id) REFERENCES trip_items(id) ON DELETE SET NULL,
+   CONSTRAINT fk_trip_documents_trip_booking FOREIGN KEY (trip_booking_id) REFERENCES trip_bookings(id) ON DELETE SET NULL,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+   deleted_at TIMESTAMP NULL DEFAULT NULL
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS trip_checklist_items;
+DROP TABLE IF EXISTS trip_checklists;
@@ -0,0 +1,29 @@
+CREATE TABLE trip_checklists (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   trip_id INT NOT NULL,
+   created_by INT NOT NULL,
+   type ENUM('packing', 'todo') NOT NULL,
+   title VARCHAR(255) NOT NULL,
+   CONSTRAINT fk_trip_trip_checklist FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+   deleted_at TIMESTAMP NULL DEFAULT NULL
+);
+
+CREATE TABLE trip_checklist_items (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   checklist_id INT NOT NULL,
+   trip_id INT NOT NULL,
+   created_by INT NOT NULL,
+   title VARCHAR(255) NOT NULL,
+   assignee_id INT NULL,
+   due_date DATE NULL,
+   is_done BOOLEAN NOT NULL DEFAULT FALSE,
+   done_by INT NULL,
+   done_at TIMESTAMP NULL,
+   CONSTRAINT fk_trip_checklist_item_checklist FOREIGN KEY (checklist_id) REFERENCES trip_checklists(id) ON DELETE CASCADE,
+   CONSTRAINT fk_trip_checklist_item_trip FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+   deleted_at TIMESTAMP NULL DEFAULT NULL
+);
@@ -0,0 +1,9 @@
+ALTER TABLE notifications 
+MODIFY COLUMN type ENUM(
+    'friendRequestReceived',
+    'friendRequestAccepted',
+    'tripInvitationReceived',
+    'tripGenerated',
+    'tripGeneratedFailed',
+    'tripStartingSoon'
+) NOT NULL;
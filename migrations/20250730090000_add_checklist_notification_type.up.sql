@@ -0,0 +1,10 @@
+ALTER TABLE notifications 
+MODIFY COLUMN type ENUM(
+    'friendRequestReceived',
+    'friendRequestAccepted',
+    'tripInvitationReceived',
+    'tripGenerated',
+    'tripGeneratedFailed',
+    'tripStartingSoon',
+    'checklistItemsPending'
+) NOT NULL;
@@ -0,0 +1,12 @@
+ALTER TABLE notifications 
+MODIFY COLUMN type ENUM(
+    'friendRequestReceived',
+    'friendRequestAccepted',
+    'tripInvitationReceived',
+    'tripGenerated',
+    'tripGeneratedFailed',
+    'tripStartingSoon',
+    'checklistItemsPending'
+) NOT NULL;
+
+DROP TABLE IF EXISTS trip_comments;
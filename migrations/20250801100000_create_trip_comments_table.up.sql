@@ -0,0 +1,27 @@
+CREATE TABLE trip_comments (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   trip_id INT NOT NULL,
+   trip_item_id INT NULL,
+   parent_id INT NULL,
+   author_id INT NOT NULL,
+   content TEXT NOT NULL,
+   edited_at TIMESTAMP NULL DEFAULT NULL,
+   CONSTRAINT fk_trip_trip_comment FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+   CONSTRAINT fk_trip_comments_trip_item FOREIGN KEY (trip_item_id) REFERENCES trip_items(id) ON DELETE CASCADE,
+   CONSTRAINT fk_trip_comments_parent FOREIGN KEY (parent_id) REFERENCES trip_comments(id) ON DELETE CASCADE,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+   deleted_at TIMESTAMP NULL DEFAULT NULL
+);
+
+ALTER TABLE notifications 
+MODIFY COLUMN type ENUM(
+    'friendRequestReceived',
+    'friendRequestAccepted',
+    'tripInvitationReceived',
+    'tripGenerated',
+    'tripGeneratedFailed',
+    'tripStartingSoon',
+    'checklistItemsPending',
+    'tripCommentMention'
+) NOT NULL;
@@ -0,0 +1,15 @@
+ALTER TABLE notifications 
+MODIFY COLUMN type ENUM(
+    'friendRequestReceived',
+    'friendRequestAccepted',
+    'tripInvitationReceived',
+    'tripGenerated',
+    'tripGeneratedFailed',
+    'tripStartingSoon',
+    'checklistItemsPending',
+    'tripCommentMention'
+) NOT NULL;
+
+DROP TABLE IF EXISTS trip_poll_votes;
+DROP TABLE IF EXISTS trip_poll_options;
+DROP TABLE IF EXISTS trip_polls;
@@ -0,0 +1,53 @@
+CREATE TABLE trip_polls (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   trip_id INT NOT NULL,
+   created_by INT NOT NULL,
+   question VARCHAR(500) NOT NULL,
+   option_type ENUM('text', 'place') NOT NULL,
+   allow_multiple BOOLEAN NOT NULL DEFAULT FALSE,
+   is_anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+   deadline TIMESTAMP NULL DEFAULT NULL,
+   status ENUM('open', 'closed') NOT NULL DEFAULT 'open',
+   closed_at TIMESTAMP NULL DEFAULT NULL,
+   apply_to_trip_item_id INT NULL,
+   winning_option_id INT NULL,
+   CONSTRAINT fk_trip_trip_poll FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+   CONSTRAINT fk_trip_polls_trip_item FOREIGN KEY (apply_to_trip_item_id) REFERENCES trip_items(id) ON DELETE SET NULL,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+   deleted_at TIMESTAMP NULL DEFAULT NULL
+);
+
+CREATE TABLE trip_poll_options (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   poll_id INT NOT NULL,
+   label VARCHAR(255) NOT NULL,
+   place_id VARCHAR(255) NULL,
+   option_order INT NOT NULL,
+   CONSTRAINT fk_trip_poll_option_poll FOREIGN KEY (poll_id) REFERENCES trip_polls(id) ON DELETE CASCADE
+);
+
+CREATE TABLE trip_poll_votes (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   poll_id INT NOT NULL,
+   option_id INT NOT NULL,
+   user_id INT NOT NULL,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   UNIQUE KEY uq_trip_poll_vote (option_id, user_id),
+   CONSTRAINT fk_trip_poll_vote_poll FOREIGN KEY (poll_id) REFERENCES trip_polls(id) ON DELETE CASCADE,
+   CONSTRAINT fk_trip_poll_vote_option FOREIGN KEY (option_id) REFERENCES trip_poll_options(id) ON DELETE CASCADE
+);
+
+ALTER TABLE notifications 
+MODIFY COLUMN type ENUM(
+    'friendRequestReceived',
+    'friendRequestAccepted',
+    'tripInvitationReceived',
+    'tripGenerated',
+    'tripGeneratedFailed',
+    'tripStartingSoon',
+    'checklistItemsPending',
+    'tripCommentMention',
+    'tripPollOpened',
+    'tripPollClosed'
+) NOT NULL;
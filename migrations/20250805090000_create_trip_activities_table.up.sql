@@ -0,0 +1,13 @@
+CREATE TABLE trip_activities (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   trip_id INT NOT NULL,
+   actor_id INT NULL,
+   action VARCHAR(50) NOT NULL,
+   entity_type VARCHAR(30) NOT NULL,
+   entity_id INT NULL,
+   before_value JSON NULL,
+   after_value JSON NULL,
+   CONSTRAINT fk_trip_trip_activity FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+   INDEX idx_trip_activities_trip (trip_id, id),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,5 @@
+UPDATE trip_members SET role = 'administrator' WHERE role = 'owner';
+UPDATE trip_members SET role = 'member' WHERE role IN ('editor', 'viewer');
+
+ALTER TABLE trip_members
+  MODIFY COLUMN role ENUM('administrator', 'member') NOT NULL;
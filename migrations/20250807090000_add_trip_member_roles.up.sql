@@ -0,0 +1,12 @@
+ALTER TABLE trip_members
+  MODIFY COLUMN role ENUM('owner', 'administrator', 'editor', 'member', 'viewer') NOT NULL;
+
+-- the earliest administrator of each trip is its creator, who becomes the owner
+UPDATE trip_members tm
+JOIN (
+  SELECT trip_id, MIN(id) AS id
+  FROM trip_members
+  WHERE role = 'administrator' AND deleted_at IS NULL
+  GROUP BY trip_id
+) first_admin ON tm.id = first_admin.id
+SET tm.role = 'owner';
@@ -0,0 +1,15 @@
+DELETE FROM notifications WHERE type IN ('tripMemberLeft', 'tripOwnershipTransferred');
+
+ALTER TABLE notifications 
+MODIFY COLUMN type ENUM(
+    'friendRequestReceived',
+    'friendRequestAccepted',
+    'tripInvitationReceived',
+    'tripGenerated',
+    'tripGeneratedFailed',
+    'tripStartingSoon',
+    'checklistItemsPending',
+    'tripCommentMention',
+    'tripPollOpened',
+    'tripPollClosed'
+) NOT NULL;
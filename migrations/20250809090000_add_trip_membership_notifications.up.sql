@@ -0,0 +1,15 @@
+ALTER TABLE notifications 
+MODIFY COLUMN type ENUM(
+    'friendRequestReceived',
+    'friendRequestAccepted',
+    'tripInvitationReceived',
+    'tripGenerated',
+    'tripGeneratedFailed',
+    'tripStartingSoon',
+    'checklistItemsPending',
+    'tripCommentMention',
+    'tripPollOpened',
+    'tripPollClosed',
+    'tripMemberLeft',
+    'tripOwnershipTransferred'
+) NOT NULL;
@@ -0,0 +1,44 @@
+DELETE FROM notifications WHERE type IN ('tripCancelled', 'tripArchived', 'tripUnarchived');
+
+ALTER TABLE notifications 
+MODIFY COLUMN type ENUM(
+    'friendRequestReceived',
+    'friendRequestAccepted',
+    'tripInvitationReceived',
+    'tripGenerated',
+    'tripGeneratedFailed',
+    'tripStartingSoon',
+    'checklistItemsPending',
+    'tripCommentMention',
+    'tripPollOpened',
+    'tripPollClosed',
+    'tripMemberLeft',
+    'tripOwnershipTransferred'
+) NOT NULL;
+
+UPDATE trips SET status = COALESCE(status_before_archive, 'completed') WHERE status = 'archived';
+UPDATE trips SET status = 'not_started' WHERE status = 'draft';
+
+ALTER TABLE trips 
+MODIFY COLUMN status ENUM(
+  'not_started',
+  'in_progress',
+  'completed',
+  'cancel',
+  'cancelled',
+  'ai_generating',
+  'failed'
+) NOT NULL DEFAULT 'not_started';
+
+UPDATE trips SET status = 'cancel' WHERE status = 'cancelled';
+
+ALTER TABLE trips 
+MODIFY COLUMN status ENUM(
+  'not_started',
+  'in_progress',
+  'completed',
+  'cancel',
+  'ai_generating',
+  'failed'
+) NOT NULL DEFAULT 'not_started',
+DROP COLUMN status_before_archive;
@@ -0,0 +1,46 @@
+ALTER TABLE trips 
+MODIFY COLUMN status ENUM(
+  'not_started',
+  'in_progress',
+  'completed',
+  'cancel',
+  'cancelled',
+  'ai_generating',
+  'failed',
+  'draft',
+  'archived'
+) NOT NULL DEFAULT 'not_started';
+
+UPDATE trips SET status = 'cancelled' WHERE status = 'cancel';
+
+ALTER TABLE trips 
+MODIFY COLUMN status ENUM(
+  'draft',
+  'ai_generating',
+  'failed',
+  'not_started',
+  'in_progress',
+  'completed',
+  'cancelled',
+  'archived'
+) NOT NULL DEFAULT 'not_started',
+ADD COLUMN status_before_archive VARCHAR(20) NULL AFTER status;
+
+ALTER TABLE notifications 
+MODIFY COLUMN type ENUM(
+    'friendRequestReceived',
+    'friendRequestAccepted',
+    'tripInvitationReceived',
+    'tripGenerated',
+    'tripGeneratedFailed',
+    'tripStartingSoon',
+    'checklistItemsPending',
+    'tripCommentMention',
+    'tripPollOpened',
+    'tripPollClosed',
+    'tripMemberLeft',
+    'tripOwnershipTransferred',
+    'tripCancelled',
+    'tripArchived',
+    'tripUnarchived'
+) NOT NULL;
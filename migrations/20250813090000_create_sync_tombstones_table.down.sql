@@ -0,0 +1,3 @@
+ALTER TABLE trip_members DROP INDEX idx_trip_members_user_updated_at;
+
+DROP TABLE IF EXISTS sync_tombstones;
@@ -0,0 +1,13 @@
+CREATE TABLE sync_tombstones (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   entity_type VARCHAR(30) NOT NULL,
+   entity_id INT NOT NULL,
+   trip_id INT NULL,
+   user_id INT NULL,
+   deleted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   INDEX idx_sync_tombstones_trip (trip_id, deleted_at),
+   INDEX idx_sync_tombstones_user (user_id, deleted_at),
+   INDEX idx_sync_tombstones_deleted_at (deleted_at)
+);
+
+ALTER TABLE trip_members ADD INDEX idx_trip_members_user_updated_at (user_id, updated_at);
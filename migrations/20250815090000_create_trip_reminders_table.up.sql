@@ -0,0 +1,9 @@
+CREATE TABLE trip_reminders (
+   id INT AUTO_INCREMENT PRIMARY KEY,
+   trip_id INT NOT NULL,
+   type VARCHAR(30) NOT NULL,
+   start_date TIMESTAMP NOT NULL,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   UNIQUE KEY uq_trip_reminder (trip_id, type, start_date),
+   CONSTRAINT fk_trip_trip_reminder FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE
+);